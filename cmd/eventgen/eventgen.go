@@ -0,0 +1,117 @@
+// Eventgen generates Go structs for SparkPost webhook events from the Event Documentation API,
+// and reports where the hand-written structs in the events package have drifted from it.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/pkg/errors"
+)
+
+func main() {
+	var docsFile = flag.String("docs", "", "saved documentation json, or - for stdin (default: fetch from the api)")
+	var outDir = flag.String("out", "", "directory to write generated code and tests to")
+	var pkg = flag.String("pkg", "", "package name for generated code (default: base name of -out)")
+	var stale = flag.Bool("stale", false, "report differences from the hand-written structs, exiting 1 if there are any")
+	flag.Parse()
+
+	if *outDir == "" && !*stale {
+		log.Fatal("FATAL: at least one of -out and -stale is required\n")
+	}
+
+	groups, err := loadDocs(*docsFile)
+	if err != nil {
+		log.Fatalf("FATAL: %s\n", err)
+	}
+	types := buildTypes(groups)
+
+	if *outDir != "" {
+		if *pkg == "" {
+			abs, err := filepath.Abs(*outDir)
+			if err != nil {
+				log.Fatalf("FATAL: %s\n", err)
+			}
+			*pkg = filepath.Base(abs)
+		}
+		if err = generate(*outDir, *pkg, types); err != nil {
+			log.Fatalf("FATAL: %s\n", err)
+		}
+	}
+
+	if *stale {
+		drift := staleReport(os.Stdout, types)
+		if drift {
+			os.Exit(1)
+		}
+	}
+}
+
+// loadDocs reads documentation saved from the API, with or without the "results" wrapper,
+// or fetches it using the configuration from the environment.
+func loadDocs(path string) (map[string]*sp.EventGroup, error) {
+	if path == "" {
+		cfg, err := sp.ConfigFromEnv()
+		if err != nil {
+			return nil, err
+		}
+		client := &sp.Client{}
+		if err = client.Init(cfg); err != nil {
+			return nil, err
+		}
+		groups, _, err := client.EventDocumentation()
+		return groups, err
+	}
+
+	var body []byte
+	var err error
+	if path == "-" {
+		body, err = io.ReadAll(os.Stdin)
+	} else {
+		body, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var wrapper struct {
+		Results map[string]*sp.EventGroup `json:"results"`
+	}
+	if err = json.Unmarshal(body, &wrapper); err != nil {
+		return nil, errors.Wrap(err, "parsing documentation")
+	} else if wrapper.Results != nil {
+		return wrapper.Results, nil
+	}
+	groups := map[string]*sp.EventGroup{}
+	if err = json.Unmarshal(body, &groups); err != nil {
+		return nil, errors.Wrap(err, "parsing documentation")
+	}
+	return groups, nil
+}
+
+// generate writes the structs and their tests to dir.
+func generate(dir, pkg string, types []*eventType) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+	for name, tmpl := range map[string]string{
+		"events_gen.go":      codeTemplate,
+		"events_gen_test.go": testTemplate,
+	} {
+		src, err := render(tmpl, pkg, types)
+		if err != nil {
+			return errors.Wrap(err, name)
+		}
+		if err = os.WriteFile(filepath.Join(dir, name), src, 0644); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "wrote %s\n", filepath.Join(dir, name))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/SparkPost/gosparkpost/events"
+)
+
+// staleReport compares the documented events with the hand-written structs in the events package,
+// listing fields that are documented but not handled, and handled fields that are no longer documented.
+// It returns true if anything was reported.
+func staleReport(w io.Writer, types []*eventType) (drift bool) {
+	documented := map[string]bool{}
+	for _, t := range types {
+		documented[t.Name] = true
+		e := events.EventForName(t.Name)
+		if _, ok := e.(*events.Unknown); ok {
+			fmt.Fprintf(w, "%s: no struct in the events package\n", t.Name)
+			drift = true
+			continue
+		}
+
+		have := handWrittenFields(e)
+		var missing, stale []string
+		for _, f := range t.Fields {
+			if _, ok := have[f.Tag]; !ok {
+				missing = append(missing, f.Tag)
+			}
+		}
+		for tag := range have {
+			if !fieldsInCommon[tag] && !t.HasField(tag) {
+				stale = append(stale, tag)
+			}
+		}
+		sort.Strings(stale)
+
+		if len(missing) > 0 {
+			fmt.Fprintf(w, "%s: missing %s\n", t.Name, strings.Join(missing, ", "))
+			drift = true
+		}
+		if len(stale) > 0 {
+			fmt.Fprintf(w, "%s: stale %s\n", t.Name, strings.Join(stale, ", "))
+			drift = true
+		}
+	}
+
+	for _, name := range knownEvents() {
+		if !documented[name] {
+			fmt.Fprintf(w, "%s: not documented\n", name)
+			drift = true
+		}
+	}
+	return drift
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/SparkPost/gosparkpost/events"
+)
+
+// eventType is an event described by the documentation.
+type eventType struct {
+	Name        string
+	Group       string
+	GoName      string
+	Description string
+	Fields      []*eventField
+}
+
+// eventField is a field of an eventType, sorted by Tag.
+type eventField struct {
+	Tag         string
+	GoName      string
+	GoType      string
+	Description string
+	Sample      interface{}
+}
+
+// HasField returns true if the event has a field with the provided tag.
+func (t *eventType) HasField(tag string) bool {
+	for _, f := range t.Fields {
+		if f.Tag == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Sample returns a JSON object built from the documented sample values.
+func (t *eventType) Sample() string {
+	obj := map[string]interface{}{"type": t.Name}
+	for _, f := range t.Fields {
+		obj[f.Tag] = f.Sample
+	}
+	return string(mustMarshal(obj))
+}
+
+// Timestamps returns the tags of the fields with a Timestamp type.
+func (t *eventType) Timestamps() []string {
+	var tags []string
+	for _, f := range t.Fields {
+		if f.GoType == "events.Timestamp" {
+			tags = append(tags, f.Tag)
+		}
+	}
+	return tags
+}
+
+// fieldsInCommon are part of events.EventCommon.
+var fieldsInCommon = map[string]bool{"type": true, "event_id": true}
+
+// buildTypes converts the documentation into eventTypes, sorted by name.
+func buildTypes(groups map[string]*sp.EventGroup) []*eventType {
+	names := handWrittenNames()
+	var types []*eventType
+	for groupName, group := range groups {
+		for name, meta := range group.Events {
+			t := &eventType{
+				Name:        name,
+				Group:       groupName,
+				GoName:      goName(name),
+				Description: oneLine(meta.Description),
+			}
+			used := map[string]bool{"EventCommon": true, "Type": true, "EventID": true}
+			for tag, doc := range meta.Fields {
+				if fieldsInCommon[tag] {
+					continue
+				}
+				f := &eventField{
+					Tag:         tag,
+					GoName:      names[tag],
+					GoType:      goType(tag, doc.SampleValue),
+					Description: oneLine(doc.Description),
+					Sample:      doc.SampleValue,
+				}
+				if f.GoName == "" || used[f.GoName] {
+					f.GoName = goName(tag)
+				}
+				used[f.GoName] = true
+				t.Fields = append(t.Fields, f)
+			}
+			sort.Slice(t.Fields, func(i, j int) bool { return t.Fields[i].Tag < t.Fields[j].Tag })
+			types = append(types, t)
+		}
+	}
+	sort.Slice(types, func(i, j int) bool { return types[i].Name < types[j].Name })
+	return types
+}
+
+// initialisms are upper-cased when converting names to Go identifiers.
+var initialisms = map[string]bool{
+	"ab": true, "amp": true, "dr": true, "id": true, "ids": true, "ip": true, "npi": true,
+	"sms": true, "tls": true, "ton": true, "url": true,
+}
+
+// goName converts a snake_case name to a Go identifier.
+func goName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		if part == "" {
+			continue
+		} else if part == "ids" {
+			b.WriteString("IDs")
+		} else if initialisms[part] {
+			b.WriteString(strings.ToUpper(part))
+		} else {
+			b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+		}
+	}
+	return b.String()
+}
+
+// goType picks a Go type for a field, from its name and sample value.
+func goType(tag string, sample interface{}) string {
+	switch {
+	case tag == "timestamp" || tag == "injection_time" || strings.HasSuffix(tag, "_timestamp"):
+		return "events.Timestamp"
+	case tag == "geo_ip":
+		return "*events.GeoIP"
+	case tag == "subaccount_id":
+		return "events.Number"
+	}
+
+	switch v := sample.(type) {
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case float64:
+		if v == math.Trunc(v) {
+			return "events.Number"
+		}
+		return "float64"
+	case []interface{}:
+		for _, item := range v {
+			if _, ok := item.(string); !ok {
+				return "[]interface{}"
+			}
+		}
+		return "[]string"
+	case map[string]interface{}:
+		return "map[string]interface{}"
+	}
+	return "interface{}"
+}
+
+// handWrittenNames maps the JSON names used by the events package to its Go field names,
+// so generated structs can be swapped in with minimal changes.
+func handWrittenNames() map[string]string {
+	names := map[string]string{}
+	for _, name := range knownEvents() {
+		for tag, f := range handWrittenFields(events.EventForName(name)) {
+			if _, ok := names[tag]; !ok {
+				names[tag] = f.Name
+			}
+		}
+	}
+	return names
+}
+
+// knownEvents returns the event names the events package has structs for.
+func knownEvents() []string {
+	var names []string
+	for _, name := range []string{
+		"ab_test_cancelled", "ab_test_completed", "amp_click", "amp_initial_open", "amp_open",
+		"bounce", "click", "creation", "delay", "delivery", "generation_failure",
+		"generation_rejection", "ingest_error", "ingest_success", "initial_open", "injection",
+		"link_unsubscribe", "list_unsubscribe", "open", "out_of_band", "policy_rejection",
+		"relay_delivery", "relay_injection", "relay_message", "relay_permfail", "relay_rejection",
+		"relay_tempfail", "sms_status", "spam_complaint",
+	} {
+		if events.ValidEventType(name) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// handWrittenFields returns the fields of an event struct by JSON name, including embedded fields.
+func handWrittenFields(e events.Event) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	t := reflect.TypeOf(e)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.Anonymous && f.Type.Kind() == reflect.Struct {
+				walk(f.Type)
+				continue
+			}
+			tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if tag != "" && tag != "-" {
+				if _, ok := fields[tag]; !ok {
+					fields[tag] = f
+				}
+			}
+		}
+	}
+	if t.Kind() == reflect.Struct {
+		walk(t)
+	}
+	return fields
+}
+
+func oneLine(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+func mustMarshal(v interface{}) []byte {
+	b, err := jsonMarshal(v)
+	if err != nil {
+		panic(fmt.Sprintf("marshaling sample: %v", err))
+	}
+	return b
+}
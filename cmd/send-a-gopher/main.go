@@ -8,7 +8,6 @@ import (
 	"log"
 	"net/http"
 	"net/url"
-	"os"
 	"path"
 
 	gosp "github.com/SparkPost/gosparkpost"
@@ -52,7 +51,10 @@ func main() {
 		B64Data:  base64.StdEncoding.EncodeToString(body),
 	}
 
-	cfg := &gosp.Config{ApiKey: os.Getenv("SPARKPOST_API_KEY")}
+	cfg, err := gosp.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("SparkPost config failed: %s\n", err)
+	}
 	var sp gosp.Client
 	err = sp.Init(cfg)
 	if err != nil {
@@ -68,9 +68,12 @@ func main() {
 		log.Fatal("SUCCESS: send mail to nobody!\n")
 	}
 
-	apiKey := os.Getenv("SPARKPOST_API_KEY")
-	if strings.TrimSpace(apiKey) == "" && *dryrun == false {
-		log.Fatal("FATAL: API key not found in environment!\n")
+	cfg, err := sp.ConfigFromEnv()
+	if err != nil {
+		if *dryrun == false {
+			log.Fatalf("FATAL: %s\n", err)
+		}
+		cfg = &sp.Config{}
 	}
 
 	hasHtml := strings.TrimSpace(*htmlFlag) != ""
@@ -86,7 +89,6 @@ func main() {
 		log.Fatal("FATAL: must specify one of --html or --text!\n")
 	}
 
-	cfg := &sp.Config{ApiKey: apiKey}
 	if strings.TrimSpace(*url) != "" {
 		if !strings.HasPrefix(*url, "https://") {
 			log.Fatal("FATAL: base url must be https!\n")
@@ -98,7 +100,7 @@ func main() {
 	}
 
 	var sparky sp.Client
-	err := sparky.Init(cfg)
+	err = sparky.Init(cfg)
 	if err != nil {
 		log.Fatalf("SparkPost client init failed: %s\n", err)
 	}
@@ -0,0 +1,87 @@
+// Sptail prints SparkPost message events as they happen, like `tail -f` for your mail stream.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/SparkPost/gosparkpost/events"
+)
+
+func main() {
+	var types = flag.String("events", "", "comma-separated event types to follow (default: all)")
+	var campaigns = flag.String("campaigns", "", "comma-separated campaign ids to follow")
+	var recipients = flag.String("recipients", "", "comma-separated recipients to follow")
+	var format = flag.String("format", "string", "output format: string, eclog or json")
+	var since = flag.Duration("since", 0, "start this long ago (default: one window ago)")
+	var interval = flag.Duration("interval", sp.DefaultFollowInterval, "time between requests")
+	var window = flag.Duration("window", sp.DefaultFollowWindow, "how far back each request looks for late events")
+	var checkpoint = flag.String("checkpoint", "", "file to save progress to, so a restart resumes where it left off")
+	flag.Parse()
+
+	if *format != "string" && *format != "eclog" && *format != "json" {
+		log.Fatalf("FATAL: unsupported format [%s]\n", *format)
+	}
+
+	cfg, err := sp.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("FATAL: %s\n", err)
+	}
+	var client sp.Client
+	if err = client.Init(cfg); err != nil {
+		log.Fatalf("SparkPost client init failed: %s\n", err)
+	}
+
+	follow := sp.EventsFollow{
+		Params:   map[string]string{},
+		Interval: *interval,
+		Window:   *window,
+		OnError:  func(err error) { log.Printf("ERROR: %s\n", err) },
+	}
+	for param, value := range map[string]string{"events": *types, "campaign_ids": *campaigns, "recipients": *recipients} {
+		if value != "" {
+			follow.Params[param] = value
+		}
+	}
+	if *since > 0 {
+		follow.Since = time.Now().Add(-*since)
+	}
+	if *checkpoint != "" {
+		follow.Checkpoint = sp.FileCheckpoint{Path: *checkpoint}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	ch, err := client.FollowMessageEvents(ctx, follow)
+	if err != nil {
+		log.Fatalf("FATAL: %s\n", err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	for e := range ch {
+		switch *format {
+		case "json":
+			if err = enc.Encode(e); err != nil {
+				log.Printf("ERROR: %s\n", err)
+			}
+		case "eclog":
+			// Types without a log line of their own fall back to their summary.
+			if line := events.ECLog(e); line != "" {
+				fmt.Println(line)
+				continue
+			}
+			fallthrough
+		default:
+			fmt.Println(strings.TrimRight(fmt.Sprint(e), "\n"))
+		}
+	}
+}
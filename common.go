@@ -5,25 +5,33 @@ import (
 	"context"
 	"encoding/base64"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"log/slog"
 	"mime"
 	"net/http"
 	"net/http/httputil"
 	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
 // Config includes all information necessary to make an API request.
+// BaseUrl defaults to the API of the configured Region, which defaults to RegionUS.
 type Config struct {
 	BaseUrl    string
+	Region     Region
 	ApiKey     string
 	Username   string
 	Password   string
 	ApiVersion int
 	Verbose    bool
+
+	// Retry controls automatic retries in DoRequest. Requests are attempted once when it is nil.
+	Retry *RetryPolicy
 }
 
 // Client contains connection, configuration, and authentication information.
@@ -31,10 +39,18 @@ type Config struct {
 // Clients are safe for concurrent (read-only) reuse by multiple goroutines.
 // Headers is useful to set subaccount (X-MSYS-SUBACCOUNT header) and any other custom headers.
 // All changes to Headers must happen before Client is exposed to possible concurrent use.
+// RateLimits optionally throttles requests per EndpointFamily, with FamilyDefault covering any
+// family that isn't listed. Its limiters are shared by all goroutines using the Client.
+// Middleware wraps every request sent; see Use.
+// When Logger is set, each attempt at a request is logged as described in LogOptions.
 type Client struct {
-	Config  *Config
-	Client  *http.Client
-	Headers *http.Header
+	Config     *Config
+	Client     *http.Client
+	Headers    *http.Header
+	RateLimits map[EndpointFamily]*RateLimiter
+	Middleware []Middleware
+	Logger     *slog.Logger
+	LogOptions LogOptions
 
 	macros map[string]Macro
 }
@@ -42,13 +58,22 @@ type Client struct {
 var nonDigit *regexp.Regexp = regexp.MustCompile(`\D`)
 
 // NewConfig builds a Config object using the provided map.
+// Either baseurl or region is required, as is apikey.
+// The optional keys apiver, username, password and verbose are also recognized.
 func NewConfig(m map[string]string) (*Config, error) {
 	c := &Config{}
 
-	if baseurl, ok := m["baseurl"]; ok {
-		c.BaseUrl = baseurl
-	} else {
-		return nil, errors.New("BaseUrl is required for api config")
+	baseurl, hasBaseurl := m["baseurl"]
+	region, hasRegion := m["region"]
+	if !hasBaseurl && !hasRegion {
+		return nil, errors.New("BaseUrl or Region is required for api config")
+	}
+	c.BaseUrl = baseurl
+	c.Region = Region(region)
+	if hasRegion {
+		if _, err := c.Region.BaseUrl(); err != nil {
+			return nil, err
+		}
 	}
 
 	if apikey, ok := m["apikey"]; ok {
@@ -57,6 +82,23 @@ func NewConfig(m map[string]string) (*Config, error) {
 		return nil, errors.New("ApiKey is required for api config")
 	}
 
+	if apiver, ok := m["apiver"]; ok {
+		ver, err := strconv.Atoi(apiver)
+		if err != nil {
+			return nil, errors.New("ApiVersion must be an integer")
+		}
+		c.ApiVersion = ver
+	}
+	if verbose, ok := m["verbose"]; ok {
+		v, err := strconv.ParseBool(verbose)
+		if err != nil {
+			return nil, errors.New("Verbose must be a boolean")
+		}
+		c.Verbose = v
+	}
+	c.Username = m["username"]
+	c.Password = m["password"]
+
 	return c, nil
 }
 
@@ -72,8 +114,8 @@ type Response struct {
 }
 
 // HTTPError returns nil when the HTTP response code is in the range 200-299.
-// If the API has returned a JSON error in the expected format, return that.
-// Otherwise, return an error containing the HTTP code and response body.
+// Otherwise it returns an *APIError, which wraps the JSON errors returned by the API, if any,
+// or an error containing the HTTP code and response body.
 func (res *Response) HTTPError() error {
 	if res == nil {
 		return errors.New("Internal error: Response may not be nil")
@@ -83,15 +125,26 @@ func (res *Response) HTTPError() error {
 
 	if Is2XX(res.HTTP.StatusCode) {
 		return nil
-	} else if len(res.Errors) > 0 {
-		return res.Errors
 	}
 
-	return SPErrors{{
-		Code:        ErrorCode(res.HTTP.Status),
-		Message:     string(res.Body),
-		Description: "HTTP/JSON Error",
-	}}
+	apiErr := &APIError{Response: res, StatusCode: res.HTTP.StatusCode, Errors: res.Errors}
+	if len(apiErr.Errors) == 0 && len(res.Body) > 0 {
+		// The body may not have gone through ParseResponse yet.
+		var parsed struct {
+			Errors SPErrors `json:"errors"`
+		}
+		if json.Unmarshal(res.Body, &parsed) == nil {
+			apiErr.Errors = parsed.Errors
+		}
+	}
+	if len(apiErr.Errors) == 0 {
+		apiErr.Errors = SPErrors{{
+			Code:        ErrorCode(res.HTTP.Status),
+			Message:     string(res.Body),
+			Description: "HTTP/JSON Error",
+		}}
+	}
+	return apiErr
 }
 
 // SPErrors is the plural of SPError
@@ -139,7 +192,11 @@ func (e SPErrors) Error() string {
 func (c *Client) Init(cfg *Config) error {
 	// Set default values
 	if cfg.BaseUrl == "" {
-		cfg.BaseUrl = "https://api.sparkpost.com"
+		baseUrl, err := cfg.Region.BaseUrl()
+		if err != nil {
+			return err
+		}
+		cfg.BaseUrl = baseUrl
 	} else if !strings.HasPrefix(cfg.BaseUrl, "https://") {
 		return errors.New("API base url must be https!")
 	}
@@ -221,6 +278,8 @@ func (c *Client) HttpDelete(ctx context.Context, url string) (*Response, error)
 	return c.DoRequest(ctx, "DELETE", url, nil)
 }
 
+// DoRequest sends a request using the configured authentication and headers.
+// Failed requests are retried according to Config.Retry, within any deadline set on the context.
 func (c *Client) DoRequest(ctx context.Context, method, urlStr string, data []byte) (*Response, error) {
 	if c == nil {
 		return nil, errors.New("Client must be non-nil!")
@@ -230,9 +289,8 @@ func (c *Client) DoRequest(ctx context.Context, method, urlStr string, data []by
 		return nil, errors.New("Client.Config must be non-nil!")
 	}
 
-	req, err := http.NewRequest(method, urlStr, bytes.NewBuffer(data))
-	if err != nil {
-		return nil, errors.Wrap(err, "building request")
+	if ctx == nil {
+		ctx = context.Background()
 	}
 
 	ares := &Response{}
@@ -242,13 +300,96 @@ func (c *Client) DoRequest(ctx context.Context, method, urlStr string, data []by
 		}
 		ares.Verbose["http_method"] = method
 		ares.Verbose["http_uri"] = urlStr
+		if data != nil {
+			ares.Verbose["http_postdata"] = string(redactBody(data, c.LogOptions.redactFields()))
+		}
 	}
-	if data != nil {
-		req.Header.Set("Content-Type", "application/json")
+
+	var requestID string
+	if c.Logger != nil {
+		requestID = newRequestID()
+	}
+
+	for attempt := 1; ; attempt++ {
+		// The request body can only be read once, so each attempt gets a fresh request.
+		req, err := c.newRequest(ctx, method, urlStr, data)
+		if err != nil {
+			if attempt == 1 {
+				return nil, err
+			}
+			return ares, err
+		}
 
 		if c.Config.Verbose {
-			ares.Verbose["http_postdata"] = string(data)
+			reqBytes, err := httputil.DumpRequestOut(req, false)
+			if err != nil {
+				return ares, errors.Wrap(err, "saving request")
+			}
+			ares.Verbose["http_requestdump"] = string(redactRequestDump(reqBytes, req))
+		}
+
+		if err := c.rateLimiter(urlStr).Wait(ctx); err != nil {
+			return ares, errors.Wrap(err, "waiting for rate limiter")
+		}
+
+		start := time.Now()
+		res, err := c.roundTrip(req)
+		latency := time.Since(start)
+		ares.HTTP = res
+		ares.Body = nil
+
+		if c.Config.Verbose && res != nil {
+			ares.Verbose["http_status"] = ares.HTTP.Status
+			bodyBytes, dumpErr := httputil.DumpResponse(res, true)
+			if dumpErr != nil {
+				ares.Verbose["http_responsedump_err"] = dumpErr.Error()
+			} else {
+				ares.Verbose["http_responsedump"] = string(bodyBytes)
+			}
 		}
+
+		c.logAttempt(ctx, ares, req, data, requestID, attempt, latency, err)
+
+		wait, retry := c.Config.Retry.backoff(attempt, method, res, err)
+		if retry && ctx.Err() != nil {
+			retry = false
+		}
+		if deadline, ok := ctx.Deadline(); retry && ok && time.Now().Add(wait).After(deadline) {
+			// Waiting would blow the deadline, so return what we have.
+			retry = false
+		}
+
+		if c.Config.Verbose {
+			ares.Verbose[fmt.Sprintf("http_attempt_%d", attempt)] = attemptSummary(res, err, wait, retry)
+		}
+
+		if !retry {
+			if err != nil {
+				return ares, errors.Wrap(err, "error response")
+			}
+			return ares, nil
+		}
+
+		if res != nil {
+			// Drain and close the body so the connection can be reused.
+			// It stays cached in ares.Body in case the wait is cut short.
+			ares.ReadBody()
+		}
+		if err := sleepContext(ctx, wait); err != nil {
+			return ares, errors.Wrap(err, "waiting to retry")
+		}
+	}
+}
+
+// newRequest builds an http.Request with authentication and any configured headers.
+func (c *Client) newRequest(ctx context.Context, method, urlStr string, data []byte) (*http.Request, error) {
+	req, err := http.NewRequest(method, urlStr, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, errors.Wrap(err, "building request")
+	}
+
+	if data != nil {
+		req.Header.Set("Content-Type", "application/json")
 	}
 
 	// TODO: set User-Agent based on gosparkpost version and possibly git's short hash
@@ -269,11 +410,8 @@ func (c *Client) DoRequest(ctx context.Context, method, urlStr string, data []by
 		}
 	}
 
-	if ctx == nil {
-		ctx = context.Background()
-	}
 	// set any headers provided in context
-	if header, ok := ctx.Value("http.Header").(http.Header); ok {
+	if header, ok := HeaderFromContext(ctx); ok {
 		for key, vals := range map[string][]string(header) {
 			req.Header.Del(key)
 			for _, val := range vals {
@@ -281,33 +419,22 @@ func (c *Client) DoRequest(ctx context.Context, method, urlStr string, data []by
 			}
 		}
 	}
-	req = req.WithContext(ctx)
-
-	if c.Config.Verbose {
-		reqBytes, err := httputil.DumpRequestOut(req, false)
-		if err != nil {
-			return ares, errors.Wrap(err, "saving request")
-		}
-		ares.Verbose["http_requestdump"] = string(reqBytes)
-	}
-
-	res, err := c.Client.Do(req)
-	ares.HTTP = res
 
-	if c.Config.Verbose && res != nil {
-		ares.Verbose["http_status"] = ares.HTTP.Status
-		bodyBytes, dumpErr := httputil.DumpResponse(res, true)
-		if dumpErr != nil {
-			ares.Verbose["http_responsedump_err"] = dumpErr.Error()
-		} else {
-			ares.Verbose["http_responsedump"] = string(bodyBytes)
-		}
-	}
+	return req.WithContext(ctx), nil
+}
 
+// attemptSummary describes the outcome of one attempt, for Response.Verbose.
+func attemptSummary(res *http.Response, err error, wait time.Duration, retry bool) string {
+	var summary string
 	if err != nil {
-		return ares, errors.Wrap(err, "error response")
+		summary = err.Error()
+	} else if res != nil {
+		summary = res.Status
+	}
+	if retry {
+		summary += fmt.Sprintf(" (retrying in %s)", wait)
 	}
-	return ares, nil
+	return summary
 }
 
 // Is2XX returns true if the provided HTTP response code is in the range 200-299.
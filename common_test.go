@@ -67,9 +67,13 @@ func TestNewConfig(t *testing.T) {
 		cfg *sp.Config
 		err error
 	}{
-		{map[string]string{}, nil, errors.New("BaseUrl is required for api config")},
+		{map[string]string{}, nil, errors.New("BaseUrl or Region is required for api config")},
+		{map[string]string{"region": "mars", "apikey": "foo"}, nil, errors.New(`unknown region "mars"`)},
 		{map[string]string{"baseurl": "http://example.com"}, nil, errors.New("ApiKey is required for api config")},
 		{map[string]string{"baseurl": "http://example.com", "apikey": "foo"}, &sp.Config{BaseUrl: "http://example.com", ApiKey: "foo"}, nil},
+		{map[string]string{"region": "eu", "apikey": "foo", "apiver": "1", "verbose": "true"},
+			&sp.Config{Region: sp.RegionEU, ApiKey: "foo", ApiVersion: 1, Verbose: true}, nil},
+		{map[string]string{"region": "eu", "apikey": "foo", "apiver": "one"}, nil, errors.New("ApiVersion must be an integer")},
 	} {
 		cfg, err := sp.NewConfig(test.in)
 		if err == nil && test.err != nil || err != nil && test.err == nil {
@@ -78,6 +82,8 @@ func TestNewConfig(t *testing.T) {
 			t.Errorf("NewConfig[%d] => err %q, want %q", idx, err, test.err)
 		} else if cfg == nil && test.cfg != nil || cfg != nil && test.cfg == nil {
 			t.Errorf("NewConfig[%d] => cfg %v, want %v", idx, cfg, test.cfg)
+		} else if cfg != nil && *cfg != *test.cfg {
+			t.Errorf("NewConfig[%d] => cfg %+v, want %+v", idx, cfg, test.cfg)
 		}
 	}
 }
@@ -174,6 +180,10 @@ func TestInit(t *testing.T) {
 	}{
 		{&sp.Client{}, &sp.Config{BaseUrl: ""}, &sp.Config{BaseUrl: "https://api.sparkpost.com"}, nil},
 		{&sp.Client{}, &sp.Config{BaseUrl: "http://api.sparkpost.com"}, nil, errors.New("API base url must be https!")},
+		{&sp.Client{}, &sp.Config{Region: sp.RegionEU}, &sp.Config{BaseUrl: "https://api.eu.sparkpost.com"}, nil},
+		{&sp.Client{}, &sp.Config{Region: "EU"}, &sp.Config{BaseUrl: "https://api.eu.sparkpost.com"}, nil},
+		{&sp.Client{}, &sp.Config{Region: sp.RegionEU, BaseUrl: "https://sp.example.com"}, &sp.Config{BaseUrl: "https://sp.example.com"}, nil},
+		{&sp.Client{}, &sp.Config{Region: "mars"}, nil, errors.New(`unknown region "mars"`)},
 	} {
 		err := test.api.Init(test.cfg)
 		if err == nil && test.err != nil || err != nil && test.err == nil {
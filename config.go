@@ -0,0 +1,196 @@
+package gosparkpost
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Region selects which SparkPost deployment a Client talks to.
+// To use some other API endpoint, set Config.BaseUrl, which takes precedence over Config.Region.
+type Region string
+
+// Regions with a SparkPost deployment.
+const (
+	RegionUS Region = "us"
+	RegionEU Region = "eu"
+)
+
+// RegionBaseUrls maps each Region to the base url of its API.
+var RegionBaseUrls = map[Region]string{
+	RegionUS: "https://api.sparkpost.com",
+	RegionEU: "https://api.eu.sparkpost.com",
+}
+
+// BaseUrl returns the base url of the API for the Region. The empty Region is treated as RegionUS.
+func (r Region) BaseUrl() (string, error) {
+	if r == "" {
+		r = RegionUS
+	}
+	if u, ok := RegionBaseUrls[Region(strings.ToLower(string(r)))]; ok {
+		return u, nil
+	}
+	return "", errors.Errorf("unknown region %q", string(r))
+}
+
+// Environment variables read by ConfigFromEnv.
+const (
+	EnvApiKey     = "SPARKPOST_API_KEY"
+	EnvBaseUrl    = "SPARKPOST_BASEURL"
+	EnvRegion     = "SPARKPOST_REGION"
+	EnvApiVersion = "SPARKPOST_APIVER"
+	EnvUsername   = "SPARKPOST_USERNAME"
+	EnvPassword   = "SPARKPOST_PASSWORD"
+	EnvVerbose    = "SPARKPOST_VERBOSE"
+	EnvProfile    = "SPARKPOST_PROFILE"
+	EnvConfigFile = "SPARKPOST_CONFIG_FILE"
+)
+
+// DefaultProfile is the profile used when none is specified.
+const DefaultProfile = "default"
+
+// Profile is one named set of settings in a profile file. Fields that aren't set are left at their defaults.
+type Profile struct {
+	ApiKey     string `json:"api_key,omitempty" yaml:"api_key,omitempty"`
+	BaseUrl    string `json:"base_url,omitempty" yaml:"base_url,omitempty"`
+	Region     Region `json:"region,omitempty" yaml:"region,omitempty"`
+	ApiVersion int    `json:"api_version,omitempty" yaml:"api_version,omitempty"`
+	Username   string `json:"username,omitempty" yaml:"username,omitempty"`
+	Password   string `json:"password,omitempty" yaml:"password,omitempty"`
+	Verbose    bool   `json:"verbose,omitempty" yaml:"verbose,omitempty"`
+}
+
+// Config returns a Config containing the Profile's settings.
+func (p *Profile) Config() *Config {
+	return &Config{
+		ApiKey:     p.ApiKey,
+		BaseUrl:    p.BaseUrl,
+		Region:     p.Region,
+		ApiVersion: p.ApiVersion,
+		Username:   p.Username,
+		Password:   p.Password,
+		Verbose:    p.Verbose,
+	}
+}
+
+// DefaultConfigFile returns the location of the profile file used when SPARKPOST_CONFIG_FILE isn't set,
+// which is .sparkpost/config.yaml in the user's home directory.
+func DefaultConfigFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".sparkpost", "config.yaml")
+}
+
+// LoadProfiles reads a profile file, which maps profile names to settings.
+// Files ending in .json are parsed as JSON, and anything else as YAML:
+//
+//	default:
+//	  api_key: 0123456789abcdef0123456789abcdef01234567
+//	eu:
+//	  api_key: 76543210fedcba9876543210fedcba9876543210
+//	  region: eu
+func LoadProfiles(path string) (map[string]Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading profile file")
+	}
+	profiles := map[string]Profile{}
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(data, &profiles)
+	} else {
+		err = yaml.Unmarshal(data, &profiles)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing profile file %s", path)
+	}
+	return profiles, nil
+}
+
+// LoadProfile returns a Config using the named profile from a profile file.
+// An empty name selects DefaultProfile.
+func LoadProfile(path, name string) (*Config, error) {
+	if name == "" {
+		name = DefaultProfile
+	}
+	profiles, err := LoadProfiles(path)
+	if err != nil {
+		return nil, err
+	}
+	p, ok := profiles[name]
+	if !ok {
+		return nil, errors.Errorf("profile %q not found in %s", name, path)
+	}
+	return p.Config(), nil
+}
+
+// ConfigFromEnv builds a Config from SPARKPOST_* environment variables, optionally layered over a profile.
+//
+// The profile named by SPARKPOST_PROFILE (or DefaultProfile) is read from the file named by
+// SPARKPOST_CONFIG_FILE (or DefaultConfigFile). It's not an error for the default file not to exist,
+// unless a profile was requested. Each of the following variables that is set then overrides the profile:
+// SPARKPOST_API_KEY, SPARKPOST_BASEURL, SPARKPOST_REGION, SPARKPOST_APIVER,
+// SPARKPOST_USERNAME, SPARKPOST_PASSWORD and SPARKPOST_VERBOSE.
+// Either an API key or a username is required.
+func ConfigFromEnv() (*Config, error) {
+	cfg := &Config{}
+
+	path, profile := os.Getenv(EnvConfigFile), os.Getenv(EnvProfile)
+	explicit := path != "" || profile != ""
+	if path == "" {
+		path = DefaultConfigFile()
+	}
+	if path != "" {
+		if _, err := os.Stat(path); err == nil || explicit {
+			if cfg, err = LoadProfile(path, profile); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	for _, v := range []struct {
+		name string
+		dst  *string
+	}{
+		{EnvApiKey, &cfg.ApiKey},
+		{EnvBaseUrl, &cfg.BaseUrl},
+		{EnvUsername, &cfg.Username},
+		{EnvPassword, &cfg.Password},
+	} {
+		if val := os.Getenv(v.name); val != "" {
+			*v.dst = val
+		}
+	}
+	if region := os.Getenv(EnvRegion); region != "" {
+		cfg.Region = Region(region)
+		if os.Getenv(EnvBaseUrl) == "" {
+			// don't let a base url from the profile take precedence
+			cfg.BaseUrl = ""
+		}
+	}
+	if apiVer := os.Getenv(EnvApiVersion); apiVer != "" {
+		ver, err := strconv.Atoi(apiVer)
+		if err != nil {
+			return nil, errors.Errorf("API Version must be an integer: %s", EnvApiVersion)
+		}
+		cfg.ApiVersion = ver
+	}
+	if verbose := os.Getenv(EnvVerbose); verbose != "" {
+		v, err := strconv.ParseBool(verbose)
+		if err != nil {
+			return nil, errors.Errorf("Verbose must be a boolean: %s", EnvVerbose)
+		}
+		cfg.Verbose = v
+	}
+
+	if cfg.ApiKey == "" && cfg.Username == "" {
+		return nil, errors.Errorf("API Key not set in environment: %s", EnvApiKey)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,116 @@
+package gosparkpost_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/pkg/errors"
+)
+
+var configEnv = []string{
+	sp.EnvApiKey, sp.EnvBaseUrl, sp.EnvRegion, sp.EnvApiVersion, sp.EnvUsername,
+	sp.EnvPassword, sp.EnvVerbose, sp.EnvProfile, sp.EnvConfigFile,
+}
+
+const profilesYAML = `default:
+  api_key: default-key
+eu:
+  api_key: eu-key
+  region: eu
+  api_version: 1
+staging:
+  api_key: staging-key
+  base_url: https://staging.example.com
+  verbose: true
+`
+
+const profilesJSON = `{
+  "default": {"api_key": "default-key"},
+  "eu": {"api_key": "eu-key", "region": "eu", "api_version": 1}
+}`
+
+func TestConfigFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	yamlFile := filepath.Join(dir, "config.yaml")
+	jsonFile := filepath.Join(dir, "config.json")
+	badFile := filepath.Join(dir, "bad.yaml")
+	for path, data := range map[string]string{yamlFile: profilesYAML, jsonFile: profilesJSON, badFile: "default: [1"} {
+		if err := os.WriteFile(path, []byte(data), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for idx, test := range []struct {
+		env map[string]string
+		cfg *sp.Config
+		err error
+	}{
+		{nil, nil, errors.New("API Key not set in environment: SPARKPOST_API_KEY")},
+		{map[string]string{sp.EnvApiKey: "key", sp.EnvRegion: "eu", sp.EnvApiVersion: "1", sp.EnvVerbose: "true"},
+			&sp.Config{ApiKey: "key", Region: sp.RegionEU, ApiVersion: 1, Verbose: true}, nil},
+		{map[string]string{sp.EnvUsername: "user", sp.EnvPassword: "pass", sp.EnvBaseUrl: "https://sp.example.com"},
+			&sp.Config{Username: "user", Password: "pass", BaseUrl: "https://sp.example.com"}, nil},
+		{map[string]string{sp.EnvApiKey: "key", sp.EnvApiVersion: "one"}, nil,
+			errors.New("API Version must be an integer: SPARKPOST_APIVER")},
+		{map[string]string{sp.EnvApiKey: "key", sp.EnvVerbose: "loud"}, nil,
+			errors.New("Verbose must be a boolean: SPARKPOST_VERBOSE")},
+
+		{map[string]string{sp.EnvConfigFile: yamlFile}, &sp.Config{ApiKey: "default-key"}, nil},
+		{map[string]string{sp.EnvConfigFile: yamlFile, sp.EnvProfile: "eu"},
+			&sp.Config{ApiKey: "eu-key", Region: sp.RegionEU, ApiVersion: 1}, nil},
+		{map[string]string{sp.EnvConfigFile: jsonFile, sp.EnvProfile: "eu", sp.EnvApiKey: "env-key"},
+			&sp.Config{ApiKey: "env-key", Region: sp.RegionEU, ApiVersion: 1}, nil},
+		{map[string]string{sp.EnvConfigFile: yamlFile, sp.EnvProfile: "staging"},
+			&sp.Config{ApiKey: "staging-key", BaseUrl: "https://staging.example.com", Verbose: true}, nil},
+		{map[string]string{sp.EnvConfigFile: yamlFile, sp.EnvProfile: "staging", sp.EnvRegion: "eu"},
+			&sp.Config{ApiKey: "staging-key", Region: sp.RegionEU, Verbose: true}, nil},
+		{map[string]string{sp.EnvConfigFile: yamlFile, sp.EnvProfile: "prod"}, nil,
+			errors.New(`profile "prod" not found in ` + yamlFile)},
+		{map[string]string{sp.EnvConfigFile: filepath.Join(dir, "missing.yaml")}, nil,
+			errors.New("reading profile file: open " + filepath.Join(dir, "missing.yaml") + ": no such file or directory")},
+		{map[string]string{sp.EnvConfigFile: badFile}, nil,
+			errors.New("parsing profile file " + badFile + ": yaml: line 1: did not find expected ',' or ']'")},
+	} {
+		t.Setenv("HOME", dir)
+		for _, name := range configEnv {
+			t.Setenv(name, test.env[name])
+		}
+
+		cfg, err := sp.ConfigFromEnv()
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("ConfigFromEnv[%d] => err %q, want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("ConfigFromEnv[%d] => err %q, want %q", idx, err, test.err)
+		} else if test.cfg != nil && *cfg != *test.cfg {
+			t.Errorf("ConfigFromEnv[%d] => cfg %+v, want %+v", idx, cfg, test.cfg)
+		}
+	}
+}
+
+func TestConfigFromEnvDefaultFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range configEnv {
+		t.Setenv(name, "")
+	}
+	t.Setenv("HOME", dir)
+	if err := os.MkdirAll(filepath.Join(dir, ".sparkpost"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(sp.DefaultConfigFile(), []byte(profilesYAML), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := sp.ConfigFromEnv()
+	if err != nil || cfg.ApiKey != "default-key" {
+		t.Fatalf("ConfigFromEnv => %+v, %v", cfg, err)
+	}
+	var client sp.Client
+	t.Setenv(sp.EnvProfile, "eu")
+	if cfg, err = sp.ConfigFromEnv(); err != nil {
+		t.Fatalf("ConfigFromEnv => %v", err)
+	} else if err = client.Init(cfg); err != nil || client.Config.BaseUrl != "https://api.eu.sparkpost.com" {
+		t.Errorf("Init => %q, %v", client.Config.BaseUrl, err)
+	}
+}
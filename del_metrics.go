@@ -5,12 +5,16 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/pkg/errors"
 )
 
 var MetricsPathFormat = "/api/v%d/metrics/deliverability"
 
+// https://developers.sparkpost.com/api/metrics/#header-discoverability-links
+var MetricsListPathFormat = "/api/v%d/metrics/%s"
+
 type MetricItem struct {
 	CountInjected               int    `json:"count_injected"`
 	CountBounce                 int    `json:"count_bounce,omitempty"`
@@ -49,6 +53,11 @@ type MetricItem struct {
 	WatchedDomain               string `json:"watched_domain,omitempty"`
 	Binding                     string `json:"binding,omitempty"`
 	BindingGroup                string `json:"binding_group,omitempty"`
+	SendingIP                   string `json:"sending_ip,omitempty"`
+	IPPool                      string `json:"ip_pool,omitempty"`
+	SendingDomain               string `json:"sending_domain,omitempty"`
+	SubaccountID                int    `json:"subaccount_id,omitempty"`
+	MailboxProvider             string `json:"mailbox_provider,omitempty"`
 }
 
 type Metrics struct {
@@ -88,6 +97,146 @@ func (c *Client) QueryMetricsContext(ctx context.Context, m *Metrics) (*Response
 	return m.doMetricsRequest(ctx, c, finalUrl)
 }
 
+// MetricsList filters the values returned by the metrics discoverability methods, such as MetricsCampaigns.
+type MetricsList struct {
+	// From, To and Timezone bound the values returned, as for MetricsQuery.
+	From     time.Time
+	To       time.Time
+	Timezone *time.Location
+	// Match, if set, only returns values containing it. It applies to campaigns, domains and templates,
+	// and isn't sent for the other lists.
+	Match string
+	// Limit is the most values to return.
+	Limit int
+}
+
+// Params validates the filter, and returns it as query params.
+func (l *MetricsList) Params() (map[string]string, error) {
+	params, err := metricsRangeParams("MetricsList", l.From, l.To, l.Timezone, l.Limit)
+	if err != nil {
+		return nil, err
+	}
+	if l.Match != "" {
+		params["match"] = l.Match
+	}
+	return params, nil
+}
+
+// MetricsCampaigns returns the campaign IDs with metrics in the time range of l.
+func (c *Client) MetricsCampaigns(l *MetricsList) ([]string, *Response, error) {
+	return c.MetricsCampaignsContext(context.Background(), l)
+}
+
+// MetricsCampaignsContext is the same as MetricsCampaigns, and it accepts a context.Context
+func (c *Client) MetricsCampaignsContext(ctx context.Context, l *MetricsList) (campaigns []string, res *Response, err error) {
+	res, err = c.metricsList(ctx, "campaigns", true, l, &campaigns)
+	return
+}
+
+// MetricsDomains returns the recipient domains with metrics in the time range of l.
+func (c *Client) MetricsDomains(l *MetricsList) ([]string, *Response, error) {
+	return c.MetricsDomainsContext(context.Background(), l)
+}
+
+// MetricsDomainsContext is the same as MetricsDomains, and it accepts a context.Context
+func (c *Client) MetricsDomainsContext(ctx context.Context, l *MetricsList) (domains []string, res *Response, err error) {
+	res, err = c.metricsList(ctx, "domains", true, l, &domains)
+	return
+}
+
+// MetricsSendingIPs returns the sending IPs with metrics in the time range of l.
+func (c *Client) MetricsSendingIPs(l *MetricsList) ([]string, *Response, error) {
+	return c.MetricsSendingIPsContext(context.Background(), l)
+}
+
+// MetricsSendingIPsContext is the same as MetricsSendingIPs, and it accepts a context.Context
+func (c *Client) MetricsSendingIPsContext(ctx context.Context, l *MetricsList) (ips []string, res *Response, err error) {
+	res, err = c.metricsList(ctx, "sending-ips", false, l, &ips)
+	return
+}
+
+// MetricsIPPools returns the IP pools with metrics in the time range of l.
+func (c *Client) MetricsIPPools(l *MetricsList) ([]string, *Response, error) {
+	return c.MetricsIPPoolsContext(context.Background(), l)
+}
+
+// MetricsIPPoolsContext is the same as MetricsIPPools, and it accepts a context.Context
+func (c *Client) MetricsIPPoolsContext(ctx context.Context, l *MetricsList) (pools []string, res *Response, err error) {
+	res, err = c.metricsList(ctx, "ip-pools", false, l, &pools)
+	return
+}
+
+// MetricsTemplates returns the template IDs with metrics in the time range of l.
+func (c *Client) MetricsTemplates(l *MetricsList) ([]string, *Response, error) {
+	return c.MetricsTemplatesContext(context.Background(), l)
+}
+
+// MetricsTemplatesContext is the same as MetricsTemplates, and it accepts a context.Context
+func (c *Client) MetricsTemplatesContext(ctx context.Context, l *MetricsList) (templates []string, res *Response, err error) {
+	res, err = c.metricsList(ctx, "templates", true, l, &templates)
+	return
+}
+
+// MetricsSubaccounts returns the IDs of subaccounts with metrics in the time range of l.
+func (c *Client) MetricsSubaccounts(l *MetricsList) ([]int, *Response, error) {
+	return c.MetricsSubaccountsContext(context.Background(), l)
+}
+
+// MetricsSubaccountsContext is the same as MetricsSubaccounts, and it accepts a context.Context
+func (c *Client) MetricsSubaccountsContext(ctx context.Context, l *MetricsList) (subaccounts []int, res *Response, err error) {
+	res, err = c.metricsList(ctx, "subaccounts", false, l, &subaccounts)
+	return
+}
+
+// metricsList requests the discoverability list named key, and unmarshals it into out.
+// Lists that can't be matched against aren't sent l.Match.
+func (c *Client) metricsList(ctx context.Context, key string, matchable bool, l *MetricsList, out interface{}) (*Response, error) {
+	if l == nil {
+		l = &MetricsList{}
+	}
+	params, err := l.Params()
+	if err != nil {
+		return nil, err
+	}
+	if !matchable {
+		delete(params, "match")
+	}
+	query := url.Values{}
+	for k, v := range params {
+		query.Add(k, v)
+	}
+	path := fmt.Sprintf(MetricsListPathFormat, c.Config.ApiVersion, key)
+	res, err := c.HttpGet(ctx, fmt.Sprintf("%s%s?%s", c.Config.BaseUrl, path, query.Encode()))
+	if err != nil {
+		return res, err
+	}
+
+	var body []byte
+	if body, err = res.AssertJson(); err != nil {
+		return res, err
+	}
+
+	if !Is2XX(res.HTTP.StatusCode) {
+		if err = res.ParseResponse(); err == nil {
+			err = res.HTTPError()
+		}
+		return res, err
+	}
+
+	lists := map[string]map[string]json.RawMessage{}
+	if err = json.Unmarshal(body, &lists); err != nil {
+		return res, errors.Wrap(err, "unmarshaling response")
+	}
+	list, ok := lists["results"][key]
+	if !ok {
+		return res, errors.Errorf("Unexpected response to metrics %s list", key)
+	}
+	if err = json.Unmarshal(list, out); err != nil {
+		return res, errors.Wrap(err, "unmarshaling response")
+	}
+	return res, nil
+}
+
 func (m *Metrics) doMetricsRequest(ctx context.Context, c *Client, finalUrl string) (*Response, error) {
 	// Send off our request
 	res, err := c.HttpGet(ctx, finalUrl)
@@ -0,0 +1,286 @@
+package gosparkpost
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MetricsPrecision is the size of each interval in a Deliverability Metrics time series.
+type MetricsPrecision string
+
+// Precisions supported by DeliverabilityTimeSeries.
+const (
+	PrecisionMinute   MetricsPrecision = "1min"
+	Precision5Minute  MetricsPrecision = "5min"
+	Precision15Minute MetricsPrecision = "15min"
+	PrecisionHour     MetricsPrecision = "hour"
+	Precision12Hour   MetricsPrecision = "12hr"
+	PrecisionDay      MetricsPrecision = "day"
+	PrecisionWeek     MetricsPrecision = "week"
+	PrecisionMonth    MetricsPrecision = "month"
+)
+
+var metricsPrecisions = map[MetricsPrecision]bool{
+	PrecisionMinute: true, Precision5Minute: true, Precision15Minute: true, PrecisionHour: true,
+	Precision12Hour: true, PrecisionDay: true, PrecisionWeek: true, PrecisionMonth: true,
+}
+
+// metricsTimeFormat is the format of the "from" and "to" metrics parameters.
+const metricsTimeFormat = "2006-01-02T15:04"
+
+// metricsRangeParams validates the time range and limit shared by metrics queries, and returns them as params.
+// Errors are prefixed with name, the type of the query.
+func metricsRangeParams(name string, from, to time.Time, tz *time.Location, limit int) (map[string]string, error) {
+	params := map[string]string{}
+	if tz == nil {
+		tz = time.UTC
+	} else {
+		params["timezone"] = tz.String()
+	}
+	if from.IsZero() {
+		return nil, errors.Errorf("%s From is required", name)
+	}
+	params["from"] = from.In(tz).Format(metricsTimeFormat)
+	if !to.IsZero() {
+		if !from.Before(to) {
+			return nil, errors.Errorf("%s From must be before To", name)
+		}
+		params["to"] = to.In(tz).Format(metricsTimeFormat)
+	}
+	if limit < 0 {
+		return nil, errors.Errorf("%s Limit must not be negative", name)
+	} else if limit > 0 {
+		params["limit"] = strconv.Itoa(limit)
+	}
+	return params, nil
+}
+
+// MetricNames returns the names of the metrics that can be requested, which are the counts and totals in MetricItem.
+func MetricNames() []string {
+	var names []string
+	t := reflect.TypeOf(MetricItem{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if strings.HasPrefix(name, "count_") || strings.HasPrefix(name, "total_") {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// MetricsQuery describes a Deliverability Metrics request, for DeliverabilityMetrics and the methods grouping by
+// domain, campaign and so on. Empty filters don't filter, and list filters match any of their values.
+// Mistakes are caught by Params, before any request is made.
+type MetricsQuery struct {
+	// From is required, and To defaults to now. Both are rounded down to the minute, in Timezone.
+	From time.Time
+	To   time.Time
+	// Timezone is the zone From, To and time series intervals are in; UTC if nil.
+	Timezone *time.Location
+	// Precision is the interval size, for DeliverabilityTimeSeries only.
+	Precision MetricsPrecision
+	// Metrics are the MetricNames to return; at least one is required.
+	Metrics []string
+
+	Domains        []string
+	Campaigns      []string
+	Templates      []string
+	SendingIPs     []string
+	IPPools        []string
+	SendingDomains []string
+	Subaccounts    []int
+
+	// Limit is the most results to return, for the grouped methods.
+	Limit int
+}
+
+// Params validates the query, and returns it as Metrics.Params.
+func (q *MetricsQuery) Params() (map[string]string, error) {
+	params, err := metricsRangeParams("MetricsQuery", q.From, q.To, q.Timezone, q.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(q.Metrics) == 0 {
+		return nil, errors.New("MetricsQuery Metrics is required")
+	}
+	valid := map[string]bool{}
+	for _, name := range MetricNames() {
+		valid[name] = true
+	}
+	for _, name := range q.Metrics {
+		if !valid[name] {
+			return nil, fmt.Errorf("Invalid metric [%s]", name)
+		}
+	}
+
+	if q.Precision != "" {
+		if !metricsPrecisions[q.Precision] {
+			return nil, fmt.Errorf("Invalid precision [%s]", q.Precision)
+		}
+		params["precision"] = string(q.Precision)
+	}
+
+	subaccounts := make([]string, len(q.Subaccounts))
+	for i, id := range q.Subaccounts {
+		subaccounts[i] = strconv.Itoa(id)
+	}
+	for param, values := range map[string][]string{
+		"metrics":         q.Metrics,
+		"domains":         q.Domains,
+		"campaigns":       q.Campaigns,
+		"templates":       q.Templates,
+		"sending_ips":     q.SendingIPs,
+		"ip_pools":        q.IPPools,
+		"sending_domains": q.SendingDomains,
+		"subaccounts":     subaccounts,
+	} {
+		for _, v := range values {
+			if v == "" || strings.Contains(v, ",") {
+				return nil, fmt.Errorf("Invalid %s value [%s]", param, v)
+			}
+		}
+		if len(values) > 0 {
+			params[param] = strings.Join(values, ",")
+		}
+	}
+	return params, nil
+}
+
+// deliverabilityMetrics runs q against the grouping at extraPath under MetricsPathFormat.
+func (c *Client) deliverabilityMetrics(ctx context.Context, extraPath string, q *MetricsQuery) (*Metrics, *Response, error) {
+	if q == nil {
+		q = &MetricsQuery{}
+	}
+	if q.Precision != "" && extraPath != "time-series" {
+		return nil, nil, errors.New("MetricsQuery Precision only applies to DeliverabilityTimeSeries")
+	}
+	params, err := q.Params()
+	if err != nil {
+		return nil, nil, err
+	}
+	m := &Metrics{ExtraPath: extraPath, Params: params}
+	res, err := c.QueryMetricsContext(ctx, m)
+	if err != nil {
+		return nil, res, err
+	}
+	return m, res, nil
+}
+
+// DeliverabilityMetrics returns metrics aggregated over everything matching q.
+// https://developers.sparkpost.com/api/metrics/#metrics-get-deliverability-metrics-summary
+func (c *Client) DeliverabilityMetrics(q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.DeliverabilityMetricsContext(context.Background(), q)
+}
+
+// DeliverabilityMetricsContext is the same as DeliverabilityMetrics, and it accepts a context.Context
+func (c *Client) DeliverabilityMetricsContext(ctx context.Context, q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.deliverabilityMetrics(ctx, "", q)
+}
+
+// DeliverabilityMetricsByDomain returns metrics for each recipient domain, in MetricItem.Domain.
+func (c *Client) DeliverabilityMetricsByDomain(q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.DeliverabilityMetricsByDomainContext(context.Background(), q)
+}
+
+// DeliverabilityMetricsByDomainContext is the same as DeliverabilityMetricsByDomain, and it accepts a context.Context
+func (c *Client) DeliverabilityMetricsByDomainContext(ctx context.Context, q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.deliverabilityMetrics(ctx, "domain", q)
+}
+
+// DeliverabilityMetricsBySendingIP returns metrics for each sending IP, in MetricItem.SendingIP.
+func (c *Client) DeliverabilityMetricsBySendingIP(q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.DeliverabilityMetricsBySendingIPContext(context.Background(), q)
+}
+
+// DeliverabilityMetricsBySendingIPContext is the same as DeliverabilityMetricsBySendingIP, and it accepts a context.Context
+func (c *Client) DeliverabilityMetricsBySendingIPContext(ctx context.Context, q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.deliverabilityMetrics(ctx, "sending-ip", q)
+}
+
+// DeliverabilityMetricsByIPPool returns metrics for each IP pool, in MetricItem.IPPool.
+func (c *Client) DeliverabilityMetricsByIPPool(q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.DeliverabilityMetricsByIPPoolContext(context.Background(), q)
+}
+
+// DeliverabilityMetricsByIPPoolContext is the same as DeliverabilityMetricsByIPPool, and it accepts a context.Context
+func (c *Client) DeliverabilityMetricsByIPPoolContext(ctx context.Context, q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.deliverabilityMetrics(ctx, "ip-pool", q)
+}
+
+// DeliverabilityMetricsBySendingDomain returns metrics for each sending domain, in MetricItem.SendingDomain.
+func (c *Client) DeliverabilityMetricsBySendingDomain(q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.DeliverabilityMetricsBySendingDomainContext(context.Background(), q)
+}
+
+// DeliverabilityMetricsBySendingDomainContext is the same as DeliverabilityMetricsBySendingDomain, and it accepts a context.Context
+func (c *Client) DeliverabilityMetricsBySendingDomainContext(ctx context.Context, q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.deliverabilityMetrics(ctx, "sending-domain", q)
+}
+
+// DeliverabilityMetricsBySubaccount returns metrics for each subaccount, in MetricItem.SubaccountID.
+func (c *Client) DeliverabilityMetricsBySubaccount(q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.DeliverabilityMetricsBySubaccountContext(context.Background(), q)
+}
+
+// DeliverabilityMetricsBySubaccountContext is the same as DeliverabilityMetricsBySubaccount, and it accepts a context.Context
+func (c *Client) DeliverabilityMetricsBySubaccountContext(ctx context.Context, q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.deliverabilityMetrics(ctx, "subaccount", q)
+}
+
+// DeliverabilityMetricsByCampaign returns metrics for each campaign, in MetricItem.CampaignId.
+func (c *Client) DeliverabilityMetricsByCampaign(q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.DeliverabilityMetricsByCampaignContext(context.Background(), q)
+}
+
+// DeliverabilityMetricsByCampaignContext is the same as DeliverabilityMetricsByCampaign, and it accepts a context.Context
+func (c *Client) DeliverabilityMetricsByCampaignContext(ctx context.Context, q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.deliverabilityMetrics(ctx, "campaign", q)
+}
+
+// DeliverabilityMetricsByTemplate returns metrics for each template, in MetricItem.TemplateId.
+func (c *Client) DeliverabilityMetricsByTemplate(q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.DeliverabilityMetricsByTemplateContext(context.Background(), q)
+}
+
+// DeliverabilityMetricsByTemplateContext is the same as DeliverabilityMetricsByTemplate, and it accepts a context.Context
+func (c *Client) DeliverabilityMetricsByTemplateContext(ctx context.Context, q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.deliverabilityMetrics(ctx, "template", q)
+}
+
+// DeliverabilityMetricsByWatchedDomain returns metrics for each watched domain, in MetricItem.WatchedDomain.
+func (c *Client) DeliverabilityMetricsByWatchedDomain(q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.DeliverabilityMetricsByWatchedDomainContext(context.Background(), q)
+}
+
+// DeliverabilityMetricsByWatchedDomainContext is the same as DeliverabilityMetricsByWatchedDomain, and it accepts a context.Context
+func (c *Client) DeliverabilityMetricsByWatchedDomainContext(ctx context.Context, q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.deliverabilityMetrics(ctx, "watched-domain", q)
+}
+
+// DeliverabilityMetricsByMailboxProvider returns metrics for each mailbox provider, in MetricItem.MailboxProvider.
+func (c *Client) DeliverabilityMetricsByMailboxProvider(q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.DeliverabilityMetricsByMailboxProviderContext(context.Background(), q)
+}
+
+// DeliverabilityMetricsByMailboxProviderContext is the same as DeliverabilityMetricsByMailboxProvider, and it accepts a context.Context
+func (c *Client) DeliverabilityMetricsByMailboxProviderContext(ctx context.Context, q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.deliverabilityMetrics(ctx, "mailbox-provider", q)
+}
+
+// DeliverabilityTimeSeries returns metrics for each interval of q.Precision between q.From and q.To,
+// with the start of each interval in MetricItem.TimeStamp.
+func (c *Client) DeliverabilityTimeSeries(q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.DeliverabilityTimeSeriesContext(context.Background(), q)
+}
+
+// DeliverabilityTimeSeriesContext is the same as DeliverabilityTimeSeries, and it accepts a context.Context
+func (c *Client) DeliverabilityTimeSeriesContext(ctx context.Context, q *MetricsQuery) (*Metrics, *Response, error) {
+	return c.deliverabilityMetrics(ctx, "time-series", q)
+}
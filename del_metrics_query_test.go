@@ -0,0 +1,115 @@
+package gosparkpost_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+)
+
+func TestMetricsQueryParams(t *testing.T) {
+	from := time.Date(2017, 1, 1, 12, 30, 45, 0, time.UTC)
+	to := from.Add(25 * time.Hour)
+	denver, err := time.LoadLocation("America/Denver")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	for idx, test := range []struct {
+		in     *sp.MetricsQuery
+		err    string
+		params map[string]string
+	}{
+		{&sp.MetricsQuery{}, "MetricsQuery From is required", nil},
+		{&sp.MetricsQuery{From: to, To: from, Metrics: []string{"count_injected"}}, "MetricsQuery From must be before To", nil},
+		{&sp.MetricsQuery{From: from}, "MetricsQuery Metrics is required", nil},
+		{&sp.MetricsQuery{From: from, Metrics: []string{"count_injected", "domain"}}, "Invalid metric [domain]", nil},
+		{&sp.MetricsQuery{From: from, Metrics: []string{"count_injectd"}}, "Invalid metric [count_injectd]", nil},
+		{&sp.MetricsQuery{From: from, Metrics: []string{"count_sent"}, Precision: "fortnight"}, "Invalid precision [fortnight]", nil},
+		{&sp.MetricsQuery{From: from, Metrics: []string{"count_sent"}, Campaigns: []string{"a,b"}}, "Invalid campaigns value [a,b]", nil},
+		{&sp.MetricsQuery{From: from, Metrics: []string{"count_sent"}, Limit: -1}, "MetricsQuery Limit must not be negative", nil},
+
+		{&sp.MetricsQuery{From: from, Metrics: []string{"count_sent"}}, "",
+			map[string]string{"from": "2017-01-01T12:30", "metrics": "count_sent"}},
+		{&sp.MetricsQuery{From: from, To: to, Timezone: denver, Precision: sp.PrecisionHour,
+			Metrics: []string{"count_sent", "total_msg_volume"}, Domains: []string{"gmail.com", "yahoo.com"},
+			Subaccounts: []int{0, 12}, IPPools: []string{"default"}, Limit: 5}, "",
+			map[string]string{"from": "2017-01-01T05:30", "to": "2017-01-02T06:30", "timezone": "America/Denver",
+				"precision": "hour", "metrics": "count_sent,total_msg_volume", "domains": "gmail.com,yahoo.com",
+				"subaccounts": "0,12", "ip_pools": "default", "limit": "5"}},
+	} {
+		params, err := test.in.Params()
+		if err == nil && test.err != "" || err != nil && err.Error() != test.err {
+			t.Errorf("MetricsQuery.Params[%d] => err %q want %q", idx, err, test.err)
+		} else if test.err == "" && !reflect.DeepEqual(params, test.params) {
+			t.Errorf("MetricsQuery.Params[%d] => %v want %v", idx, params, test.params)
+		}
+	}
+}
+
+func TestMetricNames(t *testing.T) {
+	names := sp.MetricNames()
+	if len(names) != 30 || names[0] != "count_injected" {
+		t.Errorf("MetricNames => %v", names)
+	}
+}
+
+func TestDeliverabilityMetricsGroupings(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	base := fmt.Sprintf(sp.MetricsPathFormat, testClient.Config.ApiVersion)
+	var gotPath string
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		gotPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results":[{"count_sent":4,"mailbox_provider":"Gmail","subaccount_id":12}]}`))
+	}
+	testMux.HandleFunc(base, handler)
+	testMux.HandleFunc(base+"/", handler)
+
+	q := &sp.MetricsQuery{From: time.Now().Add(-time.Hour), Metrics: []string{"count_sent"}}
+	ctx := context.Background()
+	for idx, test := range []struct {
+		fn   func(context.Context, *sp.MetricsQuery) (*sp.Metrics, *sp.Response, error)
+		path string
+	}{
+		{testClient.DeliverabilityMetricsContext, ""},
+		{testClient.DeliverabilityMetricsByDomainContext, "/domain"},
+		{testClient.DeliverabilityMetricsBySendingIPContext, "/sending-ip"},
+		{testClient.DeliverabilityMetricsByIPPoolContext, "/ip-pool"},
+		{testClient.DeliverabilityMetricsBySendingDomainContext, "/sending-domain"},
+		{testClient.DeliverabilityMetricsBySubaccountContext, "/subaccount"},
+		{testClient.DeliverabilityMetricsByCampaignContext, "/campaign"},
+		{testClient.DeliverabilityMetricsByTemplateContext, "/template"},
+		{testClient.DeliverabilityMetricsByWatchedDomainContext, "/watched-domain"},
+		{testClient.DeliverabilityMetricsByMailboxProviderContext, "/mailbox-provider"},
+		{testClient.DeliverabilityTimeSeriesContext, "/time-series"},
+	} {
+		gotPath = ""
+		m, res, err := test.fn(ctx, q)
+		if err != nil {
+			testFailVerbose(t, res, "Deliverability[%d] => err %v", idx, err)
+		} else if gotPath != base+test.path {
+			t.Errorf("Deliverability[%d] => path %q want %q", idx, gotPath, base+test.path)
+		} else if len(m.Results) != 1 || m.Results[0].CountSent != 4 || m.Results[0].MailboxProvider != "Gmail" ||
+			m.Results[0].SubaccountID != 12 {
+			t.Errorf("Deliverability[%d] => %+v", idx, m.Results)
+		}
+	}
+
+	// Precision only makes sense for a time series.
+	q.Precision = sp.PrecisionDay
+	gotPath = ""
+	if _, _, err := testClient.DeliverabilityMetricsByDomain(q); err == nil || gotPath != "" {
+		t.Errorf("DeliverabilityMetricsByDomain with Precision => err %v, requested %q", err, gotPath)
+	}
+	if _, _, err := testClient.DeliverabilityTimeSeries(q); err != nil {
+		t.Errorf("DeliverabilityTimeSeries with Precision => err %v", err)
+	}
+}
@@ -1,9 +1,12 @@
 package gosparkpost_test
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"reflect"
 	"testing"
+	"time"
 
 	sp "github.com/SparkPost/gosparkpost"
 )
@@ -60,3 +63,77 @@ func TestMetrics_Get_noArgsError(t *testing.T) {
 		testFailVerbose(t, res, "Expected 3 errors, got %d", len(m.Errors))
 	}
 }
+
+func TestMetricsLists(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	from := time.Date(2017, 1, 1, 12, 30, 45, 0, time.UTC)
+	for _, key := range []string{"campaigns", "domains", "sending-ips", "ip-pools", "templates", "subaccounts"} {
+		key := key
+		testMux.HandleFunc(fmt.Sprintf(sp.MetricsListPathFormat, testClient.Config.ApiVersion, key), func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			w.Header().Set("Content-Type", "application/json; charset=utf8")
+			// Only some lists can be matched against.
+			q := r.URL.Query()
+			if match := key == "campaigns" || key == "domains" || key == "templates"; q.Has("match") != match {
+				t.Errorf("MetricsList %s => match %q", key, q.Get("match"))
+			}
+			if q.Get("from") != "2017-01-01T12:30" || q.Get("limit") != "2" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"errors":[{"message":"from is required","param":"from"}]}`))
+				return
+			}
+			values := `["a","b"]`
+			if key == "subaccounts" {
+				values = `[0,12]`
+			}
+			fmt.Fprintf(w, `{"results":{%q:%s}}`, key, values)
+		})
+	}
+
+	ctx := context.Background()
+	list := &sp.MetricsList{From: from, Limit: 2, Match: "a"}
+	for idx, test := range []struct {
+		fn func() (interface{}, *sp.Response, error)
+	}{
+		{func() (interface{}, *sp.Response, error) { return testClient.MetricsCampaignsContext(ctx, list) }},
+		{func() (interface{}, *sp.Response, error) { return testClient.MetricsDomainsContext(ctx, list) }},
+		{func() (interface{}, *sp.Response, error) { return testClient.MetricsSendingIPsContext(ctx, list) }},
+		{func() (interface{}, *sp.Response, error) { return testClient.MetricsIPPoolsContext(ctx, list) }},
+		{func() (interface{}, *sp.Response, error) { return testClient.MetricsTemplatesContext(ctx, list) }},
+	} {
+		got, res, err := test.fn()
+		if err != nil {
+			testFailVerbose(t, res, "MetricsList[%d] => err %v", idx, err)
+		} else if !reflect.DeepEqual(got, []string{"a", "b"}) {
+			t.Errorf("MetricsList[%d] => %v", idx, got)
+		}
+	}
+
+	subaccounts, res, err := testClient.MetricsSubaccounts(list)
+	if err != nil {
+		testFailVerbose(t, res, "MetricsSubaccounts => err %v", err)
+	} else if !reflect.DeepEqual(subaccounts, []int{0, 12}) {
+		t.Errorf("MetricsSubaccounts => %v", subaccounts)
+	}
+
+	// API errors are returned, and bad filters are caught before a request is made.
+	if _, _, err = testClient.MetricsCampaigns(&sp.MetricsList{From: from, Match: "a"}); err == nil ||
+		err.Error() != `[{"message":"from is required","code":"","description":""}]` {
+		t.Errorf("MetricsCampaigns without limit => err %v", err)
+	}
+	for idx, test := range []struct {
+		in  *sp.MetricsList
+		err string
+	}{
+		{nil, "MetricsList From is required"},
+		{&sp.MetricsList{From: from, To: from}, "MetricsList From must be before To"},
+		{&sp.MetricsList{From: from, Limit: -1}, "MetricsList Limit must not be negative"},
+	} {
+		_, res, err = testClient.MetricsDomains(test.in)
+		if err == nil || err.Error() != test.err || res != nil {
+			t.Errorf("MetricsDomains[%d] => err %v want %q", idx, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package gosparkpost
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DKIM contains the DKIM signing details for a sending domain.
+// Private and Public are base64-encoded DER keys, without PEM headers. Private is never returned by the API.
+type DKIM struct {
+	Private       string `json:"private,omitempty"`
+	Public        string `json:"public,omitempty"`
+	Selector      string `json:"selector,omitempty"`
+	Headers       string `json:"headers,omitempty"`
+	SigningDomain string `json:"signing_domain,omitempty"`
+}
+
+// DefaultDKIMKeyBits is the key size used by NewDKIMKey when zero is specified.
+const DefaultDKIMKeyBits = 2048
+
+// NewDKIMKey generates an RSA keypair locally, for use with the specified selector.
+// Set the result as SendingDomain.DKIM when creating or updating a sending domain,
+// and publish TXTRecord at RecordName.
+func NewDKIMKey(selector string, bits int) (*DKIM, error) {
+	if selector == "" {
+		return nil, errors.New("DKIM requires a non-empty selector")
+	}
+	if bits == 0 {
+		bits = DefaultDKIMKeyBits
+	} else if bits < 1024 || bits > 4096 {
+		return nil, errors.Errorf("DKIM key length must be between 1024 and 4096 bits, not %d", bits)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		return nil, errors.Wrap(err, "generating DKIM key")
+	}
+	public, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "encoding DKIM public key")
+	}
+
+	return &DKIM{
+		Private:  base64.StdEncoding.EncodeToString(x509.MarshalPKCS1PrivateKey(key)),
+		Public:   base64.StdEncoding.EncodeToString(public),
+		Selector: selector,
+	}, nil
+}
+
+// RecordName returns the name of the DNS TXT record holding the public key for the specified domain.
+func (d *DKIM) RecordName(domain string) string {
+	return d.Selector + "._domainkey." + strings.TrimSuffix(domain, ".")
+}
+
+// TXTRecord returns the value of the DNS TXT record to publish, containing the public key.
+func (d *DKIM) TXTRecord() string {
+	return "v=DKIM1; k=rsa; h=sha256; p=" + d.Public
+}
+
+// TXTRecordZone returns TXTRecord in zone file format: quoted, and split into strings
+// of at most 255 characters, as required for keys longer than 1024 bits.
+func (d *DKIM) TXTRecordZone() string {
+	value := d.TXTRecord()
+	var parts []string
+	for len(value) > 255 {
+		parts = append(parts, `"`+value[:255]+`"`)
+		value = value[255:]
+	}
+	parts = append(parts, `"`+value+`"`)
+	return strings.Join(parts, " ")
+}
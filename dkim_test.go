@@ -0,0 +1,73 @@
+package gosparkpost_test
+
+import (
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/pkg/errors"
+)
+
+func TestNewDKIMKey(t *testing.T) {
+	for idx, test := range []struct {
+		selector string
+		bits     int
+		err      error
+		want     int
+	}{
+		{"", 1024, errors.New("DKIM requires a non-empty selector"), 0},
+		{"s1", 512, errors.New("DKIM key length must be between 1024 and 4096 bits, not 512"), 0},
+		{"s1", 1024, nil, 1024},
+		{"s1", 0, nil, sp.DefaultDKIMKeyBits},
+	} {
+		dkim, err := sp.NewDKIMKey(test.selector, test.bits)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("NewDKIMKey[%d] => err %q want %q", idx, err, test.err)
+			continue
+		} else if err != nil {
+			if err.Error() != test.err.Error() {
+				t.Errorf("NewDKIMKey[%d] => err %q want %q", idx, err, test.err)
+			}
+			continue
+		}
+
+		der, err := base64.StdEncoding.DecodeString(dkim.Private)
+		if err != nil {
+			t.Fatalf("NewDKIMKey[%d] => private key %v", idx, err)
+		}
+		key, err := x509.ParsePKCS1PrivateKey(der)
+		if err != nil {
+			t.Fatalf("NewDKIMKey[%d] => private key %v", idx, err)
+		}
+		if key.N.BitLen() != test.want {
+			t.Errorf("NewDKIMKey[%d] => %d bits, want %d", idx, key.N.BitLen(), test.want)
+		}
+
+		der, _ = base64.StdEncoding.DecodeString(dkim.Public)
+		pub, err := x509.ParsePKIXPublicKey(der)
+		if err != nil {
+			t.Fatalf("NewDKIMKey[%d] => public key %v", idx, err)
+		} else if !key.PublicKey.Equal(pub.(*rsa.PublicKey)) {
+			t.Errorf("NewDKIMKey[%d] => public key doesn't match private key", idx)
+		}
+
+		if name := dkim.RecordName("example.com."); name != "s1._domainkey.example.com" {
+			t.Errorf("NewDKIMKey[%d] => RecordName %q", idx, name)
+		}
+		if txt := dkim.TXTRecord(); txt != "v=DKIM1; k=rsa; h=sha256; p="+dkim.Public {
+			t.Errorf("NewDKIMKey[%d] => TXTRecord %q", idx, txt)
+		}
+		zone := dkim.TXTRecordZone()
+		for _, part := range strings.Split(zone, `" "`) {
+			if len(strings.Trim(part, `"`)) > 255 {
+				t.Errorf("NewDKIMKey[%d] => TXTRecordZone has a string longer than 255 bytes: %s", idx, zone)
+			}
+		}
+		if joined := strings.ReplaceAll(strings.Trim(zone, `"`), `" "`, ""); joined != dkim.TXTRecord() {
+			t.Errorf("NewDKIMKey[%d] => TXTRecordZone %s", idx, zone)
+		}
+	}
+}
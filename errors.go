@@ -0,0 +1,83 @@
+package gosparkpost
+
+import (
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// Sentinel errors describing why an API call failed.
+// They're matched by the *APIError values returned from Response.HTTPError, using errors.Is.
+var (
+	ErrUnauthorized        = errors.New("unauthorized")
+	ErrNotFound            = errors.New("not found")
+	ErrValidation          = errors.New("validation failed")
+	ErrRateLimited         = errors.New("rate limited")
+	ErrSuppressedRecipient = errors.New("suppressed recipient")
+	ErrServer              = errors.New("server error")
+)
+
+// ErrorCodeKinds maps SparkPost error codes to sentinel errors.
+// When a response contains one of these codes, it takes precedence over the HTTP status code.
+// https://developers.sparkpost.com/api/#header-error-codes
+var ErrorCodeKinds = map[ErrorCode]error{
+	"1902": ErrSuppressedRecipient, // Message generation rejected
+}
+
+// APIError is returned when the API responds with a status code outside the range 200-299.
+// Its Error method returns the same JSON that SPErrors.Error does.
+//
+//	var apiErr *APIError
+//	if errors.Is(err, ErrUnauthorized) { ... }
+//	if errors.As(err, &apiErr) { log.Print(apiErr.StatusCode) }
+type APIError struct {
+	Response   *Response
+	StatusCode int
+	Errors     SPErrors
+}
+
+// Error satisfies the builtin Error interface
+func (e *APIError) Error() string {
+	return e.Errors.Error()
+}
+
+// Unwrap allows errors.As to retrieve the underlying SPErrors.
+func (e *APIError) Unwrap() error {
+	return e.Errors
+}
+
+// Is reports whether the target is the sentinel error describing this failure.
+func (e *APIError) Is(target error) bool {
+	kind := e.Kind()
+	return kind != nil && kind == target
+}
+
+// Kind returns the sentinel error describing this failure, or nil if none applies.
+func (e *APIError) Kind() error {
+	for _, spe := range e.Errors {
+		if kind, ok := ErrorCodeKinds[spe.Code]; ok {
+			return kind
+		}
+	}
+
+	switch {
+	case e.StatusCode == http.StatusUnauthorized, e.StatusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode == http.StatusBadRequest, e.StatusCode == http.StatusConflict,
+		e.StatusCode == http.StatusUnprocessableEntity:
+		return ErrValidation
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode >= 500:
+		return ErrServer
+	}
+	return nil
+}
+
+// Temporary returns true if the same request may succeed when tried again later.
+func (e *APIError) Temporary() bool {
+	kind := e.Kind()
+	return kind == ErrRateLimited || kind == ErrServer
+}
@@ -0,0 +1,75 @@
+package gosparkpost_test
+
+import (
+	"fmt"
+	"testing"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/pkg/errors"
+)
+
+func TestAPIError(t *testing.T) {
+	for idx, test := range []struct {
+		name      string
+		status    int
+		json      string
+		kind      error
+		temporary bool
+	}{
+		{"bad key", 401, `{"errors":[{"message":"Unauthorized."}]}`, sp.ErrUnauthorized, false},
+		{"missing grant", 403, `{"errors":[{"message":"Forbidden."}]}`, sp.ErrUnauthorized, false},
+		{"not found", 404, `{"errors":[{"message":"resource not found","code":"1600"}]}`, sp.ErrNotFound, false},
+		{"validation", 422, `{"errors":[{"message":"invalid data format/type","code":"1300"}]}`, sp.ErrValidation, false},
+		{"suppressed", 400, `{"errors":[{"message":"Message generation rejected","code":"1902"}]}`, sp.ErrSuppressedRecipient, false},
+		{"rate limited", 429, `{"errors":[{"message":"Too many requests"}]}`, sp.ErrRateLimited, true},
+		{"outage", 503, ``, sp.ErrServer, true},
+		{"teapot", 418, ``, nil, false},
+	} {
+		testSetup(t)
+		mockRestResponseBuilder(t, "GET", test.status, "/errors", test.json)
+
+		res, err := testClient.HttpGetJson(nil, testClient.Config.BaseUrl+"/errors", nil)
+		if err == nil {
+			t.Errorf("APIError[%d] (%s) => expected an error", idx, test.name)
+			testTeardown()
+			continue
+		}
+		testTeardown()
+
+		var apiErr *sp.APIError
+		if !errors.As(err, &apiErr) {
+			t.Errorf("APIError[%d] (%s) => %T is not an *APIError", idx, test.name, err)
+			continue
+		}
+		if apiErr.StatusCode != test.status || apiErr.Response != res {
+			t.Errorf("APIError[%d] (%s) => status %d, want %d", idx, test.name, apiErr.StatusCode, test.status)
+		}
+		if test.kind != nil && !errors.Is(err, test.kind) {
+			t.Errorf("APIError[%d] (%s) => errors.Is(%v) false", idx, test.name, test.kind)
+		}
+		for _, other := range []error{sp.ErrUnauthorized, sp.ErrNotFound, sp.ErrValidation,
+			sp.ErrRateLimited, sp.ErrSuppressedRecipient, sp.ErrServer} {
+			if other != test.kind && errors.Is(err, other) {
+				t.Errorf("APIError[%d] (%s) => unexpected match for %v", idx, test.name, other)
+			}
+		}
+		if apiErr.Temporary() != test.temporary {
+			t.Errorf("APIError[%d] (%s) => Temporary %t, want %t", idx, test.name, apiErr.Temporary(), test.temporary)
+		}
+
+		var spErrs sp.SPErrors
+		if !errors.As(err, &spErrs) || len(spErrs) != 1 {
+			t.Errorf("APIError[%d] (%s) => SPErrors not available: %v", idx, test.name, spErrs)
+		} else if err.Error() != spErrs.Error() {
+			t.Errorf("APIError[%d] (%s) => got/want:\n%s\n%s", idx, test.name, err, spErrs)
+		}
+	}
+}
+
+func ExampleAPIError() {
+	err := error(&sp.APIError{StatusCode: 401, Errors: sp.SPErrors{{Message: "Unauthorized."}}})
+	if errors.Is(err, sp.ErrUnauthorized) {
+		fmt.Println("check the API key")
+	}
+	// Output: check the API key
+}
@@ -0,0 +1,272 @@
+package gosparkpost
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// EventValidator checks raw event JSON against the fields documented by the Event Documentation API.
+// Field types are compared with the JSON type of each field's SampleValue. Numbers and strings
+// that hold a number are interchangeable, since the API sends some numeric fields either way,
+// and null is accepted for any field.
+type EventValidator struct {
+	// Groups is the documentation, as returned by EventDocumentation.
+	Groups map[string]*EventGroup
+	// Strict causes Validate to return an error when any problems are found.
+	// Otherwise, problems are only returned, and it's up to the caller to report them.
+	Strict bool
+	// Optional fields may be left out of any event without being reported as missing.
+	Optional []string
+}
+
+// EventProblemKind describes how an event differs from its documentation.
+type EventProblemKind string
+
+const (
+	EventUnknownType  EventProblemKind = "unknown type"
+	EventUnknownField EventProblemKind = "unknown field"
+	EventMissingField EventProblemKind = "missing field"
+	EventTypeMismatch EventProblemKind = "type mismatch"
+)
+
+// EventProblem is a single difference between an event and its documentation.
+type EventProblem struct {
+	// Index is the position of the event within its batch.
+	Index     int
+	EventType string
+	Kind      EventProblemKind
+	Field     string
+	// Want and Got are the documented and actual JSON types, for EventTypeMismatch.
+	Want string
+	Got  string
+}
+
+func (p EventProblem) String() string {
+	switch p.Kind {
+	case EventUnknownType:
+		return fmt.Sprintf("event %d: unknown type %q", p.Index, p.EventType)
+	case EventTypeMismatch:
+		return fmt.Sprintf("event %d (%s): type mismatch for %q: got %s, want %s",
+			p.Index, p.EventType, p.Field, p.Got, p.Want)
+	}
+	return fmt.Sprintf("event %d (%s): %s %q", p.Index, p.EventType, p.Kind, p.Field)
+}
+
+// EventValidationError is returned by a strict EventValidator when problems are found.
+type EventValidationError struct {
+	Problems []EventProblem
+}
+
+func (e *EventValidationError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = p.String()
+	}
+	return fmt.Sprintf("%d event validation problem(s): %s", len(e.Problems), strings.Join(msgs, "; "))
+}
+
+// Validate checks a single event, either bare or wrapped in an "msys" object as sent by webhooks.
+func (v *EventValidator) Validate(raw []byte) ([]EventProblem, error) {
+	problems, err := v.validate(0, raw)
+	if err != nil {
+		return nil, err
+	}
+	return problems, v.result(problems)
+}
+
+// ValidateBatch checks each event in a batch, as posted by a webhook (an array of "msys"-wrapped events)
+// or returned by the Event Samples API (a "results" array of events).
+func (v *EventValidator) ValidateBatch(body []byte) ([]EventProblem, error) {
+	var rawEvents []json.RawMessage
+	var samples struct {
+		Results []json.RawMessage `json:"results"`
+	}
+	if err := json.Unmarshal(body, &rawEvents); err != nil {
+		if err = json.Unmarshal(body, &samples); err != nil {
+			return nil, errors.Wrap(err, "parsing event batch")
+		}
+		rawEvents = samples.Results
+	}
+
+	var problems []EventProblem
+	for idx, raw := range rawEvents {
+		found, err := v.validate(idx, raw)
+		if err != nil {
+			return nil, errors.Wrapf(err, "event %d", idx)
+		}
+		problems = append(problems, found...)
+	}
+	return problems, v.result(problems)
+}
+
+func (v *EventValidator) result(problems []EventProblem) error {
+	if v.Strict && len(problems) > 0 {
+		return &EventValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// validate compares one event with the documentation for its type.
+func (v *EventValidator) validate(idx int, raw []byte) ([]EventProblem, error) {
+	fields, err := unwrapEvent(raw)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		// Webhook validation requests contain a single empty event.
+		return nil, nil
+	}
+
+	var eventType string
+	if rawType, ok := fields["type"]; ok {
+		if err = json.Unmarshal(rawType, &eventType); err != nil {
+			return nil, errors.Wrap(err, "parsing event type")
+		}
+	}
+	meta, ok := v.lookup(eventType)
+	if !ok {
+		return []EventProblem{{Index: idx, EventType: eventType, Kind: EventUnknownType}}, nil
+	}
+
+	var problems []EventProblem
+	for _, name := range sortedKeys(fields) {
+		doc, ok := meta.Fields[name]
+		if !ok {
+			problems = append(problems, EventProblem{
+				Index: idx, EventType: eventType, Kind: EventUnknownField, Field: name})
+			continue
+		}
+		want, got := sampleType(doc.SampleValue), rawType(fields[name])
+		if !compatible(want, got, doc.SampleValue, fields[name]) {
+			problems = append(problems, EventProblem{
+				Index: idx, EventType: eventType, Kind: EventTypeMismatch, Field: name, Want: want, Got: got})
+		}
+	}
+
+	optional := map[string]bool{}
+	for _, name := range v.Optional {
+		optional[name] = true
+	}
+	missing := []string{}
+	for name := range meta.Fields {
+		if _, ok := fields[name]; !ok && !optional[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		problems = append(problems, EventProblem{
+			Index: idx, EventType: eventType, Kind: EventMissingField, Field: name})
+	}
+	return problems, nil
+}
+
+// lookup finds the documentation for an event type, in any group.
+func (v *EventValidator) lookup(eventType string) (EventMeta, bool) {
+	for _, group := range v.Groups {
+		if group == nil {
+			continue
+		}
+		if meta, ok := group.Events[eventType]; ok {
+			return meta, true
+		}
+	}
+	return EventMeta{}, false
+}
+
+// unwrapEvent returns the fields of an event, removing the "msys" wrapper if there is one.
+func unwrapEvent(raw []byte) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, errors.Wrap(err, "parsing event")
+	}
+	msys, ok := fields["msys"]
+	if !ok || len(fields) != 1 {
+		return fields, nil
+	}
+
+	var wrapper map[string]json.RawMessage
+	if err := json.Unmarshal(msys, &wrapper); err != nil {
+		return nil, errors.Wrap(err, "parsing msys wrapper")
+	}
+	for _, inner := range wrapper {
+		fields = nil
+		if err := json.Unmarshal(inner, &fields); err != nil {
+			return nil, errors.Wrap(err, "parsing event")
+		}
+		return fields, nil
+	}
+	return map[string]json.RawMessage{}, nil
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sampleType returns the JSON type of a documented sample value.
+func sampleType(sample interface{}) string {
+	switch sample.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case float64, json.Number:
+		return "number"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
+
+// rawType returns the JSON type of a raw value.
+func rawType(raw json.RawMessage) string {
+	s := strings.TrimSpace(string(raw))
+	if s == "" {
+		return "null"
+	}
+	switch s[0] {
+	case '"':
+		return "string"
+	case '{':
+		return "object"
+	case '[':
+		return "array"
+	case 't', 'f':
+		return "boolean"
+	case 'n':
+		return "null"
+	}
+	return "number"
+}
+
+// compatible returns true if a value of type got may be sent for a field documented as type want.
+func compatible(want, got string, sample interface{}, raw json.RawMessage) bool {
+	switch {
+	case want == got, want == "null", got == "null":
+		return true
+	case want == "string" && got == "number":
+		s, _ := sample.(string)
+		return isNumeric(s)
+	case want == "number" && got == "string":
+		var s string
+		return json.Unmarshal(raw, &s) == nil && isNumeric(s)
+	}
+	return false
+}
+
+func isNumeric(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
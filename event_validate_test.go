@@ -0,0 +1,159 @@
+package gosparkpost_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/pkg/errors"
+)
+
+func loadEventDocs(t *testing.T) map[string]*sp.EventGroup {
+	t.Helper()
+	var docs struct {
+		Results map[string]*sp.EventGroup `json:"results"`
+	}
+	if err := json.Unmarshal(eventDocumentationBytes, &docs); err != nil {
+		t.Fatal(err)
+	}
+	return docs.Results
+}
+
+func TestEventValidator_Strict(t *testing.T) {
+	groups := loadEventDocs(t)
+	v := &sp.EventValidator{Groups: groups, Strict: true}
+
+	// An event built from the documented samples should always pass.
+	for _, group := range groups {
+		for name, meta := range group.Events {
+			event := map[string]interface{}{}
+			for field, doc := range meta.Fields {
+				event[field] = doc.SampleValue
+			}
+			event["type"] = name
+			raw, err := json.Marshal(map[string]interface{}{"msys": map[string]interface{}{"message_event": event}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err = v.Validate(raw); err != nil {
+				t.Errorf("Validate(%s) => err %q want nil", name, err)
+			}
+		}
+	}
+}
+
+var validatorGroups = map[string]*sp.EventGroup{
+	"unsubscribe_event": {Events: map[string]sp.EventMeta{
+		"list_unsubscribe": {Fields: map[string]sp.EventField{
+			"type":          {SampleValue: "list_unsubscribe"},
+			"campaign_id":   {SampleValue: "Example Campaign Name"},
+			"msg_size":      {SampleValue: "1337"},
+			"num_retries":   {SampleValue: float64(2)},
+			"rcpt_meta":     {SampleValue: map[string]interface{}{"customKey": "customValue"}},
+			"rcpt_tags":     {SampleValue: []interface{}{"male", "US"}},
+			"amp_enabled":   {SampleValue: true},
+			"friendly_from": {SampleValue: "sender@example.com"},
+		}},
+	}},
+}
+
+func TestEventValidator_Validate(t *testing.T) {
+	complete := `"campaign_id":"c","msg_size":"10","num_retries":2,"rcpt_meta":{},"rcpt_tags":[],"amp_enabled":false,"friendly_from":"f"`
+	for idx, test := range []struct {
+		in       string
+		optional []string
+		problems []sp.EventProblem
+		err      error
+	}{
+		{`{"type":"list_unsubscribe",` + complete + `}`, nil, nil, nil},
+		{`{"msys":{"unsubscribe_event":{"type":"list_unsubscribe",` + complete + `}}}`, nil, nil, nil},
+		{`{"msys":{}}`, nil, nil, nil},
+		{`{"type":"list_unsubscribe","msg_size":10,"num_retries":"2","rcpt_meta":null,"rcpt_tags":[],"amp_enabled":true,"friendly_from":"f","campaign_id":"c"}`,
+			nil, nil, nil},
+		{`{"type":"list_unsubscribe",` + complete + `,"campaign":"c"}`, nil,
+			[]sp.EventProblem{{EventType: "list_unsubscribe", Kind: sp.EventUnknownField, Field: "campaign"}}, nil},
+		{`{"type":"list_unsubscribe","msg_size":"10","num_retries":2,"rcpt_meta":{},"amp_enabled":false,"friendly_from":"f"}`, nil,
+			[]sp.EventProblem{
+				{EventType: "list_unsubscribe", Kind: sp.EventMissingField, Field: "campaign_id"},
+				{EventType: "list_unsubscribe", Kind: sp.EventMissingField, Field: "rcpt_tags"},
+			}, nil},
+		{`{"type":"list_unsubscribe","msg_size":"10","num_retries":2,"rcpt_meta":{},"amp_enabled":false,"friendly_from":"f"}`,
+			[]string{"campaign_id", "rcpt_tags"}, nil, nil},
+		{`{"type":"list_unsubscribe","campaign_id":1,"msg_size":"10","num_retries":"two","rcpt_meta":[],"rcpt_tags":"male","amp_enabled":"true","friendly_from":"f"}`, nil,
+			[]sp.EventProblem{
+				{EventType: "list_unsubscribe", Kind: sp.EventTypeMismatch, Field: "amp_enabled", Want: "boolean", Got: "string"},
+				{EventType: "list_unsubscribe", Kind: sp.EventTypeMismatch, Field: "campaign_id", Want: "string", Got: "number"},
+				{EventType: "list_unsubscribe", Kind: sp.EventTypeMismatch, Field: "num_retries", Want: "number", Got: "string"},
+				{EventType: "list_unsubscribe", Kind: sp.EventTypeMismatch, Field: "rcpt_meta", Want: "object", Got: "array"},
+				{EventType: "list_unsubscribe", Kind: sp.EventTypeMismatch, Field: "rcpt_tags", Want: "array", Got: "string"},
+			}, nil},
+		{`{"type":"list_unsubscrib"}`, nil,
+			[]sp.EventProblem{{EventType: "list_unsubscrib", Kind: sp.EventUnknownType}}, nil},
+		{`[]`, nil, nil, errors.New("parsing event: json: cannot unmarshal array")},
+	} {
+		v := &sp.EventValidator{Groups: validatorGroups, Optional: test.optional}
+		problems, err := v.Validate([]byte(test.in))
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("Validate[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && !strings.HasPrefix(err.Error(), test.err.Error()) {
+			t.Errorf("Validate[%d] => err %q want %q", idx, err, test.err)
+		} else if !reflect.DeepEqual(problems, test.problems) {
+			t.Errorf("Validate[%d] => problems %v want %v", idx, problems, test.problems)
+		}
+
+		// Strict mode returns the same problems, as an error.
+		v.Strict = true
+		problems, err = v.Validate([]byte(test.in))
+		if test.err == nil && len(test.problems) > 0 {
+			var verr *sp.EventValidationError
+			if !errors.As(err, &verr) || !reflect.DeepEqual(verr.Problems, problems) {
+				t.Errorf("Validate[%d] (strict) => err %v want EventValidationError", idx, err)
+			}
+		} else if test.err == nil && err != nil {
+			t.Errorf("Validate[%d] (strict) => err %q want nil", idx, err)
+		}
+	}
+}
+
+func TestEventValidator_ValidateBatch(t *testing.T) {
+	v := &sp.EventValidator{Groups: validatorGroups, Optional: []string{"msg_size", "num_retries", "rcpt_meta", "rcpt_tags", "amp_enabled", "friendly_from"}}
+	for idx, test := range []struct {
+		in       string
+		problems []sp.EventProblem
+		err      error
+	}{
+		{`[{"msys":{}}]`, nil, nil},
+		{`[{"msys":{"unsubscribe_event":{"type":"list_unsubscribe","campaign_id":"c"}}},{"msys":{"unsubscribe_event":{"type":"list_unsubscribe"}}}]`,
+			[]sp.EventProblem{{Index: 1, EventType: "list_unsubscribe", Kind: sp.EventMissingField, Field: "campaign_id"}}, nil},
+		{`{"results":[{"type":"list_unsubscribe","campaign_id":"c"},{"type":"open"}]}`,
+			[]sp.EventProblem{{Index: 1, EventType: "open", Kind: sp.EventUnknownType}}, nil},
+		{`"batch"`, nil, errors.New("parsing event batch: json: cannot unmarshal string")},
+	} {
+		problems, err := v.ValidateBatch([]byte(test.in))
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("ValidateBatch[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && !strings.HasPrefix(err.Error(), test.err.Error()) {
+			t.Errorf("ValidateBatch[%d] => err %q want %q", idx, err, test.err)
+		} else if !reflect.DeepEqual(problems, test.problems) {
+			t.Errorf("ValidateBatch[%d] => problems %v want %v", idx, problems, test.problems)
+		}
+	}
+}
+
+func TestEventProblem_String(t *testing.T) {
+	for idx, test := range []struct {
+		in  sp.EventProblem
+		out string
+	}{
+		{sp.EventProblem{Index: 2, EventType: "opn", Kind: sp.EventUnknownType}, `event 2: unknown type "opn"`},
+		{sp.EventProblem{EventType: "open", Kind: sp.EventMissingField, Field: "geo_ip"}, `event 0 (open): missing field "geo_ip"`},
+		{sp.EventProblem{EventType: "open", Kind: sp.EventTypeMismatch, Field: "geo_ip", Want: "object", Got: "string"},
+			`event 0 (open): type mismatch for "geo_ip": got string, want object`},
+	} {
+		if out := test.in.String(); out != test.out {
+			t.Errorf("EventProblem.String[%d] => %q want %q", idx, out, test.out)
+		}
+	}
+}
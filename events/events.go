@@ -75,6 +75,22 @@ func EventForName(eventType string) Event {
 		return &RelayTempfail{}
 	case "sms_status":
 		return &SMSStatus{}
+	case "amp_click":
+		return &AMPClick{}
+	case "amp_open":
+		return &AMPOpen{}
+	case "amp_initial_open":
+		return &AMPInitialOpen{}
+	case "initial_open":
+		return &InitialOpen{}
+	case "ab_test_completed":
+		return &ABTestCompleted{}
+	case "ab_test_cancelled":
+		return &ABTestCancelled{}
+	case "ingest_success":
+		return &IngestSuccess{}
+	case "ingest_error":
+		return &IngestError{}
 	}
 	return &Unknown{}
 }
@@ -91,7 +107,7 @@ func ParseRawJSONEvents(rawEvents []json.RawMessage) ([]Event, error) {
 
 		event := EventForName(typeLookup.EventType())
 		if e, ok := event.(*Unknown); ok {
-			e.EventCommon.Type = typeLookup.EventType()
+			e.EventCommon = typeLookup
 			e.RawJSON = rawEvent
 			e.Error = ErrNotImplemented
 			events = append(events, e)
@@ -101,7 +117,7 @@ func ParseRawJSONEvents(rawEvents []json.RawMessage) ([]Event, error) {
 		// Unmarshal into specic event object.
 		if err := json.Unmarshal(rawEvent, &event); err != nil {
 			event = &Unknown{
-				EventCommon: EventCommon{Type: typeLookup.EventType()},
+				EventCommon: typeLookup,
 				RawJSON:     rawEvent,
 				Error:       err,
 			}
@@ -188,9 +204,11 @@ type ECLogger interface {
 	ECLog() string
 }
 
-// EventCommon contains fields common to all types of Event objects
+// EventCommon contains fields common to all types of Event objects.
+// EventID is unique per event, and isn't sent for relay_message events.
 type EventCommon struct {
-	Type string `json:"type"`
+	Type    string `json:"type"`
+	EventID string `json:"event_id,omitempty"`
 }
 
 func (e EventCommon) EventType() string { return e.Type }
@@ -235,7 +253,10 @@ func (t *Timestamp) UnmarshalJSON(data []byte) error {
 	// Timestamps coming from Event Samples are in this RFC 3339-like format.
 	customTime, err := time.Parse("2006-01-02T15:04:05.000-07:00", string(data))
 	if err != nil {
-		return err
+		// Newer events, such as ingest events, use RFC 3339 with a Z suffix.
+		if customTime, err = time.Parse(time.RFC3339Nano, string(data)); err != nil {
+			return err
+		}
 	}
 
 	*t = Timestamp(customTime)
@@ -250,6 +271,27 @@ type GeoIP struct {
 	Longitude LatLong `json:"longitude"`
 }
 
+// Number is a numeric field that the API inconsistently returns as a number or a string.
+// It's kept as a string, so large ids aren't rounded.
+type Number string
+
+// Int returns the Number as an int, or zero if it's empty.
+func (n Number) Int() (int, error) {
+	if n == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(string(n))
+}
+
+func (n *Number) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*n = ""
+		return nil
+	}
+	*n = Number(bytes.Trim(data, `"`))
+	return nil
+}
+
 // The API inconsistently returns float or string. We need a custom unmarshaller.
 type LatLong float32
 
@@ -280,6 +322,7 @@ type Creation struct {
 	NodeName        string      `json:"node_name"`
 	Metadata        interface{} `json:"rcpt_meta"`
 	Tags            []string    `json:"rcpt_tags"`
+	SubaccountID    Number      `json:"subaccount_id"`
 	Submitted       string      `json:"submitted_rcpts"`
 	TemplateID      string      `json:"template_id"`
 	TemplateVersion string      `json:"template_version"`
@@ -3,8 +3,11 @@ package events
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"io/ioutil"
+	"reflect"
 	"testing"
+	"time"
 )
 
 func TestGeoIP(t *testing.T) {
@@ -68,3 +71,101 @@ func TestSampleWebhookValidationRequest(t *testing.T) {
 		t.Fatalf("expected zero events, got %d: %v", len(events), events)
 	}
 }
+
+// TestSampleEventFields checks that every event in the sample payloads decodes to its own
+// type, and that every field in the payload is mapped to a struct field.
+func TestSampleEventFields(t *testing.T) {
+	for _, file := range []string{"sample-events.json", "sample-events-current.json"} {
+		payload, err := ioutil.ReadFile("test/json/" + file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var batch []struct {
+			Msys map[string]map[string]json.RawMessage `json:"msys"`
+		}
+		if err = json.Unmarshal(payload, &batch); err != nil {
+			t.Fatal(err)
+		}
+		var events Events
+		if err = json.Unmarshal(payload, &events); err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != len(batch) {
+			t.Fatalf("%s => %d events, want %d", file, len(events), len(batch))
+		}
+
+		for idx, wrapper := range batch {
+			for _, raw := range wrapper.Msys {
+				var typ string
+				json.Unmarshal(raw["type"], &typ)
+				event := events[idx]
+				if unknown, ok := event.(*Unknown); ok {
+					t.Errorf("%s[%d] => %v", file, idx, unknown)
+					continue
+				} else if event.EventType() != typ {
+					t.Errorf("%s[%d] => type %q want %q", file, idx, event.EventType(), typ)
+				}
+				for name := range raw {
+					if _, ok := Field(event, name); !ok {
+						t.Errorf("%s[%d] => %s has no field for %q", file, idx, typ, name)
+					}
+				}
+				if s, ok := event.(fmt.Stringer); !ok || s.String() == "" {
+					t.Errorf("%s[%d] => %s has no String method", file, idx, typ)
+				}
+			}
+		}
+	}
+}
+
+func TestNewEventTypes(t *testing.T) {
+	payload, err := ioutil.ReadFile("test/json/sample-events-current.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var events Events
+	if err = json.Unmarshal(payload, &events); err != nil {
+		t.Fatal(err)
+	}
+
+	for idx, test := range []struct {
+		event Event
+		want  string
+	}{
+		{&AMPClick{}, "AC 65832150921904138 recipient@example.com => http://example.com"},
+		{&AMPOpen{}, "AO 65832150921904138 recipient@example.com"},
+		{&AMPInitialOpen{}, "AIO 65832150921904138 recipient@example.com"},
+		{&InitialOpen{}, "IO 65832150921904138 recipient@example.com"},
+		{&Click{}, "C 65832150921904138 recipient@example.com => http://example.com"},
+		{&Delivery{}, "D 65832150921904138  => recipient@example.com"},
+		{&Bounce{}, "B 65832150921904138  => recipient@example.com 1: 000 Example Remote MTA Bounce Message"},
+		{&ABTestCompleted{}, "ABC password-reset v1 => password_reset_variant1"},
+		{&ABTestCancelled{}, "ABX password-reset v1"},
+		{&IngestSuccess{}, "IS 032d330540298f54f0e8bcc1373f3cfd 125 ok, 0 failed, 0 duplicate"},
+		{&IngestError{}, "IE 032d330540298f54f0e8bcc1373f3cfd validation"},
+	} {
+		if reflect.TypeOf(events[idx]) != reflect.TypeOf(test.event) {
+			t.Errorf("NewEventTypes[%d] => %T want %T", idx, events[idx], test.event)
+			continue
+		}
+		ts, _ := Field(events[idx], "timestamp")
+		if s := events[idx].(fmt.Stringer).String(); s != ts+" "+test.want {
+			t.Errorf("NewEventTypes[%d] => %q want %q", idx, s, ts+" "+test.want)
+		}
+	}
+
+	d := events[5].(*Delivery)
+	if d.MailboxProvider != "Gsuite" || d.ReceiveProtocol != "rest" || d.QueueTime != "3004" ||
+		d.FriendlyFrom != "sender@example.com" || d.EventID != "92356927693813856" || d.SMS.Segments != "1" {
+		t.Errorf("NewEventTypes => %+v", d)
+	}
+	if id, err := d.SubaccountID.Int(); err != nil || id != 101 {
+		t.Errorf("NewEventTypes => subaccount %d, %v", id, err)
+	}
+	if ab := events[7].(*ABTestCompleted).ABTest; len(ab.Variants) != 1 || ab.Variants[0].EngagementRate != 0.2 {
+		t.Errorf("NewEventTypes => %+v", ab)
+	}
+	if first := time.Time(events[9].(*IngestSuccess).FirstReceivedTimestamp); !first.Equal(time.Date(2018, 9, 10, 21, 38, 8, 0, time.UTC)) {
+		t.Errorf("NewEventTypes => first received %v", first)
+	}
+}
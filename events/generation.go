@@ -4,23 +4,36 @@ import "fmt"
 
 type GenerationFailure struct {
 	EventCommon
-	Binding          string      `json:"binding"`
-	BindingGroup     string      `json:"binding_group"`
-	CampaignID       string      `json:"campaign_id"`
-	CustomerID       string      `json:"customer_id"`
-	ErrorCode        string      `json:"error_code"`
-	Metadata         interface{} `json:"rcpt_meta"`
-	SubstitutionData interface{} `json:"rcpt_subs"`
-	Tags             []string    `json:"rcpt_tags"`
-	Recipient        string      `json:"rcpt_to"`
-	RawReason        string      `json:"raw_reason"`
-	Reason           string      `json:"reason"`
-	ReceiveProtocol  string      `json:"recv_method"`
-	RoutingDomain    string      `json:"routing_domain"`
-	TemplateID       string      `json:"template_id"`
-	TemplateVersion  string      `json:"template_version"`
-	Timestamp        Timestamp   `json:"timestamp"`
-	TransmissionID   string      `json:"transmission_id"`
+	ABTestID              string      `json:"ab_test_id"`
+	ABTestVersion         Number      `json:"ab_test_version"`
+	AMPEnabled            bool        `json:"amp_enabled"`
+	Binding               string      `json:"binding"`
+	BindingGroup          string      `json:"binding_group"`
+	CampaignID            string      `json:"campaign_id"`
+	CustomerID            string      `json:"customer_id"`
+	ErrorCode             string      `json:"error_code"`
+	FriendlyFrom          string      `json:"friendly_from"`
+	InjectionTime         Timestamp   `json:"injection_time"`
+	IPPool                string      `json:"ip_pool"`
+	MailboxProvider       string      `json:"mailbox_provider"`
+	MailboxProviderRegion string      `json:"mailbox_provider_region"`
+	RawRecipient          string      `json:"raw_rcpt_to"`
+	Metadata              interface{} `json:"rcpt_meta"`
+	SubstitutionData      interface{} `json:"rcpt_subs"`
+	Tags                  []string    `json:"rcpt_tags"`
+	Recipient             string      `json:"rcpt_to"`
+	RawReason             string      `json:"raw_reason"`
+	Reason                string      `json:"reason"`
+	RecipientDomain       string      `json:"recipient_domain"`
+	ReceiveProtocol       string      `json:"recv_method"`
+	RoutingDomain         string      `json:"routing_domain"`
+	SendingIP             string      `json:"sending_ip"`
+	SubaccountID          Number      `json:"subaccount_id"`
+	Subject               string      `json:"subject"`
+	TemplateID            string      `json:"template_id"`
+	TemplateVersion       string      `json:"template_version"`
+	Timestamp             Timestamp   `json:"timestamp"`
+	TransmissionID        string      `json:"transmission_id"`
 }
 
 // String returns a brief summary of a GenerationFailure event
@@ -4,28 +4,43 @@ import "fmt"
 
 type Delivery struct {
 	EventCommon
-	Binding         string      `json:"binding"`
-	BindingGroup    string      `json:"binding_group"`
-	CampaignID      string      `json:"campaign_id"`
-	CustomerID      string      `json:"customer_id"`
-	DeliveryMethod  string      `json:"delv_method"`
-	DeviceToken     string      `json:"device_token"`
-	IPAddress       string      `json:"ip_address"`
-	MessageID       string      `json:"message_id"`
-	MessageFrom     string      `json:"msg_from"`
-	MessageSize     string      `json:"msg_size"`
-	Retries         string      `json:"num_retries"`
-	QueueTime       string      `json:"queue_time"`
-	Metadata        interface{} `json:"rcpt_meta"`
-	Tags            []string    `json:"rcpt_tags"`
-	Recipient       string      `json:"rcpt_to"`
-	RecipientType   string      `json:"rcpt_type"`
-	ReceiveProtocol string      `json:"recv_method"`
-	RoutingDomain   string      `json:"routing_domain"`
-	TemplateID      string      `json:"template_id"`
-	TemplateVersion string      `json:"template_version"`
-	Timestamp       Timestamp   `json:"timestamp"`
-	TransmissionID  string      `json:"transmission_id"`
+	SMS
+	ABTestID              string      `json:"ab_test_id"`
+	ABTestVersion         Number      `json:"ab_test_version"`
+	AMPEnabled            bool        `json:"amp_enabled"`
+	Binding               string      `json:"binding"`
+	BindingGroup          string      `json:"binding_group"`
+	CampaignID            string      `json:"campaign_id"`
+	CustomerID            string      `json:"customer_id"`
+	DeliveryMethod        string      `json:"delv_method"`
+	DeviceToken           string      `json:"device_token"`
+	FriendlyFrom          string      `json:"friendly_from"`
+	InjectionTime         Timestamp   `json:"injection_time"`
+	IPAddress             string      `json:"ip_address"`
+	IPPool                string      `json:"ip_pool"`
+	MailboxProvider       string      `json:"mailbox_provider"`
+	MailboxProviderRegion string      `json:"mailbox_provider_region"`
+	MessageID             string      `json:"message_id"`
+	MessageFrom           string      `json:"msg_from"`
+	MessageSize           string      `json:"msg_size"`
+	Retries               string      `json:"num_retries"`
+	OutboundTLS           string      `json:"outbound_tls"`
+	QueueTime             string      `json:"queue_time"`
+	RawRecipient          string      `json:"raw_rcpt_to"`
+	Metadata              interface{} `json:"rcpt_meta"`
+	Tags                  []string    `json:"rcpt_tags"`
+	Recipient             string      `json:"rcpt_to"`
+	RecipientType         string      `json:"rcpt_type"`
+	RecipientDomain       string      `json:"recipient_domain"`
+	ReceiveProtocol       string      `json:"recv_method"`
+	RoutingDomain         string      `json:"routing_domain"`
+	SendingIP             string      `json:"sending_ip"`
+	SubaccountID          Number      `json:"subaccount_id"`
+	Subject               string      `json:"subject"`
+	TemplateID            string      `json:"template_id"`
+	TemplateVersion       string      `json:"template_version"`
+	Timestamp             Timestamp   `json:"timestamp"`
+	TransmissionID        string      `json:"transmission_id"`
 }
 
 // String returns a brief summary of a Delivery event
@@ -44,26 +59,39 @@ func (d *Delivery) ECLog() string {
 
 type Injection struct {
 	EventCommon
-	Binding         string      `json:"binding"`
-	BindingGroup    string      `json:"binding_group"`
-	CampaignID      string      `json:"campaign_id"`
-	CustomerID      string      `json:"customer_id"`
-	MessageID       string      `json:"message_id"`
-	MessageFrom     string      `json:"msg_from"`
-	MessageSize     string      `json:"msg_size"`
-	Metadata        interface{} `json:"rcpt_meta"`
-	Pathway         string      `json:"pathway"`
-	PathwayGroup    string      `json:"pathway_group"`
-	Tags            []string    `json:"rcpt_tags"`
-	Recipient       string      `json:"rcpt_to"`
-	RecipientType   string      `json:"rcpt_type"`
-	ReceiveProtocol string      `json:"recv_method"`
-	RoutingDomain   string      `json:"routing_domain"`
-	Subject         string      `json:"subject"`
-	TemplateID      string      `json:"template_id"`
-	TemplateVersion string      `json:"template_version"`
-	Timestamp       Timestamp   `json:"timestamp"`
-	TransmissionID  string      `json:"transmission_id"`
+	SMS
+	ABTestID              string      `json:"ab_test_id"`
+	ABTestVersion         Number      `json:"ab_test_version"`
+	AMPEnabled            bool        `json:"amp_enabled"`
+	Binding               string      `json:"binding"`
+	BindingGroup          string      `json:"binding_group"`
+	CampaignID            string      `json:"campaign_id"`
+	CustomerID            string      `json:"customer_id"`
+	FriendlyFrom          string      `json:"friendly_from"`
+	InjectionTime         Timestamp   `json:"injection_time"`
+	IPPool                string      `json:"ip_pool"`
+	MailboxProvider       string      `json:"mailbox_provider"`
+	MailboxProviderRegion string      `json:"mailbox_provider_region"`
+	MessageID             string      `json:"message_id"`
+	MessageFrom           string      `json:"msg_from"`
+	MessageSize           string      `json:"msg_size"`
+	RawRecipient          string      `json:"raw_rcpt_to"`
+	Metadata              interface{} `json:"rcpt_meta"`
+	Pathway               string      `json:"pathway"`
+	PathwayGroup          string      `json:"pathway_group"`
+	Tags                  []string    `json:"rcpt_tags"`
+	Recipient             string      `json:"rcpt_to"`
+	RecipientType         string      `json:"rcpt_type"`
+	RecipientDomain       string      `json:"recipient_domain"`
+	ReceiveProtocol       string      `json:"recv_method"`
+	RoutingDomain         string      `json:"routing_domain"`
+	SendingIP             string      `json:"sending_ip"`
+	SubaccountID          Number      `json:"subaccount_id"`
+	Subject               string      `json:"subject"`
+	TemplateID            string      `json:"template_id"`
+	TemplateVersion       string      `json:"template_version"`
+	Timestamp             Timestamp   `json:"timestamp"`
+	TransmissionID        string      `json:"transmission_id"`
 }
 
 // String returns a brief summary of a GenerationFailure event
@@ -83,32 +111,46 @@ func (i *Injection) ECLog() string {
 
 type Bounce struct {
 	EventCommon
-	Binding         string            `json:"binding"`
-	BindingGroup    string            `json:"binding_group"`
-	BounceClass     string            `json:"bounce_class"`
-	CampaignID      string            `json:"campaign_id"`
-	CustomerID      string            `json:"customer_id"`
-	DeliveryMethod  string            `json:"delv_method"`
-	DeviceToken     string            `json:"device_token"`
-	ErrorCode       string            `json:"error_code"`
-	IPAddress       string            `json:"ip_address"`
-	MessageID       string            `json:"message_id"`
-	MessageFrom     string            `json:"msg_from"`
-	MessageSize     string            `json:"msg_size"`
-	Retries         string            `json:"num_retries"`
-	Metadata        map[string]string `json:"rcpt_meta"`
-	Tags            []string          `json:"rcpt_tags"`
-	Recipient       string            `json:"rcpt_to"`
-	RecipientType   string            `json:"rcpt_type"`
-	RawReason       string            `json:"raw_reason"`
-	Reason          string            `json:"reason"`
-	ReceiveProtocol string            `json:"recv_method"`
-	RoutingDomain   string            `json:"routing_domain"`
-	Subject         string            `json:"subject"`
-	TemplateID      string            `json:"template_id"`
-	TemplateVersion string            `json:"template_version"`
-	Timestamp       Timestamp         `json:"timestamp"`
-	TransmissionID  string            `json:"transmission_id"`
+	SMS
+	ABTestID              string            `json:"ab_test_id"`
+	ABTestVersion         Number            `json:"ab_test_version"`
+	AMPEnabled            bool              `json:"amp_enabled"`
+	Binding               string            `json:"binding"`
+	BindingGroup          string            `json:"binding_group"`
+	BounceClass           string            `json:"bounce_class"`
+	CampaignID            string            `json:"campaign_id"`
+	CustomerID            string            `json:"customer_id"`
+	DeliveryMethod        string            `json:"delv_method"`
+	DeviceToken           string            `json:"device_token"`
+	ErrorCode             string            `json:"error_code"`
+	FriendlyFrom          string            `json:"friendly_from"`
+	InjectionTime         Timestamp         `json:"injection_time"`
+	IPAddress             string            `json:"ip_address"`
+	IPPool                string            `json:"ip_pool"`
+	MailboxProvider       string            `json:"mailbox_provider"`
+	MailboxProviderRegion string            `json:"mailbox_provider_region"`
+	MessageID             string            `json:"message_id"`
+	MessageFrom           string            `json:"msg_from"`
+	MessageSize           string            `json:"msg_size"`
+	Retries               string            `json:"num_retries"`
+	QueueTime             string            `json:"queue_time"`
+	RawRecipient          string            `json:"raw_rcpt_to"`
+	Metadata              map[string]string `json:"rcpt_meta"`
+	Tags                  []string          `json:"rcpt_tags"`
+	Recipient             string            `json:"rcpt_to"`
+	RecipientType         string            `json:"rcpt_type"`
+	RawReason             string            `json:"raw_reason"`
+	Reason                string            `json:"reason"`
+	RecipientDomain       string            `json:"recipient_domain"`
+	ReceiveProtocol       string            `json:"recv_method"`
+	RoutingDomain         string            `json:"routing_domain"`
+	SendingIP             string            `json:"sending_ip"`
+	SubaccountID          Number            `json:"subaccount_id"`
+	Subject               string            `json:"subject"`
+	TemplateID            string            `json:"template_id"`
+	TemplateVersion       string            `json:"template_version"`
+	Timestamp             Timestamp         `json:"timestamp"`
+	TransmissionID        string            `json:"transmission_id"`
 }
 
 // String returns a brief summary of a Bounce event
@@ -129,24 +171,36 @@ func (b *Bounce) ECLog() string {
 
 type OutOfBand struct {
 	EventCommon
-	Binding         string    `json:"binding"`
-	BindingGroup    string    `json:"binding_group"`
-	BounceClass     string    `json:"bounce_class"`
-	CampaignID      string    `json:"campaign_id"`
-	CustomerID      string    `json:"customer_id"`
-	DeliveryMethod  string    `json:"delv_method"`
-	DeviceToken     string    `json:"device_token"`
-	ErrorCode       string    `json:"error_code"`
-	MessageID       string    `json:"message_id"`
-	MessageFrom     string    `json:"msg_from"`
-	Recipient       string    `json:"rcpt_to"`
-	RawReason       string    `json:"raw_reason"`
-	Reason          string    `json:"reason"`
-	ReceiveProtocol string    `json:"recv_method"`
-	RoutingDomain   string    `json:"routing_domain"`
-	TemplateID      string    `json:"template_id"`
-	TemplateVersion string    `json:"template_version"`
-	Timestamp       Timestamp `json:"timestamp"`
+	ABTestID              string    `json:"ab_test_id"`
+	ABTestVersion         Number    `json:"ab_test_version"`
+	AMPEnabled            bool      `json:"amp_enabled"`
+	Binding               string    `json:"binding"`
+	BindingGroup          string    `json:"binding_group"`
+	BounceClass           string    `json:"bounce_class"`
+	CampaignID            string    `json:"campaign_id"`
+	CustomerID            string    `json:"customer_id"`
+	DeliveryMethod        string    `json:"delv_method"`
+	DeviceToken           string    `json:"device_token"`
+	ErrorCode             string    `json:"error_code"`
+	FriendlyFrom          string    `json:"friendly_from"`
+	InjectionTime         Timestamp `json:"injection_time"`
+	IPPool                string    `json:"ip_pool"`
+	MailboxProvider       string    `json:"mailbox_provider"`
+	MailboxProviderRegion string    `json:"mailbox_provider_region"`
+	MessageID             string    `json:"message_id"`
+	MessageFrom           string    `json:"msg_from"`
+	RawRecipient          string    `json:"raw_rcpt_to"`
+	Recipient             string    `json:"rcpt_to"`
+	RawReason             string    `json:"raw_reason"`
+	Reason                string    `json:"reason"`
+	RecipientDomain       string    `json:"recipient_domain"`
+	ReceiveProtocol       string    `json:"recv_method"`
+	RoutingDomain         string    `json:"routing_domain"`
+	SendingIP             string    `json:"sending_ip"`
+	SubaccountID          Number    `json:"subaccount_id"`
+	TemplateID            string    `json:"template_id"`
+	TemplateVersion       string    `json:"template_version"`
+	Timestamp             Timestamp `json:"timestamp"`
 }
 
 // String returns a brief summary of a Bounce event
@@ -166,26 +220,37 @@ func (b *OutOfBand) ECLog() string {
 
 type SpamComplaint struct {
 	EventCommon
-	Binding         string      `json:"binding"`
-	BindingGroup    string      `json:"binding_group"`
-	CampaignID      string      `json:"campaign_id"`
-	CustomerID      string      `json:"customer_id"`
-	DeliveryMethod  string      `json:"delv_method"`
-	FeedbackType    string      `json:"fbtype"`
-	FriendlyFrom    string      `json:"friendly_from"`
-	MessageID       string      `json:"message_id"`
-	Metadata        interface{} `json:"rcpt_meta"`
-	Tags            []string    `json:"rcpt_tags"`
-	Recipient       string      `json:"rcpt_to"`
-	RecipientType   string      `json:"rcpt_type"`
-	ReportedBy      string      `json:"report_by"`
-	ReportedTo      string      `json:"report_to"`
-	Subject         string      `json:"subject"`
-	TemplateID      string      `json:"template_id"`
-	TemplateVersion string      `json:"template_version"`
-	Timestamp       Timestamp   `json:"timestamp"`
-	TransmissionID  string      `json:"transmission_id"`
-	UserString      string      `json:"user_str"`
+	ABTestID              string      `json:"ab_test_id"`
+	ABTestVersion         Number      `json:"ab_test_version"`
+	AMPEnabled            bool        `json:"amp_enabled"`
+	Binding               string      `json:"binding"`
+	BindingGroup          string      `json:"binding_group"`
+	CampaignID            string      `json:"campaign_id"`
+	CustomerID            string      `json:"customer_id"`
+	DeliveryMethod        string      `json:"delv_method"`
+	FeedbackType          string      `json:"fbtype"`
+	FriendlyFrom          string      `json:"friendly_from"`
+	InjectionTime         Timestamp   `json:"injection_time"`
+	IPPool                string      `json:"ip_pool"`
+	MailboxProvider       string      `json:"mailbox_provider"`
+	MailboxProviderRegion string      `json:"mailbox_provider_region"`
+	MessageID             string      `json:"message_id"`
+	RawRecipient          string      `json:"raw_rcpt_to"`
+	Metadata              interface{} `json:"rcpt_meta"`
+	Tags                  []string    `json:"rcpt_tags"`
+	Recipient             string      `json:"rcpt_to"`
+	RecipientType         string      `json:"rcpt_type"`
+	RecipientDomain       string      `json:"recipient_domain"`
+	ReportedBy            string      `json:"report_by"`
+	ReportedTo            string      `json:"report_to"`
+	SendingIP             string      `json:"sending_ip"`
+	SubaccountID          Number      `json:"subaccount_id"`
+	Subject               string      `json:"subject"`
+	TemplateID            string      `json:"template_id"`
+	TemplateVersion       string      `json:"template_version"`
+	Timestamp             Timestamp   `json:"timestamp"`
+	TransmissionID        string      `json:"transmission_id"`
+	UserString            string      `json:"user_str"`
 }
 
 // String returns a brief summary of a SpamComplaint event
@@ -196,24 +261,37 @@ func (p *SpamComplaint) String() string {
 
 type PolicyRejection struct {
 	EventCommon
-	CampaignID      string      `json:"campaign_id"`
-	CustomerID      string      `json:"customer_id"`
-	ErrorCode       string      `json:"error_code"`
-	MessageID       string      `json:"message_id"`
-	MessageFrom     string      `json:"msg_from"`
-	Metadata        interface{} `json:"rcpt_meta"`
-	Pathway         string      `json:"pathway"`
-	PathwayGroup    string      `json:"pathway_group"`
-	Tags            []string    `json:"rcpt_tags"`
-	RawReason       string      `json:"raw_reason"`
-	Reason          string      `json:"reason"`
-	Recipient       string      `json:"rcpt_to"`
-	RecipientType   string      `json:"rcpt_type"`
-	ReceiveProtocol string      `json:"recv_method"`
-	TemplateID      string      `json:"template_id"`
-	TemplateVersion string      `json:"template_version"`
-	Timestamp       Timestamp   `json:"timestamp"`
-	TransmissionID  string      `json:"transmission_id"`
+	ABTestID              string      `json:"ab_test_id"`
+	ABTestVersion         Number      `json:"ab_test_version"`
+	AMPEnabled            bool        `json:"amp_enabled"`
+	CampaignID            string      `json:"campaign_id"`
+	CustomerID            string      `json:"customer_id"`
+	ErrorCode             string      `json:"error_code"`
+	FriendlyFrom          string      `json:"friendly_from"`
+	InjectionTime         Timestamp   `json:"injection_time"`
+	IPPool                string      `json:"ip_pool"`
+	MailboxProvider       string      `json:"mailbox_provider"`
+	MailboxProviderRegion string      `json:"mailbox_provider_region"`
+	MessageID             string      `json:"message_id"`
+	MessageFrom           string      `json:"msg_from"`
+	RawRecipient          string      `json:"raw_rcpt_to"`
+	Metadata              interface{} `json:"rcpt_meta"`
+	Pathway               string      `json:"pathway"`
+	PathwayGroup          string      `json:"pathway_group"`
+	Tags                  []string    `json:"rcpt_tags"`
+	RawReason             string      `json:"raw_reason"`
+	Reason                string      `json:"reason"`
+	Recipient             string      `json:"rcpt_to"`
+	RecipientType         string      `json:"rcpt_type"`
+	RecipientDomain       string      `json:"recipient_domain"`
+	ReceiveProtocol       string      `json:"recv_method"`
+	RemoteAddress         string      `json:"remote_addr"`
+	SendingIP             string      `json:"sending_ip"`
+	SubaccountID          Number      `json:"subaccount_id"`
+	TemplateID            string      `json:"template_id"`
+	TemplateVersion       string      `json:"template_version"`
+	Timestamp             Timestamp   `json:"timestamp"`
+	TransmissionID        string      `json:"transmission_id"`
 }
 
 // String returns a brief summary of a PolicyRejection event
@@ -225,32 +303,46 @@ func (p *PolicyRejection) String() string {
 
 type Delay struct {
 	EventCommon
-	Binding         string      `json:"binding"`
-	BindingGroup    string      `json:"binding_group"`
-	BounceClass     string      `json:"bounce_class"`
-	CampaignID      string      `json:"campaign_id"`
-	CustomerID      string      `json:"customer_id"`
-	DeliveryMethod  string      `json:"delv_method"`
-	DeviceToken     string      `json:"device_token"`
-	ErrorCode       string      `json:"error_code"`
-	IPAddress       string      `json:"ip_address"`
-	MessageID       string      `json:"message_id"`
-	MessageFrom     string      `json:"msg_from"`
-	MessageSize     string      `json:"msg_size"`
-	Retries         string      `json:"num_retries"`
-	QueueTime       string      `json:"queue_time"`
-	Metadata        interface{} `json:"rcpt_meta"`
-	Tags            []string    `json:"rcpt_tags"`
-	Recipient       string      `json:"rcpt_to"`
-	RecipientType   string      `json:"rcpt_type"`
-	RawReason       string      `json:"raw_reason"`
-	Reason          string      `json:"reason"`
-	RoutingDomain   string      `json:"routing_domain"`
-	Subject         string      `json:"subject"`
-	TemplateID      string      `json:"template_id"`
-	TemplateVersion string      `json:"template_version"`
-	Timestamp       Timestamp   `json:"timestamp"`
-	TransmissionID  string      `json:"transmission_id"`
+	SMS
+	ABTestID              string      `json:"ab_test_id"`
+	ABTestVersion         Number      `json:"ab_test_version"`
+	AMPEnabled            bool        `json:"amp_enabled"`
+	Binding               string      `json:"binding"`
+	BindingGroup          string      `json:"binding_group"`
+	BounceClass           string      `json:"bounce_class"`
+	CampaignID            string      `json:"campaign_id"`
+	CustomerID            string      `json:"customer_id"`
+	DeliveryMethod        string      `json:"delv_method"`
+	DeviceToken           string      `json:"device_token"`
+	ErrorCode             string      `json:"error_code"`
+	FriendlyFrom          string      `json:"friendly_from"`
+	InjectionTime         Timestamp   `json:"injection_time"`
+	IPAddress             string      `json:"ip_address"`
+	IPPool                string      `json:"ip_pool"`
+	MailboxProvider       string      `json:"mailbox_provider"`
+	MailboxProviderRegion string      `json:"mailbox_provider_region"`
+	MessageID             string      `json:"message_id"`
+	MessageFrom           string      `json:"msg_from"`
+	MessageSize           string      `json:"msg_size"`
+	Retries               string      `json:"num_retries"`
+	QueueTime             string      `json:"queue_time"`
+	RawRecipient          string      `json:"raw_rcpt_to"`
+	Metadata              interface{} `json:"rcpt_meta"`
+	Tags                  []string    `json:"rcpt_tags"`
+	Recipient             string      `json:"rcpt_to"`
+	RecipientType         string      `json:"rcpt_type"`
+	RawReason             string      `json:"raw_reason"`
+	Reason                string      `json:"reason"`
+	RecipientDomain       string      `json:"recipient_domain"`
+	ReceiveProtocol       string      `json:"recv_method"`
+	RoutingDomain         string      `json:"routing_domain"`
+	SendingIP             string      `json:"sending_ip"`
+	SubaccountID          Number      `json:"subaccount_id"`
+	Subject               string      `json:"subject"`
+	TemplateID            string      `json:"template_id"`
+	TemplateVersion       string      `json:"template_version"`
+	Timestamp             Timestamp   `json:"timestamp"`
+	TransmissionID        string      `json:"transmission_id"`
 }
 
 // String returns a brief summary of a Delay event
@@ -268,30 +360,45 @@ func (d *Delay) ECLog() string {
 		d.IPAddress, d.RawReason)
 }
 
+// SMS contains the fields sent with message events when the recipient is a phone number.
+type SMS struct {
+	Coding         string   `json:"sms_coding,omitempty"`
+	Destination    string   `json:"sms_dst,omitempty"`
+	DestinationNPI string   `json:"sms_dst_npi,omitempty"`
+	DestinationTON string   `json:"sms_dst_ton,omitempty"`
+	RemoteIDs      []string `json:"sms_remoteids,omitempty"`
+	Segments       Number   `json:"sms_segments,omitempty"`
+	Source         string   `json:"sms_src,omitempty"`
+	SourceNPI      string   `json:"sms_src_npi,omitempty"`
+	SourceTON      string   `json:"sms_src_ton,omitempty"`
+	Text           string   `json:"sms_text,omitempty"`
+}
+
 type SMSStatus struct {
 	EventCommon
-	CustomerID     string `json:"customer_id"`
-	DeliveryMethod string `json:"delv_method"`
-	// TODO: `json:"dr_latency"`
-	IPAddress      string   `json:"ip_address"`
-	RawReason      string   `json:"raw_reason"`
-	Reason         string   `json:"reason"`
-	RoutingDomain  string   `json:"routing_domain"`
-	Destination    string   `json:"sms_dst"`
-	DestinationNPI string   `json:"sms_dst_npi"`
-	DestinationTON string   `json:"sms_dst_ton"`
-	RemoteIDs      []string `json:"sms_remoteids"`
-	Source         string   `json:"sms_src"`
-	SourceNPI      string   `json:"sms_src_npi"`
-	SourceTON      string   `json:"sms_src_ton"`
-	Text           string   `json:"sms_text"`
-	StatusType     string   `json:"stat_type"`
-	StatusState    string   `json:"stat_state"`
-	// TODO: SubAccountID string `json:"subaccount_id"`
-	Timestamp Timestamp `json:"timestamp"`
+	CustomerID     string    `json:"customer_id"`
+	DeliveryMethod string    `json:"delv_method"`
+	DRLatency      string    `json:"dr_latency"`
+	IPAddress      string    `json:"ip_address"`
+	RawReason      string    `json:"raw_reason"`
+	Reason         string    `json:"reason"`
+	RoutingDomain  string    `json:"routing_domain"`
+	Destination    string    `json:"sms_dst"`
+	DestinationNPI string    `json:"sms_dst_npi"`
+	DestinationTON string    `json:"sms_dst_ton"`
+	RemoteIDs      []string  `json:"sms_remoteids"`
+	Source         string    `json:"sms_src"`
+	SourceNPI      string    `json:"sms_src_npi"`
+	SourceTON      string    `json:"sms_src_ton"`
+	Text           string    `json:"sms_text"`
+	StatusType     string    `json:"stat_type"`
+	StatusState    string    `json:"stat_state"`
+	SubaccountID   Number    `json:"subaccount_id"`
+	Timestamp      Timestamp `json:"timestamp"`
 }
 
-// String returns a brief summary of a Delay event
+// String returns a brief summary of an SMSStatus event
 func (e *SMSStatus) String() string {
-	return fmt.Sprintf("%s SMS", e.Timestamp) // TODO: Improve message.
+	return fmt.Sprintf("%s SMS %s => %s %s/%s: %s",
+		e.Timestamp, e.Source, e.Destination, e.StatusType, e.StatusState, e.RawReason)
 }
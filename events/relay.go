@@ -11,10 +11,12 @@ type RelayInjection struct {
 	MessageSize     string    `json:"msg_size"`
 	Pathway         string    `json:"pathway"`
 	PathwayGroup    string    `json:"pathway_group"`
+	RawRecipient    string    `json:"raw_rcpt_to"`
 	Recipient       string    `json:"rcpt_to"`
 	ReceiveProtocol string    `json:"recv_method"`
 	RelayID         string    `json:"relay_id"`
 	RoutingDomain   string    `json:"routing_domain"`
+	SubaccountID    Number    `json:"subaccount_id"`
 	Timestamp       Timestamp `json:"timestamp"`
 }
 
@@ -31,12 +33,14 @@ type RelayRejection struct {
 	MessageFrom     string    `json:"msg_from"`
 	Pathway         string    `json:"pathway"`
 	PathwayGroup    string    `json:"pathway_group"`
+	RawRecipient    string    `json:"raw_rcpt_to"`
 	RawReason       string    `json:"raw_reason"`
 	Reason          string    `json:"reason"`
 	Recipient       string    `json:"rcpt_to"`
 	ReceiveProtocol string    `json:"recv_method"`
 	RelayID         string    `json:"relay_id"`
 	RemoteAddress   string    `json:"remote_addr"`
+	SubaccountID    Number    `json:"subaccount_id"`
 	Timestamp       Timestamp `json:"timestamp"`
 }
 
@@ -60,6 +64,7 @@ type RelayDelivery struct {
 	RelayID         string    `json:"relay_id"`
 	Retries         string    `json:"num_retries"`
 	RoutingDomain   string    `json:"routing_domain"`
+	SubaccountID    Number    `json:"subaccount_id"`
 	Timestamp       Timestamp `json:"timestamp"`
 }
 
@@ -86,6 +91,7 @@ type RelayTempfail struct {
 	ReceiveProtocol string    `json:"recv_method"`
 	RelayID         string    `json:"relay_id"`
 	RoutingDomain   string    `json:"routing_domain"`
+	SubaccountID    Number    `json:"subaccount_id"`
 	Timestamp       Timestamp `json:"timestamp"`
 }
 
@@ -0,0 +1,324 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// HandlerFunc processes a single event.
+type HandlerFunc func(e Event) error
+
+// Middleware wraps the HandlerFunc a Router uses to dispatch each event.
+// Returning nil without calling next skips the event.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Router dispatches events to handlers registered for their concrete type,
+// replacing type switches like the one in ECLog. Events with no registered handler
+// go to the Fallback handler, if one is set, and are otherwise ignored.
+// Events that couldn't be decoded are delivered as *Unknown, and are handled with OnUnknown.
+//
+// Register handlers and middleware before dispatching; a Router isn't safe for
+// concurrent registration.
+type Router struct {
+	// Workers is the number of events handled concurrently. Values below 2 handle
+	// events one at a time, in order.
+	Workers int
+
+	handlers   map[reflect.Type][]HandlerFunc
+	fallback   HandlerFunc
+	middleware []Middleware
+}
+
+// NewRouter returns a Router that handles up to workers events concurrently.
+func NewRouter(workers int) *Router {
+	return &Router{Workers: workers}
+}
+
+// Handle registers fn for events of type T, which must be a concrete event type such as *Bounce.
+// Each event is passed to every handler registered for its type.
+func Handle[T Event](r *Router, fn func(T) error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() == reflect.Interface {
+		panic(fmt.Sprintf("events: Handle requires a concrete event type, not %s", t))
+	}
+	if r.handlers == nil {
+		r.handlers = map[reflect.Type][]HandlerFunc{}
+	}
+	r.handlers[t] = append(r.handlers[t], func(e Event) error { return fn(e.(T)) })
+}
+
+// Fallback sets the handler for events with no handler registered for their type.
+func (r *Router) Fallback(fn HandlerFunc) { r.fallback = fn }
+
+// Use appends middleware, which runs for every event in the order it was added.
+func (r *Router) Use(mw ...Middleware) { r.middleware = append(r.middleware, mw...) }
+
+func (r *Router) OnBounce(fn func(*Bounce) error)                           { Handle(r, fn) }
+func (r *Router) OnClick(fn func(*Click) error)                             { Handle(r, fn) }
+func (r *Router) OnCreation(fn func(*Creation) error)                       { Handle(r, fn) }
+func (r *Router) OnDelay(fn func(*Delay) error)                             { Handle(r, fn) }
+func (r *Router) OnDelivery(fn func(*Delivery) error)                       { Handle(r, fn) }
+func (r *Router) OnGenerationFailure(fn func(*GenerationFailure) error)     { Handle(r, fn) }
+func (r *Router) OnGenerationRejection(fn func(*GenerationRejection) error) { Handle(r, fn) }
+func (r *Router) OnInjection(fn func(*Injection) error)                     { Handle(r, fn) }
+func (r *Router) OnLinkUnsubscribe(fn func(*LinkUnsubscribe) error)         { Handle(r, fn) }
+func (r *Router) OnListUnsubscribe(fn func(*ListUnsubscribe) error)         { Handle(r, fn) }
+func (r *Router) OnOpen(fn func(*Open) error)                               { Handle(r, fn) }
+func (r *Router) OnOutOfBand(fn func(*OutOfBand) error)                     { Handle(r, fn) }
+func (r *Router) OnPolicyRejection(fn func(*PolicyRejection) error)         { Handle(r, fn) }
+func (r *Router) OnRelayDelivery(fn func(*RelayDelivery) error)             { Handle(r, fn) }
+func (r *Router) OnRelayInjection(fn func(*RelayInjection) error)           { Handle(r, fn) }
+func (r *Router) OnRelayMessage(fn func(*RelayMessage) error)               { Handle(r, fn) }
+func (r *Router) OnRelayPermfail(fn func(*RelayPermfail) error)             { Handle(r, fn) }
+func (r *Router) OnRelayRejection(fn func(*RelayRejection) error)           { Handle(r, fn) }
+func (r *Router) OnRelayTempfail(fn func(*RelayTempfail) error)             { Handle(r, fn) }
+func (r *Router) OnSMSStatus(fn func(*SMSStatus) error)                     { Handle(r, fn) }
+func (r *Router) OnSpamComplaint(fn func(*SpamComplaint) error)             { Handle(r, fn) }
+func (r *Router) OnUnknown(fn func(*Unknown) error)                         { Handle(r, fn) }
+func (r *Router) OnInitialOpen(fn func(*InitialOpen) error)                 { Handle(r, fn) }
+func (r *Router) OnAMPClick(fn func(*AMPClick) error)                       { Handle(r, fn) }
+func (r *Router) OnAMPOpen(fn func(*AMPOpen) error)                         { Handle(r, fn) }
+func (r *Router) OnAMPInitialOpen(fn func(*AMPInitialOpen) error)           { Handle(r, fn) }
+func (r *Router) OnABTestCompleted(fn func(*ABTestCompleted) error)         { Handle(r, fn) }
+func (r *Router) OnABTestCancelled(fn func(*ABTestCancelled) error)         { Handle(r, fn) }
+func (r *Router) OnIngestSuccess(fn func(*IngestSuccess) error)             { Handle(r, fn) }
+func (r *Router) OnIngestError(fn func(*IngestError) error)                 { Handle(r, fn) }
+
+// route passes e to each handler registered for its type, joining their errors.
+func (r *Router) route(e Event) error {
+	handlers := r.handlers[reflect.TypeOf(e)]
+	if len(handlers) == 0 {
+		if r.fallback != nil {
+			return r.fallback(e)
+		}
+		return nil
+	}
+	var errs []error
+	for _, h := range handlers {
+		if err := h(e); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Dispatch routes each of the provided events to its handlers, such as a batch received by
+// a webhook or a page of search results. Its signature matches webhook.Handler.Handle.
+// Handler errors don't stop the dispatch; they're returned together as a *DispatchError.
+func (r *Router) Dispatch(ctx context.Context, evs Events) error {
+	return r.DispatchSeq(ctx, func(yield func(Event, error) bool) {
+		for _, e := range evs {
+			if !yield(e, nil) {
+				return
+			}
+		}
+	})
+}
+
+// DispatchSeq is the same as Dispatch, for events read from a sequence, such as the All method
+// of a message events Iterator. Dispatch stops at the first error from the sequence itself,
+// or when ctx is done, and that error is returned along with any handler errors.
+func (r *Router) DispatchSeq(ctx context.Context, seq iter.Seq2[Event, error]) error {
+	h := r.route
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		h = r.middleware[i](h)
+	}
+
+	workers := r.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	derr := &DispatchError{}
+
+	var stop error
+	idx := 0
+	for e, err := range seq {
+		if err != nil {
+			stop = err
+			break
+		}
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if stop = ctx.Err(); stop != nil {
+			break
+		}
+
+		wg.Add(1)
+		go func(idx int, e Event) {
+			defer func() { <-sem; wg.Done() }()
+			if err := h(e); err != nil {
+				mu.Lock()
+				derr.Errors = append(derr.Errors, &HandlerError{Index: idx, Event: e, Err: err})
+				mu.Unlock()
+			}
+		}(idx, e)
+		idx++
+	}
+	wg.Wait()
+
+	derr.Count = idx
+	if len(derr.Errors) == 0 {
+		return stop
+	}
+	sort.Slice(derr.Errors, func(i, j int) bool { return derr.Errors[i].Index < derr.Errors[j].Index })
+	if stop != nil {
+		return errors.Join(stop, derr)
+	}
+	return derr
+}
+
+// HandlerError is an error returned while handling one event.
+type HandlerError struct {
+	Index int
+	Event Event
+	Err   error
+}
+
+func (e *HandlerError) Error() string {
+	return fmt.Sprintf("event %d (%s): %v", e.Index, eventTypeName(e.Event), e.Err)
+}
+
+func (e *HandlerError) Unwrap() error { return e.Err }
+
+// DispatchError collects the errors returned by handlers during a dispatch, in event order.
+// Count is the number of events dispatched.
+type DispatchError struct {
+	Count  int
+	Errors []*HandlerError
+}
+
+func (e *DispatchError) Error() string {
+	if len(e.Errors) == 1 {
+		return fmt.Sprintf("1 of %d events failed: %v", e.Count, e.Errors[0])
+	}
+	return fmt.Sprintf("%d of %d events failed, first: %v", len(e.Errors), e.Count, e.Errors[0])
+}
+
+func (e *DispatchError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// ByType groups the errors by event type.
+func (e *DispatchError) ByType() map[string][]*HandlerError {
+	byType := map[string][]*HandlerError{}
+	for _, err := range e.Errors {
+		t := eventTypeName(err.Event)
+		byType[t] = append(byType[t], err)
+	}
+	return byType
+}
+
+// eventTypeName returns the type of e as it appears in the JSON, even for Unknown events.
+func eventTypeName(e Event) string {
+	if u, ok := e.(*Unknown); ok {
+		return u.EventCommon.EventType()
+	}
+	return e.EventType()
+}
+
+// Filter returns Middleware that skips events for which keep returns false.
+func Filter(keep func(Event) bool) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(e Event) error {
+			if !keep(e) {
+				return nil
+			}
+			return next(e)
+		}
+	}
+}
+
+// CampaignFilter returns Middleware that skips events that don't belong to one of the provided campaigns.
+func CampaignFilter(ids ...string) Middleware {
+	return fieldFilter("campaign_id", ids)
+}
+
+// SubaccountFilter returns Middleware that skips events that don't belong to one of the provided
+// subaccounts. Events without a subaccount belong to the primary account, which is subaccount 0.
+func SubaccountFilter(ids ...int) Middleware {
+	keep := make([]string, len(ids))
+	for i, id := range ids {
+		keep[i] = strconv.Itoa(id)
+	}
+	return fieldFilter("subaccount_id", keep)
+}
+
+func fieldFilter(name string, values []string) Middleware {
+	return Filter(func(e Event) bool {
+		v, _ := Field(e, name)
+		if v == "" && name == "subaccount_id" {
+			v = "0"
+		}
+		for _, want := range values {
+			if v == want {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// Field returns the value of the field with the provided JSON name, formatted as a string,
+// and whether the event has that field. Unknown events are looked up in their raw JSON.
+func Field(e Event, name string) (string, bool) {
+	if u, ok := e.(*Unknown); ok {
+		var fields map[string]interface{}
+		if err := json.Unmarshal(u.RawJSON, &fields); err != nil {
+			return "", false
+		}
+		v, ok := fields[name]
+		if !ok || v == nil {
+			return "", ok
+		}
+		return fmt.Sprint(v), true
+	}
+
+	v := reflect.ValueOf(e)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "", false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return "", false
+	}
+	return structField(v, name)
+}
+
+func structField(v reflect.Value, name string) (string, bool) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			if s, ok := structField(v.Field(i), name); ok {
+				return s, true
+			}
+			continue
+		}
+		if tag, _, _ := strings.Cut(f.Tag.Get("json"), ","); tag == name {
+			if s, ok := v.Field(i).Interface().(fmt.Stringer); ok {
+				return s.String(), true
+			}
+			return fmt.Sprint(v.Field(i).Interface()), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,176 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func loadSampleEvents(t *testing.T) Events {
+	payload, err := ioutil.ReadFile("test/json/sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var evs Events
+	if err = json.Unmarshal(payload, &evs); err != nil {
+		t.Fatal(err)
+	}
+	return evs
+}
+
+func TestRouter(t *testing.T) {
+	evs := loadSampleEvents(t)
+	evs = append(evs, &Unknown{EventCommon: EventCommon{Type: "future_event"}, RawJSON: json.RawMessage(`{"type":"future_event"}`)})
+
+	r := NewRouter(1)
+	var order []string
+	r.OnBounce(func(b *Bounce) error {
+		order = append(order, "bounce:"+b.BounceClass)
+		return nil
+	})
+	r.OnBounce(func(b *Bounce) error {
+		order = append(order, "bounce again")
+		return nil
+	})
+	r.OnClick(func(c *Click) error {
+		order = append(order, "click")
+		return nil
+	})
+	r.OnUnknown(func(u *Unknown) error {
+		order = append(order, "unknown:"+u.EventCommon.EventType())
+		return nil
+	})
+	fallback := 0
+	r.Fallback(func(e Event) error {
+		fallback++
+		return nil
+	})
+
+	if err := r.Dispatch(context.Background(), evs); err != nil {
+		t.Fatalf("Router.Dispatch => %v", err)
+	}
+	want := []string{"bounce:1", "bounce again", "click", "unknown:future_event"}
+	if len(order) != len(want) {
+		t.Fatalf("Router.Dispatch => %q want %q", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Errorf("Router.Dispatch[%d] => %q want %q", i, order[i], want[i])
+		}
+	}
+	if fallback != len(evs)-3 {
+		t.Errorf("Router.Fallback => %d events want %d", fallback, len(evs)-3)
+	}
+}
+
+func TestRouterFilters(t *testing.T) {
+	var evs Events
+	if err := json.Unmarshal([]byte(`{"results":[
+		{"type":"bounce","campaign_id":"a"},
+		{"type":"delivery","campaign_id":"b"},
+		{"type":"open","campaign_id":"a"},
+		{"type":"future_open","campaign_id":"a","subaccount_id":101},
+		{"type":"future_click","campaign_id":"b","subaccount_id":102}]}`), &evs); err != nil {
+		t.Fatal(err)
+	}
+
+	for idx, test := range []struct {
+		mw   []Middleware
+		want int
+	}{
+		{nil, 5},
+		{[]Middleware{CampaignFilter("a")}, 3},
+		{[]Middleware{CampaignFilter("a", "b")}, 5},
+		{[]Middleware{SubaccountFilter(101)}, 1},
+		{[]Middleware{SubaccountFilter(0)}, 3},
+		{[]Middleware{CampaignFilter("b"), SubaccountFilter(0, 102)}, 2},
+		{[]Middleware{Filter(func(e Event) bool { return e.EventType() != "unknown" })}, 3},
+	} {
+		r := NewRouter(1)
+		r.Use(test.mw...)
+		n := 0
+		r.Fallback(func(e Event) error {
+			n++
+			return nil
+		})
+		if err := r.Dispatch(context.Background(), evs); err != nil {
+			t.Errorf("RouterFilters[%d] => %v", idx, err)
+		} else if n != test.want {
+			t.Errorf("RouterFilters[%d] => %d events want %d", idx, n, test.want)
+		}
+	}
+}
+
+func TestRouterErrors(t *testing.T) {
+	evs := loadSampleEvents(t)
+	boom := errors.New("boom")
+
+	r := NewRouter(4)
+	var running, peak int32
+	var mu sync.Mutex
+	r.Fallback(func(e Event) error {
+		n := atomic.AddInt32(&running, 1)
+		mu.Lock()
+		if n > peak {
+			peak = n
+		}
+		mu.Unlock()
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+	r.OnDelay(func(d *Delay) error { return boom })
+	r.OnClick(func(c *Click) error { return boom })
+	r.OnClick(func(c *Click) error { return errors.New("bang") })
+
+	err := r.Dispatch(context.Background(), evs)
+	var derr *DispatchError
+	if !errors.As(err, &derr) {
+		t.Fatalf("Router.Dispatch => %v", err)
+	}
+	if derr.Count != len(evs) || len(derr.Errors) != 2 {
+		t.Fatalf("Router.Dispatch => %+v", derr)
+	}
+	if derr.Error() != "2 of 19 events failed, first: event 7 (delay): boom" {
+		t.Errorf("Router.Dispatch => %q", derr.Error())
+	} else if derr.Errors[1].Error() != "event 8 (click): boom\nbang" {
+		t.Errorf("Router.Dispatch => %q", derr.Errors[1].Error())
+	}
+	if !errors.Is(err, boom) {
+		t.Errorf("Router.Dispatch => errors.Is(boom) false")
+	}
+	if byType := derr.ByType(); len(byType["click"]) != 1 || len(byType["delay"]) != 1 {
+		t.Errorf("DispatchError.ByType => %v", byType)
+	}
+	if peak < 2 || peak > 4 {
+		t.Errorf("Router.Workers => peak concurrency %d", peak)
+	}
+
+	// Errors from the sequence stop the dispatch
+	source := errors.New("page 2 failed")
+	n := 0
+	r = NewRouter(0)
+	r.Fallback(func(e Event) error {
+		n++
+		return nil
+	})
+	err = r.DispatchSeq(context.Background(), func(yield func(Event, error) bool) {
+		if yield(evs[0], nil) && yield(nil, source) {
+			yield(evs[1], nil)
+		}
+	})
+	if err != source || n != 1 {
+		t.Errorf("Router.DispatchSeq => err %v, %d events", err, n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err = r.Dispatch(ctx, evs); err != context.Canceled {
+		t.Errorf("Router.Dispatch => err %v want %v", err, context.Canceled)
+	}
+}
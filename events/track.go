@@ -4,23 +4,38 @@ import "fmt"
 
 type Click struct {
 	EventCommon
-	CampaignID      string      `json:"campaign_id"`
-	CustomerID      string      `json:"customer_id"`
-	DeliveryMethod  string      `json:"delv_method"`
-	GeoIP           *GeoIP      `json:"geo_ip"`
-	IPAddress       string      `json:"ip_address"`
-	MessageID       string      `json:"message_id"`
-	Metadata        interface{} `json:"rcpt_meta"`
-	Tags            []string    `json:"rcpt_tags"`
-	Recipient       string      `json:"rcpt_to"`
-	RecipientType   string      `json:"rcpt_type"`
-	TargetLinkName  string      `json:"target_link_name"`
-	TargetLinkURL   string      `json:"target_link_url"`
-	TemplateID      string      `json:"template_id"`
-	TemplateVersion string      `json:"template_version"`
-	Timestamp       Timestamp   `json:"timestamp"`
-	TransmissionID  string      `json:"transmission_id"`
-	UserAgent       string      `json:"user_agent"`
+	ABTestID              string      `json:"ab_test_id"`
+	ABTestVersion         Number      `json:"ab_test_version"`
+	AMPEnabled            bool        `json:"amp_enabled"`
+	AcceptLanguage        string      `json:"accept_language"`
+	CampaignID            string      `json:"campaign_id"`
+	CustomerID            string      `json:"customer_id"`
+	DeliveryMethod        string      `json:"delv_method"`
+	FriendlyFrom          string      `json:"friendly_from"`
+	GeoIP                 *GeoIP      `json:"geo_ip"`
+	InjectionTime         Timestamp   `json:"injection_time"`
+	IPAddress             string      `json:"ip_address"`
+	IPPool                string      `json:"ip_pool"`
+	MailboxProvider       string      `json:"mailbox_provider"`
+	MailboxProviderRegion string      `json:"mailbox_provider_region"`
+	MessageID             string      `json:"message_id"`
+	RawRecipient          string      `json:"raw_rcpt_to"`
+	Metadata              interface{} `json:"rcpt_meta"`
+	Tags                  []string    `json:"rcpt_tags"`
+	Recipient             string      `json:"rcpt_to"`
+	RecipientType         string      `json:"rcpt_type"`
+	RecipientDomain       string      `json:"recipient_domain"`
+	RoutingDomain         string      `json:"routing_domain"`
+	SendingIP             string      `json:"sending_ip"`
+	SubaccountID          Number      `json:"subaccount_id"`
+	Subject               string      `json:"subject"`
+	TargetLinkName        string      `json:"target_link_name"`
+	TargetLinkURL         string      `json:"target_link_url"`
+	TemplateID            string      `json:"template_id"`
+	TemplateVersion       string      `json:"template_version"`
+	Timestamp             Timestamp   `json:"timestamp"`
+	TransmissionID        string      `json:"transmission_id"`
+	UserAgent             string      `json:"user_agent"`
 }
 
 // String returns a brief summary of a Click event
@@ -31,21 +46,36 @@ func (c *Click) String() string {
 
 type Open struct {
 	EventCommon
-	CampaignID      string      `json:"campaign_id"`
-	CustomerID      string      `json:"customer_id"`
-	DeliveryMethod  string      `json:"delv_method"`
-	GeoIP           *GeoIP      `json:"geo_ip"`
-	IPAddress       string      `json:"ip_address"`
-	MessageID       string      `json:"message_id"`
-	Metadata        interface{} `json:"rcpt_meta"`
-	Tags            []string    `json:"rcpt_tags"`
-	Recipient       string      `json:"rcpt_to"`
-	RecipientType   string      `json:"rcpt_type"`
-	TemplateID      string      `json:"template_id"`
-	TemplateVersion string      `json:"template_version"`
-	Timestamp       Timestamp   `json:"timestamp"`
-	TransmissionID  string      `json:"transmission_id"`
-	UserAgent       string      `json:"user_agent"`
+	ABTestID              string      `json:"ab_test_id"`
+	ABTestVersion         Number      `json:"ab_test_version"`
+	AMPEnabled            bool        `json:"amp_enabled"`
+	AcceptLanguage        string      `json:"accept_language"`
+	CampaignID            string      `json:"campaign_id"`
+	CustomerID            string      `json:"customer_id"`
+	DeliveryMethod        string      `json:"delv_method"`
+	FriendlyFrom          string      `json:"friendly_from"`
+	GeoIP                 *GeoIP      `json:"geo_ip"`
+	InjectionTime         Timestamp   `json:"injection_time"`
+	IPAddress             string      `json:"ip_address"`
+	IPPool                string      `json:"ip_pool"`
+	MailboxProvider       string      `json:"mailbox_provider"`
+	MailboxProviderRegion string      `json:"mailbox_provider_region"`
+	MessageID             string      `json:"message_id"`
+	RawRecipient          string      `json:"raw_rcpt_to"`
+	Metadata              interface{} `json:"rcpt_meta"`
+	Tags                  []string    `json:"rcpt_tags"`
+	Recipient             string      `json:"rcpt_to"`
+	RecipientType         string      `json:"rcpt_type"`
+	RecipientDomain       string      `json:"recipient_domain"`
+	RoutingDomain         string      `json:"routing_domain"`
+	SendingIP             string      `json:"sending_ip"`
+	SubaccountID          Number      `json:"subaccount_id"`
+	Subject               string      `json:"subject"`
+	TemplateID            string      `json:"template_id"`
+	TemplateVersion       string      `json:"template_version"`
+	Timestamp             Timestamp   `json:"timestamp"`
+	TransmissionID        string      `json:"transmission_id"`
+	UserAgent             string      `json:"user_agent"`
 }
 
 // String returns a brief summary of an Open event
@@ -53,3 +83,39 @@ func (o *Open) String() string {
 	return fmt.Sprintf("%s O %s %s",
 		o.Timestamp, o.TransmissionID, o.Recipient)
 }
+
+// InitialOpen is recorded when the tracking pixel at the top of a message is loaded.
+type InitialOpen Open
+
+// String returns a brief summary of an InitialOpen event
+func (o *InitialOpen) String() string {
+	return fmt.Sprintf("%s IO %s %s",
+		o.Timestamp, o.TransmissionID, o.Recipient)
+}
+
+// AMPClick is a Click in the AMP part of a message.
+type AMPClick Click
+
+// String returns a brief summary of an AMPClick event
+func (c *AMPClick) String() string {
+	return fmt.Sprintf("%s AC %s %s => %s",
+		c.Timestamp, c.TransmissionID, c.Recipient, c.TargetLinkURL)
+}
+
+// AMPOpen is an Open of the AMP part of a message.
+type AMPOpen Open
+
+// String returns a brief summary of an AMPOpen event
+func (o *AMPOpen) String() string {
+	return fmt.Sprintf("%s AO %s %s",
+		o.Timestamp, o.TransmissionID, o.Recipient)
+}
+
+// AMPInitialOpen is an InitialOpen of the AMP part of a message.
+type AMPInitialOpen Open
+
+// String returns a brief summary of an AMPInitialOpen event
+func (o *AMPInitialOpen) String() string {
+	return fmt.Sprintf("%s AIO %s %s",
+		o.Timestamp, o.TransmissionID, o.Recipient)
+}
@@ -4,18 +4,30 @@ import "fmt"
 
 type ListUnsubscribe struct {
 	EventCommon
-	CampaignID      string      `json:"campaign_id"`
-	CustomerID      string      `json:"customer_id"`
-	MessageFrom     string      `json:"mailfrom"`
-	MessageID       string      `json:"message_id"`
-	Metadata        interface{} `json:"rcpt_meta"`
-	Tags            []string    `json:"rcpt_tags"`
-	Recipient       string      `json:"rcpt_to"`
-	RecipientType   string      `json:"rcpt_type"`
-	TemplateID      string      `json:"template_id"`
-	TemplateVersion string      `json:"template_version"`
-	Timestamp       Timestamp   `json:"timestamp"`
-	TransmissionID  string      `json:"transmission_id"`
+	ABTestID              string      `json:"ab_test_id"`
+	ABTestVersion         Number      `json:"ab_test_version"`
+	AMPEnabled            bool        `json:"amp_enabled"`
+	CampaignID            string      `json:"campaign_id"`
+	CustomerID            string      `json:"customer_id"`
+	FriendlyFrom          string      `json:"friendly_from"`
+	InjectionTime         Timestamp   `json:"injection_time"`
+	IPPool                string      `json:"ip_pool"`
+	MailboxProvider       string      `json:"mailbox_provider"`
+	MailboxProviderRegion string      `json:"mailbox_provider_region"`
+	MessageFrom           string      `json:"mailfrom"`
+	MessageID             string      `json:"message_id"`
+	RawRecipient          string      `json:"raw_rcpt_to"`
+	Metadata              interface{} `json:"rcpt_meta"`
+	Tags                  []string    `json:"rcpt_tags"`
+	Recipient             string      `json:"rcpt_to"`
+	RecipientType         string      `json:"rcpt_type"`
+	RecipientDomain       string      `json:"recipient_domain"`
+	SendingIP             string      `json:"sending_ip"`
+	SubaccountID          Number      `json:"subaccount_id"`
+	TemplateID            string      `json:"template_id"`
+	TemplateVersion       string      `json:"template_version"`
+	Timestamp             Timestamp   `json:"timestamp"`
+	TransmissionID        string      `json:"transmission_id"`
 }
 
 // String returns a brief summary of a ListUnsubscribe event
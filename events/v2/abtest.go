@@ -0,0 +1,58 @@
+package events
+
+import "fmt"
+
+type ABTestCompleted struct {
+	EventCommon
+	ABTest       ABTest    `json:"ab_test"`
+	CustomerID   string    `json:"customer_id"`
+	SubaccountID Int       `json:"subaccount_id"`
+	Timestamp    Timestamp `json:"timestamp"`
+}
+
+// String returns a brief summary of an ABTestCompleted event
+func (a *ABTestCompleted) String() string {
+	return fmt.Sprintf("%s ABC %s v%d => %s",
+		a.Timestamp, a.ABTest.ID, a.ABTest.Version, a.ABTest.WinningTemplateID)
+}
+
+func (a *ABTestCompleted) MarshalJSON() ([]byte, error) {
+	type fields ABTestCompleted
+	return marshalEvent(a.received, (*fields)(a))
+}
+
+type ABTestCancelled ABTestCompleted
+
+// String returns a brief summary of an ABTestCancelled event
+func (a *ABTestCancelled) String() string {
+	return fmt.Sprintf("%s ABX %s v%d",
+		a.Timestamp, a.ABTest.ID, a.ABTest.Version)
+}
+
+func (a *ABTestCancelled) MarshalJSON() ([]byte, error) {
+	type fields ABTestCancelled
+	return marshalEvent(a.received, (*fields)(a))
+}
+
+// ABTest describes the A/B test that an ABTestCompleted or ABTestCancelled event refers to.
+type ABTest struct {
+	ID                string           `json:"id"`
+	Name              string           `json:"name"`
+	Version           int              `json:"version"`
+	TestMode          string           `json:"test_mode"`
+	EngagementMetric  string           `json:"engagement_metric"`
+	DefaultTemplate   *ABTestTemplate  `json:"default_template"`
+	Variants          []ABTestTemplate `json:"variants"`
+	WinningTemplateID string           `json:"winning_template_id"`
+}
+
+// ABTestTemplate contains the results for one of the templates in an ABTest.
+type ABTestTemplate struct {
+	TemplateID                 string  `json:"template_id"`
+	Percent                    int     `json:"percent"`
+	SampleSize                 int     `json:"sample_size"`
+	CountUniqueConfirmedOpened int     `json:"count_unique_confirmed_opened"`
+	CountUniqueClicked         int     `json:"count_unique_clicked"`
+	CountAccepted              int     `json:"count_accepted"`
+	EngagementRate             float64 `json:"engagement_rate"`
+}
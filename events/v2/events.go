@@ -0,0 +1,660 @@
+// Package events defines a struct for each type of event, like the original events package,
+// with fields decoded into the types they represent: numbers into ints, metadata into maps,
+// and times into Timestamps. Decoded events re-marshal in SparkPost's wire format, with the fields
+// and values they were sent with, so they can be stored, replayed or forwarded without losing anything.
+// Values that are set in code are marshaled the way SparkPost sends webhooks.
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Event is a generic event.
+type Event interface {
+	EventType() string
+}
+
+// Events is a list of generic events. Useful for decoding events from API webhooks.
+// Events marshal as a webhook batch, with each event wrapped in its group.
+type Events []Event
+
+var (
+	ErrNotImplemented = errors.New("not implemented")
+)
+
+// ValidEventType returns true if the event name parameter is valid.
+func ValidEventType(eventType string) bool {
+	if _, ok := EventForName(eventType).(*Unknown); ok {
+		return false
+	}
+	return true
+}
+
+// EventForName returns a struct matching the passed-in type.
+func EventForName(eventType string) Event {
+	switch eventType {
+	case "bounce":
+		return &Bounce{}
+	case "click":
+		return &Click{}
+	case "creation":
+		return &Creation{}
+	case "delay":
+		return &Delay{}
+	case "delivery":
+		return &Delivery{}
+	case "generation_failure":
+		return &GenerationFailure{}
+	case "generation_rejection":
+		return &GenerationRejection{}
+	case "injection":
+		return &Injection{}
+	case "list_unsubscribe":
+		return &ListUnsubscribe{}
+	case "link_unsubscribe":
+		return &LinkUnsubscribe{}
+	case "open":
+		return &Open{}
+	case "out_of_band":
+		return &OutOfBand{}
+	case "policy_rejection":
+		return &PolicyRejection{}
+	case "spam_complaint":
+		return &SpamComplaint{}
+	case "relay_delivery":
+		return &RelayDelivery{}
+	case "relay_injection":
+		return &RelayInjection{}
+	case "relay_message":
+		return &RelayMessage{}
+	case "relay_permfail":
+		return &RelayPermfail{}
+	case "relay_rejection":
+		return &RelayRejection{}
+	case "relay_tempfail":
+		return &RelayTempfail{}
+	case "sms_status":
+		return &SMSStatus{}
+	case "amp_click":
+		return &AMPClick{}
+	case "amp_open":
+		return &AMPOpen{}
+	case "amp_initial_open":
+		return &AMPInitialOpen{}
+	case "initial_open":
+		return &InitialOpen{}
+	case "ab_test_completed":
+		return &ABTestCompleted{}
+	case "ab_test_cancelled":
+		return &ABTestCancelled{}
+	case "ingest_success":
+		return &IngestSuccess{}
+	case "ingest_error":
+		return &IngestError{}
+	}
+	return &Unknown{}
+}
+
+// Group returns the name that webhooks use to wrap events of the passed-in type,
+// or an empty string for unknown types.
+func Group(eventType string) string {
+	switch eventType {
+	case "bounce", "creation", "delay", "delivery", "injection", "out_of_band",
+		"policy_rejection", "sms_status", "spam_complaint":
+		return "message_event"
+	case "click", "open", "initial_open", "amp_click", "amp_open", "amp_initial_open":
+		return "track_event"
+	case "generation_failure", "generation_rejection":
+		return "gen_event"
+	case "list_unsubscribe", "link_unsubscribe":
+		return "unsubscribe_event"
+	case "relay_delivery", "relay_injection", "relay_permfail", "relay_rejection", "relay_tempfail":
+		return "relay_event"
+	case "relay_message":
+		return "relay_message"
+	case "ab_test_completed", "ab_test_cancelled":
+		return "ab_test_event"
+	case "ingest_success", "ingest_error":
+		return "ingest_event"
+	}
+	return ""
+}
+
+// ParseRawJSONEvents decodes each raw event into the struct for its type.
+// Events of unknown types, and events that can't be decoded, are returned as *Unknown.
+// The group is used for relay messages, which don't include their type, and is kept with Unknown events.
+func ParseRawJSONEvents(group string, rawEvents []json.RawMessage) ([]Event, error) {
+	events := []Event{}
+
+	for _, rawEvent := range rawEvents {
+		var typeLookup EventCommon
+		if err := json.Unmarshal(rawEvent, &typeLookup); err != nil {
+			typeLookup.Type = "unknown"
+		}
+		if typeLookup.Type == "" && group == "relay_message" {
+			typeLookup.Type = "relay_message"
+		}
+
+		event := EventForName(typeLookup.EventType())
+		if e, ok := event.(*Unknown); ok {
+			e.EventCommon = typeLookup
+			e.Group = group
+			e.RawJSON = rawEvent
+			e.Error = ErrNotImplemented
+			events = append(events, e)
+			continue
+		}
+
+		if err := json.Unmarshal(rawEvent, event); err != nil {
+			event = &Unknown{
+				EventCommon: typeLookup,
+				Group:       group,
+				RawJSON:     rawEvent,
+				Error:       err,
+			}
+		} else {
+			if c, ok := event.(interface{ common() *EventCommon }); ok {
+				c.common().received = receivedFields(rawEvent)
+			}
+			if m, ok := event.(*RelayMessage); ok {
+				m.Type = typeLookup.Type
+			}
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// UnmarshalJSON decodes a batch posted by a webhook ("msys"-wrapped array of events),
+// or the results from the Event Samples API ("results" object with array of events).
+func (events *Events) UnmarshalJSON(data []byte) error {
+	var batch []struct {
+		Msys map[string]json.RawMessage `json:"msys"`
+	}
+	if err := json.Unmarshal(data, &batch); err == nil {
+		parsed := Events{}
+		for _, wrapper := range batch {
+			for group, rawEvent := range wrapper.Msys {
+				evs, err := ParseRawJSONEvents(group, []json.RawMessage{rawEvent})
+				if err != nil {
+					return err
+				}
+				parsed = append(parsed, evs...)
+			}
+		}
+		*events = parsed
+		return nil
+	}
+
+	var samples struct {
+		RawEvents []json.RawMessage `json:"results"`
+	}
+	if err := json.Unmarshal(data, &samples); err != nil {
+		return err
+	}
+	parsed, err := ParseRawJSONEvents("", samples.RawEvents)
+	if err != nil {
+		return err
+	}
+	*events = parsed
+	return nil
+}
+
+// MarshalJSON encodes events as a webhook batch.
+func (events Events) MarshalJSON() ([]byte, error) {
+	batch := make([]map[string]map[string]Event, len(events))
+	for i, event := range events {
+		group := Group(event.EventType())
+		if u, ok := event.(*Unknown); ok {
+			group = u.Group
+		}
+		batch[i] = map[string]map[string]Event{"msys": {group: event}}
+	}
+	return json.Marshal(batch)
+}
+
+// ECLog emits an event in the same format that it would be logged by Momentum,
+// or an empty string for events that don't have an equivalent log line.
+func ECLog(e Event) string {
+	if l, ok := e.(ECLogger); ok {
+		return l.ECLog()
+	}
+	return ""
+}
+
+type ECLogger interface {
+	ECLog() string
+}
+
+// EventCommon contains fields common to all types of Event objects.
+// EventID is unique per event, and isn't sent for relay_message events.
+type EventCommon struct {
+	Type    string `json:"type"`
+	EventID string `json:"event_id,omitempty"`
+
+	// received holds the fields a decoded event was sent with, so it re-marshals with the same fields.
+	received map[string]json.RawMessage
+}
+
+func (e EventCommon) EventType() string { return e.Type }
+
+func (e *EventCommon) common() *EventCommon { return e }
+
+// receivedFields returns the fields of a raw event, in the form json.Marshal writes them,
+// so that events decoded from equivalent JSON are equal.
+func receivedFields(raw json.RawMessage) map[string]json.RawMessage {
+	fields := map[string]json.RawMessage{}
+	if json.Unmarshal(raw, &fields) != nil {
+		return nil
+	}
+	for k, v := range fields {
+		var value any
+		dec := json.NewDecoder(bytes.NewReader(v))
+		dec.UseNumber()
+		if dec.Decode(&value) != nil {
+			continue
+		}
+		if canonical, err := json.Marshal(value); err == nil {
+			fields[k] = canonical
+		}
+	}
+	return fields
+}
+
+// marshalEvent marshals the fields of an event. Events that were decoded are marshaled with the fields
+// they were sent with: fields that weren't sent are left out unless they've been set since,
+// along with implied fields, and fields the struct doesn't have are passed through.
+func marshalEvent(received map[string]json.RawMessage, v any, implied ...string) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || received == nil {
+		return data, err
+	}
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for _, k := range implied {
+		if _, ok := received[k]; !ok {
+			delete(fields, k)
+		}
+	}
+	if err = keepReceived(fields, received, reflect.TypeOf(v).Elem()); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+var marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+
+// keepReceived removes the fields of struct type t that weren't received and are unset,
+// including in nested structs, and adds received fields that t doesn't have.
+func keepReceived(fields, received map[string]json.RawMessage, t reflect.Type) error {
+	var zero map[string]json.RawMessage
+	data, err := json.Marshal(reflect.New(t).Interface())
+	if err != nil {
+		return err
+	} else if err = json.Unmarshal(data, &zero); err != nil {
+		return err
+	}
+
+	for k, value := range fields {
+		raw, ok := received[k]
+		if !ok {
+			if bytes.Equal(value, zero[k]) {
+				delete(fields, k)
+			}
+			continue
+		}
+		if fields[k], err = keepReceivedValue(value, raw, fieldType(t, k)); err != nil {
+			return err
+		}
+	}
+	for k, raw := range received {
+		if _, ok := fields[k]; !ok {
+			fields[k] = raw
+		}
+	}
+	return nil
+}
+
+// keepReceivedValue applies keepReceived to a value of type t, if it's a struct or a list of structs.
+func keepReceivedValue(value, raw json.RawMessage, t reflect.Type) (json.RawMessage, error) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || reflect.PointerTo(t).Implements(marshalerType) {
+		return value, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		var fields, received map[string]json.RawMessage
+		if json.Unmarshal(value, &fields) != nil || json.Unmarshal(raw, &received) != nil || fields == nil || received == nil {
+			return value, nil
+		}
+		if err := keepReceived(fields, received, t); err != nil {
+			return nil, err
+		}
+		return json.Marshal(fields)
+	case reflect.Slice, reflect.Array:
+		var values, raws []json.RawMessage
+		if json.Unmarshal(value, &values) != nil || json.Unmarshal(raw, &raws) != nil || len(values) != len(raws) {
+			return value, nil
+		}
+		for i := range values {
+			var err error
+			if values[i], err = keepReceivedValue(values[i], raws[i], t.Elem()); err != nil {
+				return nil, err
+			}
+		}
+		return json.Marshal(values)
+	}
+	return value, nil
+}
+
+// fieldType returns the type of the field of struct type t that's marshaled as name, or nil.
+func fieldType(t reflect.Type, name string) reflect.Type {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if f.Anonymous && tag == "" {
+			embedded := f.Type
+			if embedded.Kind() == reflect.Ptr {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				if found := fieldType(embedded, name); found != nil {
+					return found
+				}
+			}
+			continue
+		}
+		if !f.IsExported() || tag == "-" {
+			continue
+		}
+		if tag == name || tag == "" && f.Name == name {
+			return f.Type
+		}
+	}
+	return nil
+}
+
+// Unknown holds an event of a type this package doesn't have a struct for, or that couldn't be decoded.
+// It re-marshals as the original JSON.
+type Unknown struct {
+	EventCommon
+	Group   string
+	RawJSON json.RawMessage
+	Error   error
+}
+
+func (e *Unknown) EventType() string { return "unknown" }
+
+func (e *Unknown) String() string {
+	return fmt.Sprintf("Unknown event (type %q): %v\n%s", e.EventCommon.EventType(), e.Error, e.RawJSON)
+}
+
+func (e *Unknown) MarshalJSON() ([]byte, error) {
+	return e.RawJSON, nil
+}
+
+// Timestamp is a time that SparkPost sends either as Unix seconds, in a number or a string,
+// or in an RFC 3339 format. It re-marshals exactly as it was received, unless the time is changed;
+// other times are marshaled as Unix seconds in a string, and the zero time as null.
+type Timestamp struct {
+	time.Time
+	raw []byte
+}
+
+// NewTimestamp returns a Timestamp for the passed-in time.
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t}
+}
+
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.raw != nil {
+		if parsed, err := parseTimestamp(t.raw); err == nil && parsed.Equal(t.Time) {
+			return t.raw, nil
+		}
+	}
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return []byte(strconv.Quote(strconv.FormatInt(t.Unix(), 10))), nil
+}
+
+func (t *Timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = Timestamp{}
+		return nil
+	}
+	parsed, err := parseTimestamp(data)
+	if err != nil {
+		return err
+	}
+	*t = Timestamp{Time: parsed, raw: append([]byte(nil), data...)}
+	return nil
+}
+
+func parseTimestamp(data []byte) (time.Time, error) {
+	s := string(bytes.Trim(data, `"`))
+
+	// Webhook events use Unix seconds, sometimes with a fractional part.
+	if secs, err := strconv.ParseFloat(s, 64); err == nil {
+		whole := int64(secs)
+		return time.Unix(whole, int64((secs-float64(whole))*1e9)).Round(time.Millisecond), nil
+	}
+
+	// Event samples and the message events API use RFC 3339, with or without a Z suffix.
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+// Int is a whole number that SparkPost sends in a string, or as a number in the Events API.
+// Like Timestamp, it re-marshals exactly as it was received, unless Value is changed;
+// other values are marshaled as a string.
+type Int struct {
+	Value int
+	raw   []byte
+}
+
+// NewInt returns an Int for the passed-in value.
+func NewInt(n int) Int {
+	return Int{Value: n}
+}
+
+func (n Int) String() string {
+	return strconv.Itoa(n.Value)
+}
+
+func (n Int) MarshalJSON() ([]byte, error) {
+	if n.raw != nil {
+		if v, err := parseInt(n.raw); err == nil && v == n.Value {
+			return n.raw, nil
+		}
+	}
+	return []byte(strconv.Quote(n.String())), nil
+}
+
+func (n *Int) UnmarshalJSON(data []byte) error {
+	v, err := parseInt(data)
+	if err != nil {
+		return err
+	}
+	*n = Int{Value: v, raw: append([]byte(nil), data...)}
+	return nil
+}
+
+func parseInt(data []byte) (int, error) {
+	s := string(bytes.Trim(data, `"`))
+	if s == "" || s == "null" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
+
+// Float is a number with a fractional part that SparkPost sends in a string, or as a number.
+// It re-marshals exactly as it was received, unless Value is changed; other values are marshaled as a string.
+type Float struct {
+	Value float64
+	raw   []byte
+}
+
+// NewFloat returns a Float for the passed-in value.
+func NewFloat(f float64) Float {
+	return Float{Value: f}
+}
+
+func (f Float) String() string {
+	return strconv.FormatFloat(f.Value, 'f', -1, 64)
+}
+
+func (f Float) MarshalJSON() ([]byte, error) {
+	if f.raw != nil {
+		if v, err := parseFloat(f.raw); err == nil && v == f.Value {
+			return f.raw, nil
+		}
+	}
+	return []byte(strconv.Quote(f.String())), nil
+}
+
+func (f *Float) UnmarshalJSON(data []byte) error {
+	v, err := parseFloat(data)
+	if err != nil {
+		return err
+	}
+	*f = Float{Value: v, raw: append([]byte(nil), data...)}
+	return nil
+}
+
+func parseFloat(data []byte) (float64, error) {
+	s := string(bytes.Trim(data, `"`))
+	if s == "" || s == "null" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(s, 64)
+}
+
+// Bool is a flag that SparkPost sends as "1" or "0", or as a JSON boolean.
+// It re-marshals exactly as it was received, unless Value is changed; other values are marshaled as "1" or "0".
+type Bool struct {
+	Value bool
+	raw   []byte
+}
+
+// NewBool returns a Bool for the passed-in value.
+func NewBool(b bool) Bool {
+	return Bool{Value: b}
+}
+
+func (b Bool) String() string {
+	if b.Value {
+		return "1"
+	}
+	return "0"
+}
+
+func (b Bool) MarshalJSON() ([]byte, error) {
+	if b.raw != nil {
+		if v, err := parseBool(b.raw); err == nil && v == b.Value {
+			return b.raw, nil
+		}
+	}
+	return []byte(strconv.Quote(b.String())), nil
+}
+
+func (b *Bool) UnmarshalJSON(data []byte) error {
+	v, err := parseBool(data)
+	if err != nil {
+		return err
+	}
+	*b = Bool{Value: v, raw: append([]byte(nil), data...)}
+	return nil
+}
+
+func parseBool(data []byte) (bool, error) {
+	switch string(bytes.Trim(data, `"`)) {
+	case "1", "true":
+		return true, nil
+	case "0", "false", "", "null":
+		return false, nil
+	}
+	return false, fmt.Errorf("invalid flag %s", data)
+}
+
+type GeoIP struct {
+	Country   string  `json:"country"`
+	Region    string  `json:"region"`
+	City      string  `json:"city"`
+	Latitude  LatLong `json:"latitude"`
+	Longitude LatLong `json:"longitude"`
+}
+
+// LatLong is a coordinate that SparkPost sends as a number, or as a string in older events.
+// It re-marshals exactly as it was received, unless Value is changed; other values are marshaled as a number.
+type LatLong struct {
+	Value float64
+	raw   []byte
+}
+
+// NewLatLong returns a LatLong for the passed-in value.
+func NewLatLong(v float64) LatLong {
+	return LatLong{Value: v}
+}
+
+func (v LatLong) String() string {
+	return strconv.FormatFloat(v.Value, 'f', -1, 64)
+}
+
+func (v LatLong) MarshalJSON() ([]byte, error) {
+	if v.raw != nil {
+		if value, err := strconv.ParseFloat(string(bytes.Trim(v.raw, `"`)), 64); err == nil && value == v.Value {
+			return v.raw, nil
+		}
+	}
+	return []byte(v.String()), nil
+}
+
+func (v *LatLong) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseFloat(string(bytes.Trim(data, `"`)), 64)
+	if err != nil {
+		return err
+	}
+	*v = LatLong{Value: value, raw: append([]byte(nil), data...)}
+	return nil
+}
+
+type Creation struct {
+	EventCommon
+	Accepted        Int            `json:"accepted_rcpts"`
+	CampaignID      string         `json:"campaign_id"`
+	CustomerID      string         `json:"customer_id"`
+	InjectionMethod string         `json:"inj_method"`
+	NodeName        string         `json:"node_name"`
+	Metadata        map[string]any `json:"rcpt_meta"`
+	Tags            []string       `json:"rcpt_tags"`
+	SubaccountID    Int            `json:"subaccount_id"`
+	Submitted       Int            `json:"submitted_rcpts"`
+	TemplateID      string         `json:"template_id"`
+	TemplateVersion Int            `json:"template_version"`
+	Timestamp       Timestamp      `json:"timestamp"`
+	TransmissionID  string         `json:"transmission_id"`
+	UserID          string         `json:"user_id"`
+}
+
+func (c *Creation) String() string {
+	return fmt.Sprintf("%s CT %s (%s, %s)",
+		c.Timestamp, c.TransmissionID, c.Submitted, c.Accepted)
+}
+
+func (c *Creation) MarshalJSON() ([]byte, error) {
+	type fields Creation
+	return marshalEvent(c.received, (*fields)(c))
+}
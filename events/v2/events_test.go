@@ -0,0 +1,355 @@
+package events
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// eventTypes lists every type that EventForName knows about.
+var eventTypes = []string{
+	"ab_test_cancelled", "ab_test_completed", "amp_click", "amp_initial_open", "amp_open",
+	"bounce", "click", "creation", "delay", "delivery", "generation_failure", "generation_rejection",
+	"ingest_error", "ingest_success", "initial_open", "injection", "link_unsubscribe",
+	"list_unsubscribe", "open", "out_of_band", "policy_rejection", "relay_delivery",
+	"relay_injection", "relay_message", "relay_permfail", "relay_rejection", "relay_tempfail",
+	"sms_status", "spam_complaint",
+}
+
+func decodeGeneric(t *testing.T, data []byte) []map[string]map[string]map[string]any {
+	t.Helper()
+	var batch []map[string]map[string]map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&batch); err != nil {
+		t.Fatal(err)
+	}
+	return batch
+}
+
+// TestWireRoundTrip checks that every type of event re-marshals in SparkPost's wire format,
+// with exactly the fields and values it was sent with.
+func TestWireRoundTrip(t *testing.T) {
+	payload, err := os.ReadFile("test/json/sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var evs Events
+	if err = json.Unmarshal(payload, &evs); err != nil {
+		t.Fatal(err)
+	}
+	covered := map[string]bool{}
+	for idx, e := range evs {
+		if u, ok := e.(*Unknown); ok {
+			t.Fatalf("Event[%d] => %s", idx, u)
+		}
+		covered[e.EventType()] = true
+	}
+	for _, name := range eventTypes {
+		if !ValidEventType(name) {
+			t.Errorf("ValidEventType(%q) => false", name)
+		} else if !covered[name] {
+			t.Errorf("sample-events.json has no %q event", name)
+		}
+	}
+
+	out, err := json.Marshal(evs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, got := decodeGeneric(t, payload), decodeGeneric(t, out)
+	if len(got) != len(want) {
+		t.Fatalf("Marshal => %d events want %d", len(got), len(want))
+	}
+	for idx := range want {
+		if !reflect.DeepEqual(got[idx], want[idx]) {
+			t.Errorf("Event[%d] (%s) => got/want:\n%v\n%v", idx, evs[idx].EventType(), got[idx], want[idx])
+		}
+	}
+}
+
+// TestEventsAPIRoundTrip checks that events from the Events API, which sends numbers as numbers
+// and leaves out fields it doesn't have, re-marshal the same, and that changes are marshaled.
+func TestEventsAPIRoundTrip(t *testing.T) {
+	payload, err := os.ReadFile("test/json/events-api.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var raw struct {
+		Results []map[string]any `json:"results"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	if err = dec.Decode(&raw); err != nil {
+		t.Fatal(err)
+	}
+
+	var evs Events
+	if err = json.Unmarshal(payload, &evs); err != nil {
+		t.Fatal(err)
+	}
+	if len(evs) != len(raw.Results) {
+		t.Fatalf("Unmarshal => %d events want %d", len(evs), len(raw.Results))
+	}
+	decode := func(e Event) map[string]any {
+		out, err := json.Marshal(e)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got map[string]any
+		dec := json.NewDecoder(bytes.NewReader(out))
+		dec.UseNumber()
+		if err = dec.Decode(&got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	for idx, e := range evs {
+		if u, ok := e.(*Unknown); ok {
+			t.Fatalf("Event[%d] => %s", idx, u)
+		}
+		if got := decode(e); !reflect.DeepEqual(got, raw.Results[idx]) {
+			t.Errorf("Event[%d] (%s) => got/want:\n%v\n%v", idx, e.EventType(), got, raw.Results[idx])
+		}
+	}
+
+	// Changed fields are marshaled as webhooks send them, and fields set in code are added.
+	b := evs[0].(*Bounce)
+	b.SubaccountID.Value = 102
+	b.IPPool = "shared"
+	got := decode(b)
+	if got["subaccount_id"] != "102" || got["ip_pool"] != "shared" || got["msg_size"] != json.Number("1337") {
+		t.Errorf("Bounce changed => %v", got)
+	} else if _, ok := got["sending_ip"]; ok {
+		t.Errorf("Bounce changed => added sending_ip: %v", got)
+	}
+
+	// Fields the struct doesn't have are passed through.
+	var unknown Events
+	if err = json.Unmarshal([]byte(`{"results":[{"type":"delivery","queue_time":5,"new_field":{"a":[1,2]}}]}`), &unknown); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := json.Marshal(unknown[0]); err != nil {
+		t.Fatal(err)
+	} else if string(out) != `{"new_field":{"a":[1,2]},"queue_time":5,"type":"delivery"}` {
+		t.Errorf("Delivery with new field => %s", out)
+	}
+
+	// Events created in code have every field.
+	if out := decode(&Delivery{EventCommon: EventCommon{Type: "delivery"}}); out["subaccount_id"] != "0" {
+		t.Errorf("Delivery created => %v", out)
+	}
+}
+
+// TestLegacySamples checks events in the older sample format, with numeric timestamps
+// and coordinates in strings, decode the same after being re-marshaled.
+func TestLegacySamples(t *testing.T) {
+	payload, err := os.ReadFile("../test/json/sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var evs, again Events
+	if err = json.Unmarshal(payload, &evs); err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(evs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = json.Unmarshal(out, &again); err != nil {
+		t.Fatal(err)
+	}
+	if len(again) != len(evs) {
+		t.Fatalf("Unmarshal => %d events want %d", len(again), len(evs))
+	}
+	for idx := range evs {
+		if _, ok := evs[idx].(*Unknown); ok {
+			t.Errorf("Event[%d] => %s", idx, evs[idx])
+		} else if !reflect.DeepEqual(again[idx], evs[idx]) {
+			t.Errorf("Event[%d] => got/want:\n%+v\n%+v", idx, again[idx], evs[idx])
+		}
+	}
+}
+
+func TestTypedFields(t *testing.T) {
+	payload, err := os.ReadFile("test/json/sample-events.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var evs Events
+	if err = json.Unmarshal(payload, &evs); err != nil {
+		t.Fatal(err)
+	}
+	byType := map[string]Event{}
+	for _, e := range evs {
+		if _, ok := byType[e.EventType()]; !ok {
+			byType[e.EventType()] = e
+		}
+	}
+
+	sampleTime := time.Unix(1454442600, 0)
+	b := byType["bounce"].(*Bounce)
+	if b.Retries.Value != 2 || b.MessageSize.Value != 1337 || b.BounceClass.Value != 1 || b.SubaccountID.Value != 101 {
+		t.Errorf("Bounce => retries %d, size %d, class %d, subaccount %d", b.Retries, b.MessageSize, b.BounceClass, b.SubaccountID)
+	}
+	if !b.Timestamp.Equal(sampleTime) {
+		t.Errorf("Bounce.Timestamp => %s want %s", b.Timestamp, sampleTime)
+	}
+	if b.Metadata["customKey"] != "customValue" {
+		t.Errorf("Bounce.Metadata => %v", b.Metadata)
+	}
+
+	d := byType["delivery"].(*Delivery)
+	if d.QueueTime.Value != 12 || d.TemplateVersion.Value != 1 {
+		t.Errorf("Delivery => queue time %d, template version %d", d.QueueTime, d.TemplateVersion)
+	}
+
+	c := byType["creation"].(*Creation)
+	if nested, ok := c.Metadata["nested"].(map[string]any); !ok || nested["level"] != float64(2) {
+		t.Errorf("Creation.Metadata => %#v", c.Metadata)
+	}
+	if c.String() != sampleTime.String()+" CT 65832150921904138 (4, 3)" {
+		t.Errorf("Creation.String => %q", c.String())
+	}
+
+	s := byType["sms_status"].(*SMSStatus)
+	if s.DRLatency.Value != 0.02 {
+		t.Errorf("SMSStatus.DRLatency => %v", s.DRLatency)
+	}
+
+	ac := byType["amp_click"].(*AMPClick)
+	if !ac.InjectionTime.Equal(time.Date(2016, 4, 18, 14, 25, 7, 0, time.UTC)) || !ac.AMPEnabled {
+		t.Errorf("AMPClick => injection time %s, amp %v", ac.InjectionTime, ac.AMPEnabled)
+	}
+	if ac.GeoIP == nil || ac.GeoIP.Latitude.Value != 39.1749 {
+		t.Errorf("AMPClick.GeoIP => %+v", ac.GeoIP)
+	}
+
+	i := byType["ingest_success"].(*IngestSuccess)
+	if i.NumberSucceeded != 125 || !i.ExpirationTimestamp.Equal(time.Date(2018, 9, 17, 21, 38, 8, 0, time.UTC)) {
+		t.Errorf("IngestSuccess => %d succeeded, expires %s", i.NumberSucceeded, i.ExpirationTimestamp)
+	}
+
+	if m := byType["relay_message"].(*RelayMessage); m.Type != "relay_message" || m.Content.Subject == "" {
+		t.Errorf("RelayMessage => %+v", m)
+	}
+}
+
+func TestTimestamp(t *testing.T) {
+	for idx, test := range []struct {
+		in   string
+		want time.Time
+		err  bool
+	}{
+		{`1454442600`, time.Unix(1454442600, 0), false},
+		{`"1454442600"`, time.Unix(1454442600, 0), false},
+		{`"1454442600.250"`, time.Unix(1454442600, 250e6), false},
+		{`"2016-04-18T14:25:07.000Z"`, time.Date(2016, 4, 18, 14, 25, 7, 0, time.UTC), false},
+		{`"2016-04-18T14:25:07.000+00:00"`, time.Date(2016, 4, 18, 14, 25, 7, 0, time.UTC), false},
+		{`"2016-04-18T10:25:07-04:00"`, time.Date(2016, 4, 18, 14, 25, 7, 0, time.UTC), false},
+		{`null`, time.Time{}, false},
+		{`"yesterday"`, time.Time{}, true},
+	} {
+		var ts Timestamp
+		err := json.Unmarshal([]byte(test.in), &ts)
+		if (err != nil) != test.err {
+			t.Errorf("Timestamp[%d] => err %v", idx, err)
+			continue
+		} else if err != nil {
+			continue
+		}
+		if !ts.Equal(test.want) {
+			t.Errorf("Timestamp[%d] => %s want %s", idx, ts, test.want)
+		}
+
+		// Timestamps re-marshal as they were received.
+		out, err := json.Marshal(ts)
+		if err != nil {
+			t.Fatal(err)
+		} else if string(out) != test.in {
+			t.Errorf("Timestamp[%d] => marshaled %s want %s", idx, out, test.in)
+		}
+	}
+
+	// Once changed, or when created, Timestamps marshal as Unix seconds.
+	var ts Timestamp
+	if err := json.Unmarshal([]byte(`"2016-04-18T14:25:07.000Z"`), &ts); err != nil {
+		t.Fatal(err)
+	}
+	ts.Time = ts.Add(time.Minute)
+	for idx, test := range []struct {
+		in   Timestamp
+		want string
+	}{
+		{ts, `"1460989567"`},
+		{NewTimestamp(time.Unix(1454442600, 0)), `"1454442600"`},
+	} {
+		if out, err := json.Marshal(test.in); err != nil {
+			t.Fatal(err)
+		} else if string(out) != test.want {
+			t.Errorf("Timestamp.Marshal[%d] => %s want %s", idx, out, test.want)
+		}
+	}
+}
+
+func TestNumbers(t *testing.T) {
+	type numbers struct {
+		Int     Int     `json:"int"`
+		Float   Float   `json:"float"`
+		Bool    Bool    `json:"bool"`
+		LatLong LatLong `json:"latlong"`
+	}
+	for idx, test := range []struct {
+		in  string
+		err bool
+	}{
+		{`{"int":"12","float":"0.02","bool":"1","latlong":"38.8"}`, false},
+		{`{"int":12,"float":0.5,"bool":false,"latlong":-77.5}`, false},
+		{`{"int":"","float":null,"bool":"","latlong":0}`, false},
+		{`{"int":null,"float":"","bool":null,"latlong":"0"}`, false},
+		{`{"int":"twelve"}`, true},
+		{`{"bool":"yes"}`, true},
+	} {
+		var v numbers
+		err := json.Unmarshal([]byte(test.in), &v)
+		if (err != nil) != test.err {
+			t.Errorf("Numbers[%d] => err %v", idx, err)
+			continue
+		} else if err != nil {
+			continue
+		}
+
+		// Numbers re-marshal as they were received.
+		if out, err := json.Marshal(v); err != nil {
+			t.Fatal(err)
+		} else if string(out) != test.in {
+			t.Errorf("Numbers[%d] => %s want %s", idx, out, test.in)
+		}
+	}
+
+	// Once changed, or when created, numbers marshal the way webhooks send them.
+	var v numbers
+	if err := json.Unmarshal([]byte(`{"int":12,"float":0.5,"bool":false,"latlong":"38.8"}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	v.Int.Value, v.Float.Value, v.Bool.Value, v.LatLong.Value = 13, 0.25, true, 39.5
+	for idx, test := range []struct {
+		in   numbers
+		want string
+	}{
+		{v, `{"int":"13","float":"0.25","bool":"1","latlong":39.5}`},
+		{numbers{NewInt(7), NewFloat(0.02), NewBool(false), NewLatLong(-77.5)},
+			`{"int":"7","float":"0.02","bool":"0","latlong":-77.5}`},
+	} {
+		if out, err := json.Marshal(test.in); err != nil {
+			t.Fatal(err)
+		} else if string(out) != test.want {
+			t.Errorf("Numbers.Marshal[%d] => %s want %s", idx, out, test.want)
+		}
+	}
+}
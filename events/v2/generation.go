@@ -0,0 +1,63 @@
+package events
+
+import "fmt"
+
+type GenerationFailure struct {
+	EventCommon
+	ABTestID              string         `json:"ab_test_id"`
+	ABTestVersion         Int            `json:"ab_test_version"`
+	AMPEnabled            bool           `json:"amp_enabled"`
+	Binding               string         `json:"binding"`
+	BindingGroup          string         `json:"binding_group"`
+	CampaignID            string         `json:"campaign_id"`
+	CustomerID            string         `json:"customer_id"`
+	ErrorCode             string         `json:"error_code"`
+	FriendlyFrom          string         `json:"friendly_from"`
+	InjectionTime         Timestamp      `json:"injection_time"`
+	IPPool                string         `json:"ip_pool"`
+	MailboxProvider       string         `json:"mailbox_provider"`
+	MailboxProviderRegion string         `json:"mailbox_provider_region"`
+	RawRecipient          string         `json:"raw_rcpt_to"`
+	Metadata              map[string]any `json:"rcpt_meta"`
+	SubstitutionData      map[string]any `json:"rcpt_subs"`
+	Tags                  []string       `json:"rcpt_tags"`
+	Recipient             string         `json:"rcpt_to"`
+	RawReason             string         `json:"raw_reason"`
+	Reason                string         `json:"reason"`
+	RecipientDomain       string         `json:"recipient_domain"`
+	ReceiveProtocol       string         `json:"recv_method"`
+	RoutingDomain         string         `json:"routing_domain"`
+	SendingIP             string         `json:"sending_ip"`
+	SubaccountID          Int            `json:"subaccount_id"`
+	Subject               string         `json:"subject"`
+	TemplateID            string         `json:"template_id"`
+	TemplateVersion       Int            `json:"template_version"`
+	Timestamp             Timestamp      `json:"timestamp"`
+	TransmissionID        string         `json:"transmission_id"`
+}
+
+// String returns a brief summary of a GenerationFailure event
+func (g *GenerationFailure) String() string {
+	return fmt.Sprintf("%s GF %s %s => %s %s: %s",
+		g.Timestamp, g.TransmissionID, g.Binding, g.Recipient,
+		g.ErrorCode, g.RawReason)
+}
+
+func (g *GenerationFailure) MarshalJSON() ([]byte, error) {
+	type fields GenerationFailure
+	return marshalEvent(g.received, (*fields)(g))
+}
+
+type GenerationRejection GenerationFailure
+
+// String returns a brief summary of a GenerationFailure event
+func (g *GenerationRejection) String() string {
+	return fmt.Sprintf("%s GR %s %s => %s %s: %s",
+		g.Timestamp, g.TransmissionID, g.Binding, g.Recipient,
+		g.ErrorCode, g.RawReason)
+}
+
+func (g *GenerationRejection) MarshalJSON() ([]byte, error) {
+	type fields GenerationRejection
+	return marshalEvent(g.received, (*fields)(g))
+}
@@ -0,0 +1,45 @@
+package events
+
+import "fmt"
+
+// IngestSuccess is recorded when a batch of events posted to the Ingest API has been processed.
+type IngestSuccess struct {
+	EventCommon
+	BatchID                string    `json:"batch_id"`
+	CustomerID             string    `json:"customer_id"`
+	ErrorType              string    `json:"error_type"`
+	ExpirationTimestamp    Timestamp `json:"expiration_timestamp"`
+	FirstReceivedTimestamp Timestamp `json:"first_received_timestamp"`
+	Href                   string    `json:"href"`
+	NumberDuplicates       int       `json:"number_duplicates"`
+	NumberFailed           int       `json:"number_failed"`
+	NumberSucceeded        int       `json:"number_succeeded"`
+	SubaccountID           Int       `json:"subaccount_id"`
+	Timestamp              Timestamp `json:"timestamp"`
+}
+
+// String returns a brief summary of an IngestSuccess event
+func (i *IngestSuccess) String() string {
+	return fmt.Sprintf("%s IS %s %d ok, %d failed, %d duplicate",
+		i.Timestamp, i.BatchID, i.NumberSucceeded, i.NumberFailed, i.NumberDuplicates)
+}
+
+func (i *IngestSuccess) MarshalJSON() ([]byte, error) {
+	type fields IngestSuccess
+	return marshalEvent(i.received, (*fields)(i))
+}
+
+// IngestError is recorded when a batch posted to the Ingest API couldn't be processed.
+// ErrorType says why, and Href links to the batch, which may be retried until ExpirationTimestamp.
+type IngestError IngestSuccess
+
+// String returns a brief summary of an IngestError event
+func (i *IngestError) String() string {
+	return fmt.Sprintf("%s IE %s %s",
+		i.Timestamp, i.BatchID, i.ErrorType)
+}
+
+func (i *IngestError) MarshalJSON() ([]byte, error) {
+	type fields IngestError
+	return marshalEvent(i.received, (*fields)(i))
+}
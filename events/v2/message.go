@@ -0,0 +1,444 @@
+package events
+
+import "fmt"
+
+type Delivery struct {
+	EventCommon
+	SMS
+	ABTestID              string         `json:"ab_test_id"`
+	ABTestVersion         Int            `json:"ab_test_version"`
+	AMPEnabled            bool           `json:"amp_enabled"`
+	Binding               string         `json:"binding"`
+	BindingGroup          string         `json:"binding_group"`
+	CampaignID            string         `json:"campaign_id"`
+	CustomerID            string         `json:"customer_id"`
+	DeliveryMethod        string         `json:"delv_method"`
+	DeviceToken           string         `json:"device_token"`
+	FriendlyFrom          string         `json:"friendly_from"`
+	InjectionTime         Timestamp      `json:"injection_time"`
+	IPAddress             string         `json:"ip_address"`
+	IPPool                string         `json:"ip_pool"`
+	MailboxProvider       string         `json:"mailbox_provider"`
+	MailboxProviderRegion string         `json:"mailbox_provider_region"`
+	MessageID             string         `json:"message_id"`
+	MessageFrom           string         `json:"msg_from"`
+	MessageSize           Int            `json:"msg_size"`
+	Retries               Int            `json:"num_retries"`
+	OutboundTLS           Bool           `json:"outbound_tls"`
+	QueueTime             Int            `json:"queue_time"`
+	RawRecipient          string         `json:"raw_rcpt_to"`
+	Metadata              map[string]any `json:"rcpt_meta"`
+	Tags                  []string       `json:"rcpt_tags"`
+	Recipient             string         `json:"rcpt_to"`
+	RecipientType         string         `json:"rcpt_type"`
+	RecipientDomain       string         `json:"recipient_domain"`
+	ReceiveProtocol       string         `json:"recv_method"`
+	RoutingDomain         string         `json:"routing_domain"`
+	SendingIP             string         `json:"sending_ip"`
+	SubaccountID          Int            `json:"subaccount_id"`
+	Subject               string         `json:"subject"`
+	TemplateID            string         `json:"template_id"`
+	TemplateVersion       Int            `json:"template_version"`
+	Timestamp             Timestamp      `json:"timestamp"`
+	TransmissionID        string         `json:"transmission_id"`
+}
+
+// String returns a brief summary of a Delivery event
+func (d *Delivery) String() string {
+	return fmt.Sprintf("%s D %s %s => %s",
+		d.Timestamp, d.TransmissionID, d.Binding, d.Recipient)
+}
+
+func (d *Delivery) MarshalJSON() ([]byte, error) {
+	type fields Delivery
+	return marshalEvent(d.received, (*fields)(d))
+}
+
+// ECLog emits a Delivery in the same format that it would be logged to mainlog.ec:
+// https://support.messagesystems.com/docs/web-ref/log_formats.version_3.php
+func (d *Delivery) ECLog() string {
+	return fmt.Sprintf("%s@%s@@@D@%s@%s@%s@%s@%s@%s@%s",
+		d.Timestamp, d.MessageID, d.RoutingDomain, d.MessageSize,
+		d.Binding, d.BindingGroup, d.Retries, d.QueueTime, d.IPAddress)
+}
+
+type Injection struct {
+	EventCommon
+	SMS
+	ABTestID              string         `json:"ab_test_id"`
+	ABTestVersion         Int            `json:"ab_test_version"`
+	AMPEnabled            bool           `json:"amp_enabled"`
+	Binding               string         `json:"binding"`
+	BindingGroup          string         `json:"binding_group"`
+	CampaignID            string         `json:"campaign_id"`
+	CustomerID            string         `json:"customer_id"`
+	FriendlyFrom          string         `json:"friendly_from"`
+	InjectionTime         Timestamp      `json:"injection_time"`
+	IPPool                string         `json:"ip_pool"`
+	MailboxProvider       string         `json:"mailbox_provider"`
+	MailboxProviderRegion string         `json:"mailbox_provider_region"`
+	MessageID             string         `json:"message_id"`
+	MessageFrom           string         `json:"msg_from"`
+	MessageSize           Int            `json:"msg_size"`
+	RawRecipient          string         `json:"raw_rcpt_to"`
+	Metadata              map[string]any `json:"rcpt_meta"`
+	Pathway               string         `json:"pathway"`
+	PathwayGroup          string         `json:"pathway_group"`
+	Tags                  []string       `json:"rcpt_tags"`
+	Recipient             string         `json:"rcpt_to"`
+	RecipientType         string         `json:"rcpt_type"`
+	RecipientDomain       string         `json:"recipient_domain"`
+	ReceiveProtocol       string         `json:"recv_method"`
+	RoutingDomain         string         `json:"routing_domain"`
+	SendingIP             string         `json:"sending_ip"`
+	SubaccountID          Int            `json:"subaccount_id"`
+	Subject               string         `json:"subject"`
+	TemplateID            string         `json:"template_id"`
+	TemplateVersion       Int            `json:"template_version"`
+	Timestamp             Timestamp      `json:"timestamp"`
+	TransmissionID        string         `json:"transmission_id"`
+}
+
+// String returns a brief summary of a GenerationFailure event
+func (i *Injection) String() string {
+	return fmt.Sprintf("%s R %s %s => %s",
+		i.Timestamp, i.TransmissionID, i.Binding, i.Recipient)
+}
+
+func (i *Injection) MarshalJSON() ([]byte, error) {
+	type fields Injection
+	return marshalEvent(i.received, (*fields)(i))
+}
+
+// ECLog emits an Injection in the same format that it would be logged to mainlog.ec:
+// https://support.messagesystems.com/docs/web-ref/log_formats.version_3.php
+func (i *Injection) ECLog() string {
+	return fmt.Sprintf("%s@%s@@@R@%s@%s@@%s@%s@%s@%s",
+		i.Timestamp, i.MessageID, i.Recipient, i.MessageFrom,
+		i.MessageSize, i.ReceiveProtocol,
+		i.Binding, i.BindingGroup)
+}
+
+type Bounce struct {
+	EventCommon
+	SMS
+	ABTestID              string         `json:"ab_test_id"`
+	ABTestVersion         Int            `json:"ab_test_version"`
+	AMPEnabled            bool           `json:"amp_enabled"`
+	Binding               string         `json:"binding"`
+	BindingGroup          string         `json:"binding_group"`
+	BounceClass           Int            `json:"bounce_class"`
+	CampaignID            string         `json:"campaign_id"`
+	CustomerID            string         `json:"customer_id"`
+	DeliveryMethod        string         `json:"delv_method"`
+	DeviceToken           string         `json:"device_token"`
+	ErrorCode             string         `json:"error_code"`
+	FriendlyFrom          string         `json:"friendly_from"`
+	InjectionTime         Timestamp      `json:"injection_time"`
+	IPAddress             string         `json:"ip_address"`
+	IPPool                string         `json:"ip_pool"`
+	MailboxProvider       string         `json:"mailbox_provider"`
+	MailboxProviderRegion string         `json:"mailbox_provider_region"`
+	MessageID             string         `json:"message_id"`
+	MessageFrom           string         `json:"msg_from"`
+	MessageSize           Int            `json:"msg_size"`
+	Retries               Int            `json:"num_retries"`
+	QueueTime             Int            `json:"queue_time"`
+	RawRecipient          string         `json:"raw_rcpt_to"`
+	Metadata              map[string]any `json:"rcpt_meta"`
+	Tags                  []string       `json:"rcpt_tags"`
+	Recipient             string         `json:"rcpt_to"`
+	RecipientType         string         `json:"rcpt_type"`
+	RawReason             string         `json:"raw_reason"`
+	Reason                string         `json:"reason"`
+	RecipientDomain       string         `json:"recipient_domain"`
+	ReceiveProtocol       string         `json:"recv_method"`
+	RoutingDomain         string         `json:"routing_domain"`
+	SendingIP             string         `json:"sending_ip"`
+	SubaccountID          Int            `json:"subaccount_id"`
+	Subject               string         `json:"subject"`
+	TemplateID            string         `json:"template_id"`
+	TemplateVersion       Int            `json:"template_version"`
+	Timestamp             Timestamp      `json:"timestamp"`
+	TransmissionID        string         `json:"transmission_id"`
+}
+
+// String returns a brief summary of a Bounce event
+func (b *Bounce) String() string {
+	return fmt.Sprintf("%s B %s %s => %s %s: %s",
+		b.Timestamp, b.TransmissionID, b.Binding, b.Recipient,
+		b.BounceClass, b.RawReason)
+}
+
+func (b *Bounce) MarshalJSON() ([]byte, error) {
+	type fields Bounce
+	return marshalEvent(b.received, (*fields)(b))
+}
+
+// ECLog emits a Bounce in the same format that it would be logged to bouncelog.ec:
+// https://support.messagesystems.com/docs/web-ref/log_formats.version_3.php
+func (b *Bounce) ECLog() string {
+	return fmt.Sprintf("%s@%s@@@B@%s@%s@%s@%s@@%s@%s@%s@%s",
+		b.Timestamp, b.MessageID, b.Recipient, b.MessageFrom,
+		b.Binding, b.BindingGroup, b.BounceClass, b.MessageSize,
+		b.IPAddress, b.RawReason)
+}
+
+type OutOfBand struct {
+	EventCommon
+	ABTestID              string    `json:"ab_test_id"`
+	ABTestVersion         Int       `json:"ab_test_version"`
+	AMPEnabled            bool      `json:"amp_enabled"`
+	Binding               string    `json:"binding"`
+	BindingGroup          string    `json:"binding_group"`
+	BounceClass           Int       `json:"bounce_class"`
+	CampaignID            string    `json:"campaign_id"`
+	CustomerID            string    `json:"customer_id"`
+	DeliveryMethod        string    `json:"delv_method"`
+	DeviceToken           string    `json:"device_token"`
+	ErrorCode             string    `json:"error_code"`
+	FriendlyFrom          string    `json:"friendly_from"`
+	InjectionTime         Timestamp `json:"injection_time"`
+	IPPool                string    `json:"ip_pool"`
+	MailboxProvider       string    `json:"mailbox_provider"`
+	MailboxProviderRegion string    `json:"mailbox_provider_region"`
+	MessageID             string    `json:"message_id"`
+	MessageFrom           string    `json:"msg_from"`
+	RawRecipient          string    `json:"raw_rcpt_to"`
+	Recipient             string    `json:"rcpt_to"`
+	RawReason             string    `json:"raw_reason"`
+	Reason                string    `json:"reason"`
+	RecipientDomain       string    `json:"recipient_domain"`
+	ReceiveProtocol       string    `json:"recv_method"`
+	RoutingDomain         string    `json:"routing_domain"`
+	SendingIP             string    `json:"sending_ip"`
+	SubaccountID          Int       `json:"subaccount_id"`
+	TemplateID            string    `json:"template_id"`
+	TemplateVersion       Int       `json:"template_version"`
+	Timestamp             Timestamp `json:"timestamp"`
+}
+
+// String returns a brief summary of a Bounce event
+func (b *OutOfBand) String() string {
+	return fmt.Sprintf("%s OOB [%s] %s => %s %s: %s",
+		b.Timestamp, b.CampaignID, b.Binding, b.Recipient,
+		b.BounceClass, b.RawReason)
+}
+
+func (b *OutOfBand) MarshalJSON() ([]byte, error) {
+	type fields OutOfBand
+	return marshalEvent(b.received, (*fields)(b))
+}
+
+// ECLog emits an OutOfBand in the same format that it would be logged to bouncelog.ec:
+// https://support.messagesystems.com/docs/web-ref/log_formats.version_3.php
+func (b *OutOfBand) ECLog() string {
+	return fmt.Sprintf("%s@%s@@@B@%s@%s@%s@%s@@%s@@@%s",
+		b.Timestamp, b.MessageID, b.Recipient, b.MessageFrom,
+		b.Binding, b.BindingGroup, b.BounceClass, b.RawReason)
+}
+
+type SpamComplaint struct {
+	EventCommon
+	ABTestID              string         `json:"ab_test_id"`
+	ABTestVersion         Int            `json:"ab_test_version"`
+	AMPEnabled            bool           `json:"amp_enabled"`
+	Binding               string         `json:"binding"`
+	BindingGroup          string         `json:"binding_group"`
+	CampaignID            string         `json:"campaign_id"`
+	CustomerID            string         `json:"customer_id"`
+	DeliveryMethod        string         `json:"delv_method"`
+	FeedbackType          string         `json:"fbtype"`
+	FriendlyFrom          string         `json:"friendly_from"`
+	InjectionTime         Timestamp      `json:"injection_time"`
+	IPPool                string         `json:"ip_pool"`
+	MailboxProvider       string         `json:"mailbox_provider"`
+	MailboxProviderRegion string         `json:"mailbox_provider_region"`
+	MessageID             string         `json:"message_id"`
+	RawRecipient          string         `json:"raw_rcpt_to"`
+	Metadata              map[string]any `json:"rcpt_meta"`
+	Tags                  []string       `json:"rcpt_tags"`
+	Recipient             string         `json:"rcpt_to"`
+	RecipientType         string         `json:"rcpt_type"`
+	RecipientDomain       string         `json:"recipient_domain"`
+	ReportedBy            string         `json:"report_by"`
+	ReportedTo            string         `json:"report_to"`
+	SendingIP             string         `json:"sending_ip"`
+	SubaccountID          Int            `json:"subaccount_id"`
+	Subject               string         `json:"subject"`
+	TemplateID            string         `json:"template_id"`
+	TemplateVersion       Int            `json:"template_version"`
+	Timestamp             Timestamp      `json:"timestamp"`
+	TransmissionID        string         `json:"transmission_id"`
+	UserString            string         `json:"user_str"`
+}
+
+// String returns a brief summary of a SpamComplaint event
+func (p *SpamComplaint) String() string {
+	return fmt.Sprintf("%s S %s %s %s => %s (%s)",
+		p.Timestamp, p.TransmissionID, p.Binding, p.ReportedBy, p.ReportedTo, p.Recipient)
+}
+
+func (p *SpamComplaint) MarshalJSON() ([]byte, error) {
+	type fields SpamComplaint
+	return marshalEvent(p.received, (*fields)(p))
+}
+
+type PolicyRejection struct {
+	EventCommon
+	ABTestID              string         `json:"ab_test_id"`
+	ABTestVersion         Int            `json:"ab_test_version"`
+	AMPEnabled            bool           `json:"amp_enabled"`
+	CampaignID            string         `json:"campaign_id"`
+	CustomerID            string         `json:"customer_id"`
+	ErrorCode             string         `json:"error_code"`
+	FriendlyFrom          string         `json:"friendly_from"`
+	InjectionTime         Timestamp      `json:"injection_time"`
+	IPPool                string         `json:"ip_pool"`
+	MailboxProvider       string         `json:"mailbox_provider"`
+	MailboxProviderRegion string         `json:"mailbox_provider_region"`
+	MessageID             string         `json:"message_id"`
+	MessageFrom           string         `json:"msg_from"`
+	RawRecipient          string         `json:"raw_rcpt_to"`
+	Metadata              map[string]any `json:"rcpt_meta"`
+	Pathway               string         `json:"pathway"`
+	PathwayGroup          string         `json:"pathway_group"`
+	Tags                  []string       `json:"rcpt_tags"`
+	RawReason             string         `json:"raw_reason"`
+	Reason                string         `json:"reason"`
+	Recipient             string         `json:"rcpt_to"`
+	RecipientType         string         `json:"rcpt_type"`
+	RecipientDomain       string         `json:"recipient_domain"`
+	ReceiveProtocol       string         `json:"recv_method"`
+	RemoteAddress         string         `json:"remote_addr"`
+	SendingIP             string         `json:"sending_ip"`
+	SubaccountID          Int            `json:"subaccount_id"`
+	TemplateID            string         `json:"template_id"`
+	TemplateVersion       Int            `json:"template_version"`
+	Timestamp             Timestamp      `json:"timestamp"`
+	TransmissionID        string         `json:"transmission_id"`
+}
+
+// String returns a brief summary of a PolicyRejection event
+func (p *PolicyRejection) String() string {
+	return fmt.Sprintf("%s PR %s [%s] => %s %s: %s",
+		p.Timestamp, p.TransmissionID, p.CampaignID, p.Recipient,
+		p.ErrorCode, p.RawReason)
+}
+
+func (p *PolicyRejection) MarshalJSON() ([]byte, error) {
+	type fields PolicyRejection
+	return marshalEvent(p.received, (*fields)(p))
+}
+
+type Delay struct {
+	EventCommon
+	SMS
+	ABTestID              string         `json:"ab_test_id"`
+	ABTestVersion         Int            `json:"ab_test_version"`
+	AMPEnabled            bool           `json:"amp_enabled"`
+	Binding               string         `json:"binding"`
+	BindingGroup          string         `json:"binding_group"`
+	BounceClass           Int            `json:"bounce_class"`
+	CampaignID            string         `json:"campaign_id"`
+	CustomerID            string         `json:"customer_id"`
+	DeliveryMethod        string         `json:"delv_method"`
+	DeviceToken           string         `json:"device_token"`
+	ErrorCode             string         `json:"error_code"`
+	FriendlyFrom          string         `json:"friendly_from"`
+	InjectionTime         Timestamp      `json:"injection_time"`
+	IPAddress             string         `json:"ip_address"`
+	IPPool                string         `json:"ip_pool"`
+	MailboxProvider       string         `json:"mailbox_provider"`
+	MailboxProviderRegion string         `json:"mailbox_provider_region"`
+	MessageID             string         `json:"message_id"`
+	MessageFrom           string         `json:"msg_from"`
+	MessageSize           Int            `json:"msg_size"`
+	Retries               Int            `json:"num_retries"`
+	QueueTime             Int            `json:"queue_time"`
+	RawRecipient          string         `json:"raw_rcpt_to"`
+	Metadata              map[string]any `json:"rcpt_meta"`
+	Tags                  []string       `json:"rcpt_tags"`
+	Recipient             string         `json:"rcpt_to"`
+	RecipientType         string         `json:"rcpt_type"`
+	RawReason             string         `json:"raw_reason"`
+	Reason                string         `json:"reason"`
+	RecipientDomain       string         `json:"recipient_domain"`
+	ReceiveProtocol       string         `json:"recv_method"`
+	RoutingDomain         string         `json:"routing_domain"`
+	SendingIP             string         `json:"sending_ip"`
+	SubaccountID          Int            `json:"subaccount_id"`
+	Subject               string         `json:"subject"`
+	TemplateID            string         `json:"template_id"`
+	TemplateVersion       Int            `json:"template_version"`
+	Timestamp             Timestamp      `json:"timestamp"`
+	TransmissionID        string         `json:"transmission_id"`
+}
+
+// String returns a brief summary of a Delay event
+func (d *Delay) String() string {
+	return fmt.Sprintf("%s T %s => %s %s: %s",
+		d.Timestamp, d.MessageFrom, d.Recipient, d.BounceClass, d.RawReason)
+}
+
+func (d *Delay) MarshalJSON() ([]byte, error) {
+	type fields Delay
+	return marshalEvent(d.received, (*fields)(d))
+}
+
+// ECLog emits a Delay in the same format that it would be logged to bouncelog.ec:
+// https://support.messagesystems.com/docs/web-ref/log_formats.version_3.php
+func (d *Delay) ECLog() string {
+	return fmt.Sprintf("%s@%s@@@T@%s@%s@%s@%s@@%s@%s@%s@%s",
+		d.Timestamp, d.MessageID, d.Recipient, d.MessageFrom,
+		d.Binding, d.BindingGroup, d.BounceClass, d.MessageSize,
+		d.IPAddress, d.RawReason)
+}
+
+// SMS contains the fields sent with message events when the recipient is a phone number.
+type SMS struct {
+	Coding         string   `json:"sms_coding,omitempty"`
+	Destination    string   `json:"sms_dst,omitempty"`
+	DestinationNPI string   `json:"sms_dst_npi,omitempty"`
+	DestinationTON string   `json:"sms_dst_ton,omitempty"`
+	RemoteIDs      []string `json:"sms_remoteids,omitempty"`
+	Segments       int      `json:"sms_segments,omitempty"`
+	Source         string   `json:"sms_src,omitempty"`
+	SourceNPI      string   `json:"sms_src_npi,omitempty"`
+	SourceTON      string   `json:"sms_src_ton,omitempty"`
+	Text           string   `json:"sms_text,omitempty"`
+}
+
+type SMSStatus struct {
+	EventCommon
+	CustomerID     string    `json:"customer_id"`
+	DeliveryMethod string    `json:"delv_method"`
+	DRLatency      Float     `json:"dr_latency"`
+	IPAddress      string    `json:"ip_address"`
+	RawReason      string    `json:"raw_reason"`
+	Reason         string    `json:"reason"`
+	RoutingDomain  string    `json:"routing_domain"`
+	Destination    string    `json:"sms_dst"`
+	DestinationNPI string    `json:"sms_dst_npi"`
+	DestinationTON string    `json:"sms_dst_ton"`
+	RemoteIDs      []string  `json:"sms_remoteids"`
+	Source         string    `json:"sms_src"`
+	SourceNPI      string    `json:"sms_src_npi"`
+	SourceTON      string    `json:"sms_src_ton"`
+	Text           string    `json:"sms_text"`
+	StatusType     string    `json:"stat_type"`
+	StatusState    string    `json:"stat_state"`
+	SubaccountID   Int       `json:"subaccount_id"`
+	Timestamp      Timestamp `json:"timestamp"`
+}
+
+// String returns a brief summary of an SMSStatus event
+func (e *SMSStatus) String() string {
+	return fmt.Sprintf("%s SMS %s => %s %s/%s: %s",
+		e.Timestamp, e.Source, e.Destination, e.StatusType, e.StatusState, e.RawReason)
+}
+
+func (e *SMSStatus) MarshalJSON() ([]byte, error) {
+	type fields SMSStatus
+	return marshalEvent(e.received, (*fields)(e))
+}
@@ -0,0 +1,151 @@
+package events
+
+import "fmt"
+
+type Click struct {
+	EventCommon
+	ABTestID              string         `json:"ab_test_id"`
+	ABTestVersion         Int            `json:"ab_test_version"`
+	AMPEnabled            bool           `json:"amp_enabled"`
+	AcceptLanguage        string         `json:"accept_language"`
+	CampaignID            string         `json:"campaign_id"`
+	CustomerID            string         `json:"customer_id"`
+	DeliveryMethod        string         `json:"delv_method"`
+	FriendlyFrom          string         `json:"friendly_from"`
+	GeoIP                 *GeoIP         `json:"geo_ip"`
+	InjectionTime         Timestamp      `json:"injection_time"`
+	IPAddress             string         `json:"ip_address"`
+	IPPool                string         `json:"ip_pool"`
+	MailboxProvider       string         `json:"mailbox_provider"`
+	MailboxProviderRegion string         `json:"mailbox_provider_region"`
+	MessageID             string         `json:"message_id"`
+	RawRecipient          string         `json:"raw_rcpt_to"`
+	Metadata              map[string]any `json:"rcpt_meta"`
+	Tags                  []string       `json:"rcpt_tags"`
+	Recipient             string         `json:"rcpt_to"`
+	RecipientType         string         `json:"rcpt_type"`
+	RecipientDomain       string         `json:"recipient_domain"`
+	RoutingDomain         string         `json:"routing_domain"`
+	SendingIP             string         `json:"sending_ip"`
+	SubaccountID          Int            `json:"subaccount_id"`
+	Subject               string         `json:"subject"`
+	TargetLinkName        string         `json:"target_link_name"`
+	TargetLinkURL         string         `json:"target_link_url"`
+	TemplateID            string         `json:"template_id"`
+	TemplateVersion       Int            `json:"template_version"`
+	Timestamp             Timestamp      `json:"timestamp"`
+	TransmissionID        string         `json:"transmission_id"`
+	UserAgent             string         `json:"user_agent"`
+}
+
+// String returns a brief summary of a Click event
+func (c *Click) String() string {
+	return fmt.Sprintf("%s C %s %s => %s",
+		c.Timestamp, c.TransmissionID, c.Recipient, c.TargetLinkURL)
+}
+
+func (c *Click) MarshalJSON() ([]byte, error) {
+	type fields Click
+	return marshalEvent(c.received, (*fields)(c))
+}
+
+type Open struct {
+	EventCommon
+	ABTestID              string         `json:"ab_test_id"`
+	ABTestVersion         Int            `json:"ab_test_version"`
+	AMPEnabled            bool           `json:"amp_enabled"`
+	AcceptLanguage        string         `json:"accept_language"`
+	CampaignID            string         `json:"campaign_id"`
+	CustomerID            string         `json:"customer_id"`
+	DeliveryMethod        string         `json:"delv_method"`
+	FriendlyFrom          string         `json:"friendly_from"`
+	GeoIP                 *GeoIP         `json:"geo_ip"`
+	InjectionTime         Timestamp      `json:"injection_time"`
+	IPAddress             string         `json:"ip_address"`
+	IPPool                string         `json:"ip_pool"`
+	MailboxProvider       string         `json:"mailbox_provider"`
+	MailboxProviderRegion string         `json:"mailbox_provider_region"`
+	MessageID             string         `json:"message_id"`
+	RawRecipient          string         `json:"raw_rcpt_to"`
+	Metadata              map[string]any `json:"rcpt_meta"`
+	Tags                  []string       `json:"rcpt_tags"`
+	Recipient             string         `json:"rcpt_to"`
+	RecipientType         string         `json:"rcpt_type"`
+	RecipientDomain       string         `json:"recipient_domain"`
+	RoutingDomain         string         `json:"routing_domain"`
+	SendingIP             string         `json:"sending_ip"`
+	SubaccountID          Int            `json:"subaccount_id"`
+	Subject               string         `json:"subject"`
+	TemplateID            string         `json:"template_id"`
+	TemplateVersion       Int            `json:"template_version"`
+	Timestamp             Timestamp      `json:"timestamp"`
+	TransmissionID        string         `json:"transmission_id"`
+	UserAgent             string         `json:"user_agent"`
+}
+
+// String returns a brief summary of an Open event
+func (o *Open) String() string {
+	return fmt.Sprintf("%s O %s %s",
+		o.Timestamp, o.TransmissionID, o.Recipient)
+}
+
+func (o *Open) MarshalJSON() ([]byte, error) {
+	type fields Open
+	return marshalEvent(o.received, (*fields)(o))
+}
+
+// InitialOpen is recorded when the tracking pixel at the top of a message is loaded.
+type InitialOpen Open
+
+// String returns a brief summary of an InitialOpen event
+func (o *InitialOpen) String() string {
+	return fmt.Sprintf("%s IO %s %s",
+		o.Timestamp, o.TransmissionID, o.Recipient)
+}
+
+func (o *InitialOpen) MarshalJSON() ([]byte, error) {
+	type fields InitialOpen
+	return marshalEvent(o.received, (*fields)(o))
+}
+
+// AMPClick is a Click in the AMP part of a message.
+type AMPClick Click
+
+// String returns a brief summary of an AMPClick event
+func (c *AMPClick) String() string {
+	return fmt.Sprintf("%s AC %s %s => %s",
+		c.Timestamp, c.TransmissionID, c.Recipient, c.TargetLinkURL)
+}
+
+func (c *AMPClick) MarshalJSON() ([]byte, error) {
+	type fields AMPClick
+	return marshalEvent(c.received, (*fields)(c))
+}
+
+// AMPOpen is an Open of the AMP part of a message.
+type AMPOpen Open
+
+// String returns a brief summary of an AMPOpen event
+func (o *AMPOpen) String() string {
+	return fmt.Sprintf("%s AO %s %s",
+		o.Timestamp, o.TransmissionID, o.Recipient)
+}
+
+func (o *AMPOpen) MarshalJSON() ([]byte, error) {
+	type fields AMPOpen
+	return marshalEvent(o.received, (*fields)(o))
+}
+
+// AMPInitialOpen is an InitialOpen of the AMP part of a message.
+type AMPInitialOpen Open
+
+// String returns a brief summary of an AMPInitialOpen event
+func (o *AMPInitialOpen) String() string {
+	return fmt.Sprintf("%s AIO %s %s",
+		o.Timestamp, o.TransmissionID, o.Recipient)
+}
+
+func (o *AMPInitialOpen) MarshalJSON() ([]byte, error) {
+	type fields AMPInitialOpen
+	return marshalEvent(o.received, (*fields)(o))
+}
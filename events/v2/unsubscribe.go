@@ -0,0 +1,65 @@
+package events
+
+import "fmt"
+
+type ListUnsubscribe struct {
+	EventCommon
+	ABTestID              string         `json:"ab_test_id"`
+	ABTestVersion         Int            `json:"ab_test_version"`
+	AMPEnabled            bool           `json:"amp_enabled"`
+	CampaignID            string         `json:"campaign_id"`
+	CustomerID            string         `json:"customer_id"`
+	FriendlyFrom          string         `json:"friendly_from"`
+	InjectionTime         Timestamp      `json:"injection_time"`
+	IPPool                string         `json:"ip_pool"`
+	MailboxProvider       string         `json:"mailbox_provider"`
+	MailboxProviderRegion string         `json:"mailbox_provider_region"`
+	MessageFrom           string         `json:"mailfrom"`
+	MessageID             string         `json:"message_id"`
+	RawRecipient          string         `json:"raw_rcpt_to"`
+	Metadata              map[string]any `json:"rcpt_meta"`
+	Tags                  []string       `json:"rcpt_tags"`
+	Recipient             string         `json:"rcpt_to"`
+	RecipientType         string         `json:"rcpt_type"`
+	RecipientDomain       string         `json:"recipient_domain"`
+	SendingIP             string         `json:"sending_ip"`
+	SubaccountID          Int            `json:"subaccount_id"`
+	TemplateID            string         `json:"template_id"`
+	TemplateVersion       Int            `json:"template_version"`
+	Timestamp             Timestamp      `json:"timestamp"`
+	TransmissionID        string         `json:"transmission_id"`
+}
+
+// String returns a brief summary of a ListUnsubscribe event
+func (l *ListUnsubscribe) String() string {
+	return fmt.Sprintf("%s U %s %s: [%s]",
+		l.Timestamp, l.TransmissionID, l.Recipient, l.CampaignID)
+}
+
+func (l *ListUnsubscribe) MarshalJSON() ([]byte, error) {
+	type fields ListUnsubscribe
+	return marshalEvent(l.received, (*fields)(l))
+}
+
+type LinkUnsubscribe struct {
+	EventCommon
+	ListUnsubscribe
+	UserAgent string `json:"user_agent"`
+}
+
+// String returns a brief summary of a ListUnsubscribe event
+func (l *LinkUnsubscribe) String() string {
+	return fmt.Sprintf("%s LU %s %s: [%s]",
+		l.Timestamp, l.TransmissionID, l.Recipient, l.CampaignID)
+}
+
+func (l *LinkUnsubscribe) MarshalJSON() ([]byte, error) {
+	// The embedded ListUnsubscribe's MarshalJSON would hide the other fields, so it's embedded without it.
+	type list ListUnsubscribe
+	fields := struct {
+		EventCommon
+		list
+		UserAgent string `json:"user_agent"`
+	}{l.EventCommon, list(l.ListUnsubscribe), l.UserAgent}
+	return marshalEvent(l.received, &fields)
+}
@@ -0,0 +1,129 @@
+package gosparkpost
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SparkPost/gosparkpost/events"
+	"github.com/pkg/errors"
+)
+
+// https://developers.sparkpost.com/api/events/
+var EventsSearchPathFormat = "/api/v%d/events/message"
+
+// EventsSearchMaxPerPage is the largest page size the Events API supports.
+const EventsSearchMaxPerPage = 10000
+
+// EventsFilter selects events from the Events API, which supersedes Message Events.
+// Empty fields don't filter, and list fields match any of their values.
+type EventsFilter struct {
+	// From and To bound the time events happened. The API defaults to the last 24 hours.
+	From time.Time
+	To   time.Time
+
+	Events           []string
+	Campaigns        []string
+	Templates        []string
+	Subaccounts      []int
+	Recipients       []string
+	RecipientDomains []string
+
+	// PerPage is the number of events in each page, up to EventsSearchMaxPerPage. Zero uses the API default.
+	PerPage int
+	// Extra params are added as-is, for filters not covered above.
+	Extra map[string]string
+}
+
+// Params validates the filter, and returns it as query params for the Events API.
+func (f *EventsFilter) Params() (map[string]string, error) {
+	params := map[string]string{}
+	for k, v := range f.Extra {
+		params[k] = v
+	}
+
+	if !f.From.IsZero() && !f.To.IsZero() && f.From.After(f.To) {
+		return nil, errors.New("EventsFilter From must be before To")
+	}
+	if !f.From.IsZero() {
+		params["from"] = f.From.UTC().Format(time.RFC3339)
+	}
+	if !f.To.IsZero() {
+		params["to"] = f.To.UTC().Format(time.RFC3339)
+	}
+
+	for _, etype := range f.Events {
+		if !events.ValidEventType(etype) {
+			return nil, fmt.Errorf("Invalid event type [%s]", etype)
+		}
+	}
+	subaccounts := make([]string, len(f.Subaccounts))
+	for i, id := range f.Subaccounts {
+		subaccounts[i] = strconv.Itoa(id)
+	}
+	for param, values := range map[string][]string{
+		"events":            f.Events,
+		"campaigns":         f.Campaigns,
+		"templates":         f.Templates,
+		"subaccounts":       subaccounts,
+		"recipients":        f.Recipients,
+		"recipient_domains": f.RecipientDomains,
+	} {
+		if len(values) > 0 {
+			params[param] = strings.Join(values, ",")
+		}
+	}
+
+	if f.PerPage < 0 || f.PerPage > EventsSearchMaxPerPage {
+		return nil, fmt.Errorf("EventsFilter PerPage must be between 0 and %d", EventsSearchMaxPerPage)
+	} else if f.PerPage > 0 {
+		params["per_page"] = strconv.Itoa(f.PerPage)
+	}
+	return params, nil
+}
+
+// EventsSearch returns the first page of events matching filter from the Events API.
+// The page is decoded the same way as for MessageEventsSearch, and Next follows the cursor to the next page.
+// https://developers.sparkpost.com/api/events/#events-get-search-for-message-events
+func (c *Client) EventsSearch(filter *EventsFilter) (*EventsPage, *Response, error) {
+	return c.EventsSearchContext(context.Background(), filter)
+}
+
+// EventsSearchContext is the same as EventsSearch, and it accepts a context.Context
+func (c *Client) EventsSearchContext(ctx context.Context, filter *EventsFilter) (*EventsPage, *Response, error) {
+	if filter == nil {
+		filter = &EventsFilter{}
+	}
+	params, err := filter.Params()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := &EventsPage{}
+	path := fmt.Sprintf(EventsSearchPathFormat, c.Config.ApiVersion)
+	res, err := c.getPage(ctx, listHref(path, params), page)
+	if err != nil {
+		return nil, res, err
+	}
+	page.Client = c
+	page.Params = params
+	return page, res, nil
+}
+
+// EventsSearchIter returns an Iterator over the events matching filter from the Events API,
+// which follows the cursor from page to page. An invalid filter is returned by the Iterator's Err.
+func (c *Client) EventsSearchIter(filter *EventsFilter) *Iterator[events.Event] {
+	if filter == nil {
+		filter = &EventsFilter{}
+	}
+	params, err := filter.Params()
+	if err != nil {
+		return newIterator("/", func(ctx context.Context, _ string) (*page[events.Event], *Response, error) {
+			return nil, nil, err
+		})
+	}
+	path := fmt.Sprintf(EventsSearchPathFormat, c.Config.ApiVersion)
+	return eventsPageIterator(c, listHref(path, params))
+}
@@ -0,0 +1,157 @@
+package gosparkpost_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/SparkPost/gosparkpost/events"
+	"github.com/pkg/errors"
+)
+
+func TestEventsFilterParams(t *testing.T) {
+	from := time.Date(2018, 11, 5, 17, 0, 0, 0, time.FixedZone("EST", -5*3600))
+	for idx, test := range []struct {
+		in     *sp.EventsFilter
+		params map[string]string
+		err    error
+	}{
+		{&sp.EventsFilter{}, map[string]string{}, nil},
+		{&sp.EventsFilter{
+			From:             from,
+			To:               from.Add(time.Hour),
+			Events:           []string{"bounce", "delay"},
+			Campaigns:        []string{"Summer Sale"},
+			Templates:        []string{"summer-sale", "winter-sale"},
+			Subaccounts:      []int{0, 101},
+			Recipients:       []string{"recipient@example.com"},
+			RecipientDomains: []string{"example.com"},
+			PerPage:          1000,
+			Extra:            map[string]string{"ip_pools": "shared"},
+		}, map[string]string{
+			"from":              "2018-11-05T22:00:00Z",
+			"to":                "2018-11-05T23:00:00Z",
+			"events":            "bounce,delay",
+			"campaigns":         "Summer Sale",
+			"templates":         "summer-sale,winter-sale",
+			"subaccounts":       "0,101",
+			"recipients":        "recipient@example.com",
+			"recipient_domains": "example.com",
+			"per_page":          "1000",
+			"ip_pools":          "shared",
+		}, nil},
+		{&sp.EventsFilter{Events: []string{"bounced"}}, nil, errors.New("Invalid event type [bounced]")},
+		{&sp.EventsFilter{From: from, To: from.Add(-time.Hour)}, nil, errors.New("EventsFilter From must be before To")},
+		{&sp.EventsFilter{PerPage: 10001}, nil, errors.New("EventsFilter PerPage must be between 0 and 10000")},
+	} {
+		params, err := test.in.Params()
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("EventsFilter.Params[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("EventsFilter.Params[%d] => err %q want %q", idx, err, test.err)
+		} else if !reflect.DeepEqual(params, test.params) {
+			t.Errorf("EventsFilter.Params[%d] => got/want:\n%v\n%v", idx, params, test.params)
+		}
+	}
+}
+
+func TestEventsSearch(t *testing.T) {
+	for idx, test := range []struct {
+		in     *sp.EventsFilter
+		status int
+		json   string
+		types  []string
+		next   string
+		err    error
+	}{
+		{&sp.EventsFilter{PerPage: 2}, 200, loadTestFile(t, "test/json/events_message_200-1.json"),
+			[]string{"injection", "bounce"}, "/api/v1/events/message?cursor=WycyMDE4LTExLTA1VDIyOjQ1OjM4LjAwMFonXQ==&per_page=2", nil},
+		{nil, 200, loadTestFile(t, "test/json/events_message_200-2.json"), []string{"click"}, "", nil},
+		{&sp.EventsFilter{Events: []string{"nope"}}, 200, `{}`, nil, "", errors.New("Invalid event type [nope]")},
+		{nil, 400, `{"errors":[{"message":"invalid params","description":"from must be before to"}]}`, nil, "",
+			errors.New(`[{"message":"invalid params","code":"","description":"from must be before to"}]`)},
+	} {
+		testSetup(t)
+		mockRestResponseBuilderFormat(t, "GET", test.status, sp.EventsSearchPathFormat, test.json)
+
+		page, _, err := testClient.EventsSearch(test.in)
+		testTeardown()
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("EventsSearch[%d] => err %q want %q", idx, err, test.err)
+			continue
+		} else if err != nil {
+			if err.Error() != test.err.Error() {
+				t.Errorf("EventsSearch[%d] => err %q want %q", idx, err, test.err)
+			}
+			continue
+		}
+
+		var types []string
+		for _, e := range page.Events {
+			types = append(types, e.EventType())
+		}
+		if !reflect.DeepEqual(types, test.types) {
+			t.Errorf("EventsSearch[%d] => types %v want %v", idx, types, test.types)
+		}
+		if page.NextPage != test.next {
+			t.Errorf("EventsSearch[%d] => next %q want %q", idx, page.NextPage, test.next)
+		}
+	}
+}
+
+func TestEventsSearchIter(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	pages := map[string]string{
+		"": loadTestFile(t, "test/json/events_message_200-1.json"),
+		"WycyMDE4LTExLTA1VDIyOjQ1OjM4LjAwMFonXQ==": loadTestFile(t, "test/json/events_message_200-2.json"),
+	}
+	var queries []string
+	path := fmt.Sprintf(sp.EventsSearchPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		queries = append(queries, r.URL.RawQuery)
+		body, ok := pages[r.URL.Query().Get("cursor")]
+		if !ok {
+			http.Error(w, `{"errors":[{"message":"bad cursor"}]}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(body))
+	})
+
+	it := testClient.EventsSearchIter(&sp.EventsFilter{Campaigns: []string{"Summer Sale"}, PerPage: 2})
+	evs, err := it.Collect(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(evs) != 3 || it.TotalCount() != 3 {
+		t.Fatalf("EventsSearchIter => %d events, total %d", len(evs), it.TotalCount())
+	}
+	if b, ok := evs[1].(*events.Bounce); !ok || b.BounceClass != "10" || b.SubaccountID != "101" {
+		t.Errorf("EventsSearchIter => %#v", evs[1])
+	}
+	if _, ok := evs[2].(*events.Click); !ok {
+		t.Errorf("EventsSearchIter => %#v", evs[2])
+	}
+	want := []string{
+		"campaigns=Summer+Sale&per_page=2",
+		"cursor=WycyMDE4LTExLTA1VDIyOjQ1OjM4LjAwMFonXQ==&per_page=2",
+	}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("EventsSearchIter => queries %v want %v", queries, want)
+	}
+
+	// An invalid filter is reported by the iterator.
+	it = testClient.EventsSearchIter(&sp.EventsFilter{Subaccounts: []int{1}, PerPage: -1})
+	if it.Next(context.Background()) {
+		t.Error("EventsSearchIter => Next true for invalid filter")
+	} else if err = it.Err(); err == nil || err.Error() != "EventsFilter PerPage must be between 0 and 10000" {
+		t.Errorf("EventsSearchIter => err %v", err)
+	}
+}
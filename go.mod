@@ -1,10 +1,22 @@
 module github.com/SparkPost/gosparkpost
 
-go 1.14
+go 1.23
 
 require (
 	github.com/buger/jsonparser v1.0.0
 	github.com/jhillyerd/enmime v0.8.0
 	github.com/kylelemons/godebug v1.1.0
 	github.com/pkg/errors v0.9.1
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require (
+	github.com/cention-sany/utf7 v0.0.0-20170124080048-26cad61bd60a // indirect
+	github.com/gogs/chardet v0.0.0-20150115103509-2404f7772561 // indirect
+	github.com/jaytaylor/html2text v0.0.0-20190408195923-01ec452cbe43 // indirect
+	github.com/mattn/go-runewidth v0.0.4 // indirect
+	github.com/olekukonko/tablewriter v0.0.1 // indirect
+	github.com/ssor/bom v0.0.0-20170718123548-6386211fdfcf // indirect
+	golang.org/x/net v0.0.0-20190724013045-ca1201d0de80 // indirect
+	golang.org/x/text v0.3.2 // indirect
 )
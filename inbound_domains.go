@@ -0,0 +1,138 @@
+package gosparkpost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// InboundDomainsPathFormat provides an easy way to fill out the path including the version.
+// https://developers.sparkpost.com/api/inbound-domains/
+// Inbound domains can't be updated; delete and re-create them instead.
+var InboundDomainsPathFormat = "/api/v%d/inbound-domains"
+
+// InboundDomain is a domain whose MX records point to SparkPost, so that mail sent to it
+// is relayed to a RelayWebhook.
+type InboundDomain struct {
+	Domain string `json:"domain"`
+}
+
+// InboundDomainCreate registers the inbound domain with the provided name.
+func (c *Client) InboundDomainCreate(domain string) (*Response, error) {
+	return c.InboundDomainCreateContext(context.Background(), domain)
+}
+
+// InboundDomainCreateContext is the same as InboundDomainCreate, and it allows the caller to provide a context.
+func (c *Client) InboundDomainCreateContext(ctx context.Context, domain string) (res *Response, err error) {
+	if domain == "" {
+		err = errors.New("Create called with blank domain")
+		return
+	}
+
+	// Marshaling a static type won't fail
+	jsonBytes, _ := json.Marshal(InboundDomain{Domain: domain})
+
+	path := fmt.Sprintf(InboundDomainsPathFormat, c.Config.ApiVersion)
+	res, err = c.HttpPost(ctx, c.Config.BaseUrl+path, jsonBytes)
+	if err != nil {
+		return
+	}
+
+	if _, err = res.AssertJson(); err != nil {
+		return
+	}
+
+	if err = res.ParseResponse(); err != nil {
+		return
+	}
+
+	err = res.HTTPError()
+	return
+}
+
+// InboundDomain looks up the inbound domain with the provided name.
+func (c *Client) InboundDomain(domain string) (*InboundDomain, *Response, error) {
+	return c.InboundDomainContext(context.Background(), domain)
+}
+
+// InboundDomainContext is the same as InboundDomain, and it allows the caller to provide a context.
+func (c *Client) InboundDomainContext(ctx context.Context, domain string) (d *InboundDomain, res *Response, err error) {
+	if domain == "" {
+		err = errors.New("InboundDomain called with blank domain")
+		return
+	}
+
+	path := fmt.Sprintf(InboundDomainsPathFormat, c.Config.ApiVersion)
+	res, err = c.HttpGet(ctx, fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(domain)))
+	if err != nil {
+		return
+	}
+
+	var body []byte
+	if body, err = res.AssertJson(); err != nil {
+		return
+	}
+
+	if Is2XX(res.HTTP.StatusCode) {
+		dlist := map[string]*InboundDomain{}
+		if err = json.Unmarshal(body, &dlist); err != nil {
+		} else if d = dlist["results"]; d == nil {
+			err = errors.New("Unexpected response to InboundDomain")
+		}
+	} else {
+		err = res.ParseResponse()
+		if err == nil {
+			err = res.HTTPError()
+		}
+	}
+
+	return
+}
+
+// InboundDomains returns all inbound domains.
+func (c *Client) InboundDomains() ([]InboundDomain, *Response, error) {
+	return c.InboundDomainsContext(context.Background())
+}
+
+// InboundDomainsContext is the same as InboundDomains, and it allows the caller to provide a context.
+func (c *Client) InboundDomainsContext(ctx context.Context) ([]InboundDomain, *Response, error) {
+	it := c.InboundDomainsIter()
+	domains, err := it.Collect(ctx)
+	return domains, it.Response(), err
+}
+
+// InboundDomainsIter returns an Iterator over all inbound domains.
+func (c *Client) InboundDomainsIter() *Iterator[InboundDomain] {
+	path := fmt.Sprintf(InboundDomainsPathFormat, c.Config.ApiVersion)
+	return listPageIterator[InboundDomain](c, path)
+}
+
+// InboundDomainDelete deletes the inbound domain with the provided name.
+func (c *Client) InboundDomainDelete(domain string) (*Response, error) {
+	return c.InboundDomainDeleteContext(context.Background(), domain)
+}
+
+// InboundDomainDeleteContext is the same as InboundDomainDelete, and it allows the caller to provide a context.
+func (c *Client) InboundDomainDeleteContext(ctx context.Context, domain string) (*Response, error) {
+	if domain == "" {
+		return nil, errors.New("Delete called with blank domain")
+	}
+
+	path := fmt.Sprintf(InboundDomainsPathFormat, c.Config.ApiVersion)
+	res, err := c.HttpDelete(ctx, fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(domain)))
+	if err != nil {
+		return res, err
+	}
+
+	// We get an empty response on success. If there are errors we get JSON.
+	if _, err = res.AssertJson(); err == nil {
+		if err = res.ParseResponse(); err != nil {
+			return res, err
+		}
+	}
+
+	return res, res.HTTPError()
+}
@@ -0,0 +1,117 @@
+package gosparkpost_test
+
+import (
+	"reflect"
+	"testing"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/pkg/errors"
+)
+
+func TestInboundDomainCreate(t *testing.T) {
+	for idx, test := range []struct {
+		in     string
+		err    error
+		status int
+		body   string
+		json   string
+	}{
+		{"", errors.New("Create called with blank domain"), 0, "", ""},
+		{"email.example.com", errors.New(`[{"message":"resource conflict","code":"1602","description":""}]`), 409,
+			`{"domain":"email.example.com"}`, `{"errors":[{"message":"resource conflict","code":"1602"}]}`},
+		{"email.example.com", nil, 200, `{"domain":"email.example.com"}`, `{"results":{}}`},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestRequestResponseBuilderFormat(t, "POST", test.status, sp.InboundDomainsPathFormat, test.body, test.json)
+
+		_, err := testClient.InboundDomainCreate(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("InboundDomainCreate[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("InboundDomainCreate[%d] => err %q want %q", idx, err, test.err)
+		}
+	}
+}
+
+func TestInboundDomain(t *testing.T) {
+	for idx, test := range []struct {
+		in     string
+		err    error
+		status int
+		json   string
+		out    *sp.InboundDomain
+	}{
+		{"", errors.New("InboundDomain called with blank domain"), 0, "", nil},
+		{"email.example.com", errors.New("Unexpected response to InboundDomain"), 200, `{"foo":{}}`, nil},
+		{"email.example.com", errors.New(`[{"message":"resource not found","code":"1600","description":""}]`), 404,
+			`{"errors":[{"message":"resource not found","code":"1600"}]}`, nil},
+		{"email.example.com", nil, 200, `{"results":{"domain":"email.example.com"}}`,
+			&sp.InboundDomain{Domain: "email.example.com"}},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "GET", test.status, sp.InboundDomainsPathFormat+"/email.example.com", test.json)
+
+		d, _, err := testClient.InboundDomain(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("InboundDomain[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("InboundDomain[%d] => err %q want %q", idx, err, test.err)
+		} else if test.out != nil && !reflect.DeepEqual(d, test.out) {
+			t.Errorf("InboundDomain[%d] => got/want:\n%+v\n%+v", idx, d, test.out)
+		}
+	}
+}
+
+func TestInboundDomains(t *testing.T) {
+	var res200 = loadTestFile(t, "test/json/inbound_domains_200.json")
+
+	for idx, test := range []struct {
+		err    error
+		status int
+		json   string
+		out    []sp.InboundDomain
+	}{
+		{errors.New(`[{"message":"error","code":"","description":""}]`), 400, `{"errors":[{"message":"error"}]}`, nil},
+		{nil, 200, res200, []sp.InboundDomain{{Domain: "email.example.com"}, {Domain: "email2.example.com"}}},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "GET", test.status, sp.InboundDomainsPathFormat, test.json)
+
+		domains, _, err := testClient.InboundDomains()
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("InboundDomains[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("InboundDomains[%d] => err %q want %q", idx, err, test.err)
+		} else if test.out != nil && !reflect.DeepEqual(domains, test.out) {
+			t.Errorf("InboundDomains[%d] => got/want:\n%+v\n%+v", idx, domains, test.out)
+		}
+	}
+}
+
+func TestInboundDomainDelete(t *testing.T) {
+	for idx, test := range []struct {
+		in     string
+		err    error
+		status int
+		json   string
+	}{
+		{"", errors.New("Delete called with blank domain"), 0, ""},
+		{"email.example.com", errors.New(`[{"message":"resource not found","code":"1600","description":""}]`), 404,
+			`{"errors":[{"message":"resource not found","code":"1600"}]}`},
+		{"email.example.com", nil, 204, ""},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "DELETE", test.status, sp.InboundDomainsPathFormat+"/email.example.com", test.json)
+
+		_, err := testClient.InboundDomainDelete(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("InboundDomainDelete[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("InboundDomainDelete[%d] => err %q want %q", idx, err, test.err)
+		}
+	}
+}
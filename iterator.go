@@ -0,0 +1,311 @@
+package gosparkpost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/SparkPost/gosparkpost/events"
+	"github.com/pkg/errors"
+)
+
+// page is one page of results from a list endpoint.
+type page[T any] struct {
+	items []T
+	total int
+	// next is the link to the next page, relative to Config.BaseUrl. It's empty on the last page.
+	next string
+}
+
+// fetchFunc requests the page at the specified url, relative to Config.BaseUrl.
+type fetchFunc[T any] func(ctx context.Context, href string) (*page[T], *Response, error)
+
+// Iterator returns items from a list endpoint one at a time, requesting more pages as they're needed.
+// Requests go through DoRequest as usual, so they honor the Client's rate limits, retry policy and
+// the context passed to Next or All. An Iterator isn't safe for concurrent use.
+//
+//	it := client.SuppressionSearchIter(map[string]string{"types": "transactional"})
+//	for it.Next(ctx) {
+//		fmt.Println(it.Item().Recipient)
+//	}
+//	if err := it.Err(); err != nil {
+//		// Save it.Cursor() to pick up where we left off later.
+//	}
+type Iterator[T any] struct {
+	fetch fetchFunc[T]
+
+	// current is the link to the page in items, and next is the one after it.
+	current string
+	next    string
+	// skip is the number of items to skip from the next page fetched, when resuming from a cursor.
+	skip int
+
+	items   []T
+	pos     int
+	item    T
+	total   int
+	started bool
+	err     error
+	res     *Response
+}
+
+// newIterator returns an Iterator that starts at the page linked to by first.
+func newIterator[T any](first string, fetch fetchFunc[T]) *Iterator[T] {
+	return &Iterator[T]{next: first, fetch: fetch}
+}
+
+// singlePageIterator returns an Iterator over an endpoint that returns all of its results in one response.
+func singlePageIterator[T any](list func(ctx context.Context) ([]T, *Response, error)) *Iterator[T] {
+	return newIterator("/", func(ctx context.Context, _ string) (*page[T], *Response, error) {
+		items, res, err := list(ctx)
+		if err != nil {
+			return nil, res, err
+		}
+		return &page[T]{items: items, total: len(items)}, res, nil
+	})
+}
+
+// Next advances to the next item, which is then available from Item.
+// It returns false when there are no more items, or when a request fails, in which case Err returns the error.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	for it.pos >= len(it.items) {
+		if it.err != nil || (it.started && it.next == "") {
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		p, res, err := it.fetch(ctx, it.next)
+		it.res = res
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.started = true
+		it.current, it.next = it.next, p.next
+		it.items, it.pos, it.total = p.items, it.skip, p.total
+		it.skip = 0
+	}
+	it.item = it.items[it.pos]
+	it.pos++
+	return true
+}
+
+// Item returns the item that the last successful call to Next advanced to.
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error, if any, that stopped the Iterator.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Response returns the response to the most recent request for a page.
+func (it *Iterator[T]) Response() *Response {
+	return it.res
+}
+
+// TotalCount returns the total number of items reported by the API, which is available once the first page
+// has been requested. Endpoints that don't report a total count return the number of items in the page.
+func (it *Iterator[T]) TotalCount() int {
+	return it.total
+}
+
+// Cursor returns an opaque position, from which Resume continues with the item after the one returned by Item.
+// It's empty once the last item has been returned.
+func (it *Iterator[T]) Cursor() string {
+	switch {
+	case it.pos < len(it.items):
+		return it.current + "#" + strconv.Itoa(it.pos)
+	case it.started && it.next == "":
+		return ""
+	case it.skip > 0:
+		return it.next + "#" + strconv.Itoa(it.skip)
+	}
+	return it.next
+}
+
+// Resume moves the Iterator to a position returned by Cursor, possibly from an Iterator in another process.
+// It must be called before Next, and the cursor must come from an Iterator over the same endpoint.
+func (it *Iterator[T]) Resume(cursor string) error {
+	if it.started {
+		return errors.New("can't resume an iterator that has already started")
+	}
+	href, skip := cursor, 0
+	if i := strings.LastIndex(cursor, "#"); i >= 0 {
+		n, err := strconv.Atoi(cursor[i+1:])
+		if err != nil || n < 0 {
+			return errors.Errorf("invalid cursor %q", cursor)
+		}
+		href, skip = cursor[:i], n
+	}
+	if !strings.HasPrefix(href, "/") {
+		return errors.Errorf("invalid cursor %q", cursor)
+	}
+	it.next, it.skip = href, skip
+	return nil
+}
+
+// All returns a sequence of the remaining items. If a request fails, the error is yielded with the zero value of T,
+// and the sequence ends.
+func (it *Iterator[T]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for it.Next(ctx) {
+			if !yield(it.Item(), nil) {
+				return
+			}
+		}
+		if err := it.Err(); err != nil {
+			var zero T
+			yield(zero, err)
+		}
+	}
+}
+
+// Collect returns all of the remaining items.
+func (it *Iterator[T]) Collect(ctx context.Context) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Item())
+	}
+	return items, it.Err()
+}
+
+// listLink is the format of the pagination links returned by list endpoints.
+type listLink struct {
+	Href string `json:"href"`
+	Rel  string `json:"rel"`
+}
+
+// nextLink returns the href of the link to the next page, if there is one.
+func nextLink(links []listLink) string {
+	for _, link := range links {
+		if link.Rel == "next" {
+			return link.Href
+		}
+	}
+	return ""
+}
+
+// listHref returns a link to the first page of an endpoint, relative to Config.BaseUrl.
+func listHref(path string, params map[string]string) string {
+	if len(params) == 0 {
+		return path
+	}
+	q := url.Values{}
+	for k, v := range params {
+		q.Add(k, v)
+	}
+	return path + "?" + q.Encode()
+}
+
+// listPageIterator returns an Iterator over an endpoint that returns results in the standard format,
+// which is an object containing results, total_count and links.
+func listPageIterator[T any](c *Client, first string) *Iterator[T] {
+	return newIterator(first, func(ctx context.Context, href string) (*page[T], *Response, error) {
+		var wrapper struct {
+			Results    []T        `json:"results"`
+			TotalCount int        `json:"total_count"`
+			Links      []listLink `json:"links"`
+		}
+		res, err := c.getPage(ctx, href, &wrapper)
+		if err != nil {
+			return nil, res, err
+		}
+		total := wrapper.TotalCount
+		if total == 0 {
+			total = len(wrapper.Results)
+		}
+		return &page[T]{items: wrapper.Results, total: total, next: nextLink(wrapper.Links)}, res, nil
+	})
+}
+
+// getPage requests a page of results and unmarshals it into v, returning an error for non-2xx responses.
+func (c *Client) getPage(ctx context.Context, href string, v interface{}) (*Response, error) {
+	res, err := c.HttpGet(ctx, c.Config.BaseUrl+href)
+	if err != nil {
+		return res, err
+	}
+	if _, err = res.ReadBody(); err != nil {
+		return res, err
+	}
+	if err = res.HTTPError(); err != nil {
+		return res, err
+	}
+	body, err := res.AssertJson()
+	if err != nil {
+		return res, err
+	}
+	if err = json.Unmarshal(body, v); err != nil {
+		return res, errors.Wrap(err, "unmarshaling response")
+	}
+	return res, nil
+}
+
+// SuppressionSearchIter returns an Iterator over the suppression list entries matching params,
+// which are the same as for SuppressionSearch. Cursor-based paging is used unless params specify otherwise.
+func (c *Client) SuppressionSearchIter(params map[string]string) *Iterator[*SuppressionEntry] {
+	q := map[string]string{"cursor": "initial"}
+	for k, v := range params {
+		q[k] = v
+	}
+	path := fmt.Sprintf(SuppressionListsPathFormat, c.Config.ApiVersion)
+	return listPageIterator[*SuppressionEntry](c, listHref(path, q))
+}
+
+// MessageEventsSearchIter returns an Iterator over the events matching params,
+// which are the same as EventsPage.Params for MessageEventsSearch.
+func (c *Client) MessageEventsSearchIter(params map[string]string) *Iterator[events.Event] {
+	path := fmt.Sprintf(MessageEventsPathFormat, c.Config.ApiVersion)
+	return eventsPageIterator(c, listHref(path, params))
+}
+
+// eventsPageIterator returns an Iterator over an endpoint that returns pages of events, decoded as for EventsPage.
+func eventsPageIterator(c *Client, first string) *Iterator[events.Event] {
+	return newIterator(first, func(ctx context.Context, href string) (*page[events.Event], *Response, error) {
+		ep := &EventsPage{}
+		res, err := c.getPage(ctx, href, ep)
+		if err != nil {
+			return nil, res, err
+		}
+		return &page[events.Event]{items: ep.Events, total: ep.TotalCount, next: ep.NextPage}, res, nil
+	})
+}
+
+// QueryMetricsIter returns an Iterator over the results of the metrics query described by m,
+// whose ExtraPath and Params are used as in QueryMetrics.
+func (c *Client) QueryMetricsIter(m *Metrics) *Iterator[MetricItem] {
+	path := fmt.Sprintf(MetricsPathFormat, c.Config.ApiVersion)
+	if m.ExtraPath != "" {
+		path = fmt.Sprintf("%s/%s", path, m.ExtraPath)
+	}
+	return listPageIterator[MetricItem](c, listHref(path, m.Params))
+}
+
+// TransmissionsIter returns an Iterator over the transmissions matching t, as for Transmissions.
+// The whole list is returned by a single request.
+func (c *Client) TransmissionsIter(t *Transmission) *Iterator[Transmission] {
+	return singlePageIterator(func(ctx context.Context) ([]Transmission, *Response, error) {
+		return c.TransmissionsContext(ctx, t)
+	})
+}
+
+// TemplatesIter returns an Iterator over the account's templates. The whole list is returned by a single request.
+func (c *Client) TemplatesIter() *Iterator[Template] {
+	return singlePageIterator(c.TemplatesContext)
+}
+
+// RecipientListsIter returns an Iterator over the account's recipient lists.
+// The whole list is returned by a single request.
+func (c *Client) RecipientListsIter() *Iterator[RecipientList] {
+	return singlePageIterator(c.RecipientListsContext)
+}
@@ -0,0 +1,215 @@
+package gosparkpost_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/SparkPost/gosparkpost/events"
+	"github.com/pkg/errors"
+)
+
+// pagedHandler serves items in pages of two, linked using the page parameter like the Message Events API.
+// Requests for the page numbered failPage get a 500.
+func pagedHandler(t *testing.T, path string, items []string, failPage int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		n, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if n < 1 {
+			n = 1
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		if n == failPage {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"errors":[{"message":"internal error"}]}`))
+			return
+		}
+		start, end := (n-1)*2, n*2
+		if end > len(items) {
+			end = len(items)
+		}
+		links := "[]"
+		if end < len(items) {
+			links = fmt.Sprintf(`[{"href":"%s?page=%d","rel":"next"}]`, path, n+1)
+		}
+		fmt.Fprintf(w, `{"results":[%s],"total_count":%d,"links":%s}`,
+			strings.Join(items[start:end], ","), len(items), links)
+	}
+}
+
+func suppressionItems(n int) []string {
+	var items []string
+	for i := 0; i < n; i++ {
+		items = append(items, fmt.Sprintf(`{"recipient":"%d@example.com","type":"transactional"}`, i))
+	}
+	return items
+}
+
+func TestIterator(t *testing.T) {
+	path := fmt.Sprintf(sp.SuppressionListsPathFormat, 1)
+	for idx, test := range []struct {
+		items    int
+		failPage int
+		want     int
+		err      string
+	}{
+		{0, 0, 0, ""},
+		{1, 0, 1, ""},
+		{5, 0, 5, ""},
+		{6, 0, 6, ""},
+		{5, 2, 2, `[{"message":"internal error","code":"","description":""}]`},
+	} {
+		testSetup(t)
+		testMux.HandleFunc(path, pagedHandler(t, path, suppressionItems(test.items), test.failPage))
+
+		it := testClient.SuppressionSearchIter(map[string]string{"per_page": "2"})
+		got, err := it.Collect(context.Background())
+		if err == nil && test.err != "" || err != nil && err.Error() != test.err {
+			t.Errorf("Iterator[%d] => err %v, want %q", idx, err, test.err)
+		}
+		if len(got) != test.want {
+			t.Errorf("Iterator[%d] => %d items, want %d", idx, len(got), test.want)
+		}
+		for i, e := range got {
+			if e.Recipient != fmt.Sprintf("%d@example.com", i) {
+				t.Errorf("Iterator[%d] => item %d is %q", idx, i, e.Recipient)
+			}
+		}
+		if test.failPage == 0 && it.TotalCount() != test.items {
+			t.Errorf("Iterator[%d] => TotalCount %d, want %d", idx, it.TotalCount(), test.items)
+		}
+		if test.err == "" && it.Cursor() != "" {
+			t.Errorf("Iterator[%d] => Cursor %q after last item", idx, it.Cursor())
+		}
+		testTeardown()
+	}
+}
+
+func TestIteratorResume(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	path := fmt.Sprintf(sp.SuppressionListsPathFormat, 1)
+	testMux.HandleFunc(path, pagedHandler(t, path, suppressionItems(7), 0))
+	ctx := context.Background()
+
+	// stop after each number of items, then resume from the cursor in a new iterator
+	for stop := 0; stop <= 7; stop++ {
+		it := testClient.SuppressionSearchIter(nil)
+		var got []string
+		for i := 0; i < stop && it.Next(ctx); i++ {
+			got = append(got, it.Item().Recipient)
+		}
+		cursor := it.Cursor()
+		if stop == 7 {
+			if cursor != "" {
+				t.Errorf("Resume[%d] => cursor %q, want empty", stop, cursor)
+			}
+			continue
+		}
+
+		resumed := testClient.SuppressionSearchIter(nil)
+		if err := resumed.Resume(cursor); err != nil {
+			t.Fatalf("Resume[%d] => %v", stop, err)
+		}
+		for e, err := range resumed.All(ctx) {
+			if err != nil {
+				t.Fatalf("Resume[%d] => %v", stop, err)
+			}
+			got = append(got, e.Recipient)
+		}
+		if len(got) != 7 {
+			t.Errorf("Resume[%d] (cursor %q) => %d items, want 7", stop, cursor, len(got))
+			continue
+		}
+		for i, r := range got {
+			if r != fmt.Sprintf("%d@example.com", i) {
+				t.Errorf("Resume[%d] (cursor %q) => item %d is %q", stop, cursor, i, r)
+			}
+		}
+	}
+
+	it := testClient.SuppressionSearchIter(nil)
+	for _, cursor := range []string{"", "example.com", "/page#x", "/page#-1"} {
+		if err := it.Resume(cursor); err == nil {
+			t.Errorf("Resume(%q) => no error", cursor)
+		}
+	}
+	it.Next(ctx)
+	if err := it.Resume(path); err == nil {
+		t.Errorf("Resume after Next => no error")
+	}
+}
+
+func TestIteratorBreak(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	path := fmt.Sprintf(sp.MessageEventsPathFormat, 1)
+	var items []string
+	for i := 0; i < 5; i++ {
+		items = append(items, fmt.Sprintf(`{"type":"delivery","rcpt_to":"%d@example.com"}`, i))
+	}
+	requests := 0
+	handler := pagedHandler(t, path, items, 0)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		handler(w, r)
+	})
+
+	n := 0
+	for e, err := range testClient.MessageEventsSearchIter(map[string]string{"events": "delivery"}).All(context.Background()) {
+		if err != nil {
+			t.Fatalf("MessageEventsSearchIter => %v", err)
+		}
+		if d, ok := e.(*events.Delivery); !ok || d.Recipient != fmt.Sprintf("%d@example.com", n) {
+			t.Errorf("MessageEventsSearchIter => %+v", e)
+		}
+		if n++; n == 3 {
+			break
+		}
+	}
+	if requests != 2 {
+		t.Errorf("MessageEventsSearchIter => %d requests, want 2", requests)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	it := testClient.MessageEventsSearchIter(nil)
+	if it.Next(ctx) || !errors.Is(it.Err(), context.Canceled) {
+		t.Errorf("MessageEventsSearchIter canceled => err %v", it.Err())
+	}
+}
+
+func TestIteratorSinglePage(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	testMux.HandleFunc(fmt.Sprintf(sp.TemplatesPathFormat, 1), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results":[{"id":"a"},{"id":"b"},{"id":"c"}]}`))
+	})
+	testMux.HandleFunc(fmt.Sprintf(sp.MetricsPathFormat, 1)+"/deliverability/domain", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("from") == "" {
+			t.Errorf("QueryMetricsIter => missing from parameter")
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		w.Write([]byte(`{"results":[{"domain":"example.com","count_injected":3}]}`))
+	})
+
+	it := testClient.TemplatesIter()
+	if err := it.Resume("/#1"); err != nil {
+		t.Fatalf("Resume => %v", err)
+	}
+	got, err := it.Collect(context.Background())
+	if err != nil || len(got) != 2 || got[0].ID != "b" || it.TotalCount() != 3 {
+		t.Errorf("TemplatesIter => %+v, %v", got, err)
+	}
+
+	metrics, err := testClient.QueryMetricsIter(&sp.Metrics{ExtraPath: "deliverability/domain",
+		Params: map[string]string{"from": "2017-01-01T00:00"}}).Collect(context.Background())
+	if err != nil || len(metrics) != 1 || metrics[0].Domain != "example.com" {
+		t.Errorf("QueryMetricsIter => %+v, %v", metrics, err)
+	}
+}
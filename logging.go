@@ -0,0 +1,145 @@
+package gosparkpost
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// Redacted replaces sensitive values in logs and verbose output.
+const Redacted = "[REDACTED]"
+
+// DefaultRedactFields lists the JSON keys redacted from logged bodies when LogOptions.RedactFields is nil.
+// Attachment and inline image contents (Attachment.B64Data) are sent using the "data" key.
+var DefaultRedactFields = []string{
+	"data",
+	"email_rfc822",
+	"password",
+	"client_secret",
+	"access_token",
+	"auth_token",
+}
+
+// LogOptions controls what is recorded by Client.Logger.
+type LogOptions struct {
+	// BodyLimit is the maximum number of bytes of each request and response body to log, at debug level.
+	// Bodies aren't logged when it's zero.
+	BodyLimit int
+	// RedactFields lists JSON object keys whose values are replaced with Redacted in logged bodies.
+	RedactFields []string
+}
+
+// redactFields returns the set of JSON keys to redact.
+func (o LogOptions) redactFields() map[string]bool {
+	fields := o.RedactFields
+	if fields == nil {
+		fields = DefaultRedactFields
+	}
+	set := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		set[f] = true
+	}
+	return set
+}
+
+// newRequestID returns a random identifier, shared by all attempts at a request in the logs.
+func newRequestID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// logAttempt emits a structured record describing one attempt at a request.
+// The API key is never logged, and bodies are only logged at debug level, redacted and truncated.
+func (c *Client) logAttempt(ctx context.Context, ares *Response, req *http.Request, data []byte,
+	requestID string, attempt int, latency time.Duration, err error) {
+	if c.Logger == nil {
+		return
+	}
+
+	level := slog.LevelInfo
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Duration("latency", latency),
+		slog.String("request_id", requestID),
+	}
+	if err != nil {
+		level = slog.LevelError
+		attrs = append(attrs, slog.String("error", err.Error()))
+	} else if ares.HTTP != nil {
+		attrs = append(attrs, slog.Int("status", ares.HTTP.StatusCode))
+		if !Is2XX(ares.HTTP.StatusCode) {
+			level = slog.LevelWarn
+		}
+	}
+
+	if c.LogOptions.BodyLimit > 0 && c.Logger.Enabled(ctx, slog.LevelDebug) {
+		redact := c.LogOptions.redactFields()
+		if data != nil {
+			attrs = append(attrs, slog.String("request_body", logBody(data, redact, c.LogOptions.BodyLimit)))
+		}
+		if err == nil && ares.HTTP != nil {
+			if body, readErr := ares.ReadBody(); readErr == nil {
+				attrs = append(attrs, slog.String("response_body", logBody(body, redact, c.LogOptions.BodyLimit)))
+			}
+		}
+	}
+
+	c.Logger.LogAttrs(ctx, level, "sparkpost request", attrs...)
+}
+
+// logBody redacts and truncates a request or response body for logging.
+func logBody(body []byte, redact map[string]bool, limit int) string {
+	body = redactBody(body, redact)
+	if len(body) > limit {
+		return string(body[:limit]) + "...(truncated)"
+	}
+	return string(body)
+}
+
+// redactBody replaces the values of matching object keys in a JSON body.
+// Bodies that aren't JSON are returned unchanged.
+func redactBody(body []byte, redact map[string]bool) []byte {
+	var parsed interface{}
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return body
+	}
+	// Marshaling a value we just unmarshaled won't fail
+	body, _ = json.Marshal(redactJSON(parsed, redact))
+	return body
+}
+
+// redactJSON replaces the values of matching object keys, at any depth.
+func redactJSON(v interface{}, redact map[string]bool) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, child := range val {
+			if redact[k] {
+				val[k] = Redacted
+			} else {
+				val[k] = redactJSON(child, redact)
+			}
+		}
+	case []interface{}:
+		for i, child := range val {
+			val[i] = redactJSON(child, redact)
+		}
+	}
+	return v
+}
+
+// redactRequestDump hides the value of the Authorization header in a request dump.
+func redactRequestDump(dump []byte, req *http.Request) []byte {
+	auth := req.Header.Get("Authorization")
+	if auth == "" {
+		return dump
+	}
+	return bytes.Replace(dump, []byte("Authorization: "+auth), []byte("Authorization: "+Redacted), 1)
+}
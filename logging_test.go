@@ -0,0 +1,106 @@
+package gosparkpost_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+)
+
+func TestLogger(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	var logs bytes.Buffer
+	testClient.Logger = slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	testClient.LogOptions = sp.LogOptions{BodyLimit: 200}
+	testClient.Config.ApiKey = "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef"
+	testClient.Config.Retry = &sp.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond, RetryPost: true}
+	defer func() {
+		testClient.Logger = nil
+		testClient.LogOptions = sp.LogOptions{}
+	}()
+
+	attempts := 0
+	mockPath := "/api/v1/transmissions"
+	testMux.HandleFunc(mockPath, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"errors":[{"message":"try again"}]}`))
+			return
+		}
+		w.Write([]byte(`{"results":{"id":"11111111111111111","padding":"` + strings.Repeat("x", 300) + `"}}`))
+	})
+
+	tx := &sp.Transmission{
+		Recipients: []string{"recipient@example.com"},
+		Content: sp.Content{
+			Subject:     "logging",
+			Text:        "hello",
+			From:        "from@example.com",
+			Attachments: []sp.Attachment{{MIMEType: "text/plain", Filename: "secret.txt", B64Data: "c2VjcmV0"}},
+		},
+	}
+	id, res, err := testClient.Send(tx)
+	if err != nil {
+		testFailVerbose(t, res, "Send returned error: %v", err)
+	} else if id != "11111111111111111" {
+		testFailVerbose(t, res, "Send returned id %q", id)
+	}
+
+	for _, secret := range []string{testClient.Config.ApiKey, "c2VjcmV0"} {
+		if strings.Contains(logs.String(), secret) {
+			t.Errorf("Logger => logs contain %q:\n%s", secret, logs.String())
+		}
+		if strings.Contains(res.Verbose["http_requestdump"], testClient.Config.ApiKey) {
+			t.Errorf("Verbose => request dump contains the API key")
+		}
+		if strings.Contains(res.Verbose["http_postdata"], secret) {
+			t.Errorf("Verbose => post data contains %q", secret)
+		}
+	}
+
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(logs.String()), "\n") {
+		record := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("Logger => invalid JSON log line %q: %v", line, err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Logger => %d records, want 2", len(records))
+	}
+
+	for idx, want := range []struct {
+		level  string
+		status float64
+	}{
+		{"WARN", 503},
+		{"INFO", 200},
+	} {
+		rec := records[idx]
+		if rec["level"] != want.level || rec["status"] != want.status || rec["attempt"] != float64(idx+1) {
+			t.Errorf("Logger[%d] => %v", idx, rec)
+		}
+		if rec["method"] != "POST" || rec["path"] != mockPath || rec["request_id"] != records[0]["request_id"] {
+			t.Errorf("Logger[%d] => %v", idx, rec)
+		}
+		if _, ok := rec["latency"]; !ok {
+			t.Errorf("Logger[%d] => latency missing", idx)
+		}
+		if reqBody, _ := rec["request_body"].(string); !strings.Contains(reqBody, `"data":"`+sp.Redacted+`"`) {
+			t.Errorf("Logger[%d] => request body not redacted: %s", idx, reqBody)
+		}
+	}
+	if resBody, _ := records[1]["response_body"].(string); !strings.HasSuffix(resBody, "...(truncated)") || len(resBody) > 220 {
+		t.Errorf("Logger => response body not truncated: %s", resBody)
+	}
+}
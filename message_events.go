@@ -94,17 +94,30 @@ func (page *EventsPage) UnmarshalJSON(data []byte) error {
 	var resultsWrapper struct {
 		RawEvents  []json.RawMessage `json:"results"`
 		TotalCount int               `json:"total_count,omitempty"`
-		Links      []struct {
-			Href string `json:"href"`
-			Rel  string `json:"rel"`
-		} `json:"links,omitempty"`
-		Errors []interface{} `json:"errors,omitempty"`
+		Links      json.RawMessage   `json:"links,omitempty"`
+		Errors     []interface{}     `json:"errors,omitempty"`
 	}
 	err := json.Unmarshal(data, &resultsWrapper)
 	if err != nil {
 		return err
 	}
 
+	// Message Events returns a list of links, and the Events API an object keyed by rel.
+	var links []listLink
+	if len(resultsWrapper.Links) > 0 && resultsWrapper.Links[0] == '{' {
+		var byRel map[string]string
+		if err = json.Unmarshal(resultsWrapper.Links, &byRel); err != nil {
+			return err
+		}
+		for rel, href := range byRel {
+			links = append(links, listLink{Href: href, Rel: rel})
+		}
+	} else if len(resultsWrapper.Links) > 0 {
+		if err = json.Unmarshal(resultsWrapper.Links, &links); err != nil {
+			return err
+		}
+	}
+
 	page.Events, err = events.ParseRawJSONEvents(resultsWrapper.RawEvents)
 	if err != nil {
 		return err
@@ -113,7 +126,7 @@ func (page *EventsPage) UnmarshalJSON(data []byte) error {
 	page.Errors = resultsWrapper.Errors
 	page.TotalCount = resultsWrapper.TotalCount
 
-	for _, link := range resultsWrapper.Links {
+	for _, link := range links {
 		switch link.Rel {
 		case "next":
 			page.NextPage = link.Href
@@ -0,0 +1,229 @@
+package gosparkpost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/SparkPost/gosparkpost/events"
+	"github.com/pkg/errors"
+)
+
+// Defaults for MessageEventsExport.
+var (
+	DefaultExportThreshold   = 10000
+	DefaultExportConcurrency = 4
+)
+
+// MessageEventsExport describes a complete export of the events in a time range, for ExportMessageEvents.
+type MessageEventsExport struct {
+	// Query selects the events to export. From and To are required.
+	Query MessageEventsQuery
+	// Threshold is the most events fetched from a single window; windows with more are split in half,
+	// down to a minute, which is the finest the API supports. DefaultExportThreshold if zero.
+	Threshold int
+	// Concurrency is the most requests made at once, and the most fetched windows waiting to be written.
+	// DefaultExportConcurrency if zero.
+	Concurrency int
+}
+
+// exportWindow is part of an export's time range, from From up to To.
+type exportWindow struct {
+	from, to time.Time
+	count    int
+}
+
+// ExportMessageEvents writes every event in the export's time range to w, as newline-delimited JSON,
+// in timestamp order. The range is split into windows small enough to be paged through completely,
+// which are fetched in parallel, and events returned more than once are written once.
+// At most Concurrency windows are fetched ahead of the one being written.
+// It returns the number of events written.
+func (c *Client) ExportMessageEvents(ctx context.Context, export *MessageEventsExport, w io.Writer) (int, error) {
+	threshold, concurrency := export.Threshold, export.Concurrency
+	if threshold <= 0 {
+		threshold = DefaultExportThreshold
+	}
+	if concurrency <= 0 {
+		concurrency = DefaultExportConcurrency
+	}
+	q := export.Query
+	if q.From.IsZero() || q.To.IsZero() {
+		return 0, errors.New("MessageEventsExport requires Query.From and Query.To")
+	}
+	q.From, q.To = q.From.UTC().Truncate(time.Minute), q.To.UTC().Truncate(time.Minute)
+	q.PerPage = 0
+	if _, err := q.Params(); err != nil {
+		return 0, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	x := &exporter{c: c, query: q, threshold: threshold, sem: make(chan struct{}, concurrency)}
+
+	windows, err := x.plan(ctx, q.From, q.To)
+	if err != nil {
+		return 0, err
+	}
+
+	// Fetch windows in parallel, and write each one as soon as the windows before it are written.
+	// A window is only fetched once there's room for it, so at most concurrency windows are held in memory.
+	results := make([]chan []events.Event, len(windows))
+	for i := range results {
+		results[i] = make(chan []events.Event, 1)
+	}
+	errs := make(chan error, 1)
+	room := make(chan struct{}, concurrency)
+	go func() {
+		for i, win := range windows {
+			select {
+			case room <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			go func(win exportWindow, out chan<- []events.Event) {
+				evs, err := x.fetch(ctx, win)
+				if err != nil {
+					select {
+					case errs <- err:
+					default:
+					}
+					cancel()
+					return
+				}
+				out <- evs
+			}(win, results[i])
+		}
+	}()
+
+	written := 0
+	enc := json.NewEncoder(w)
+	seen := map[string]time.Time{}
+	for i, result := range results {
+		var evs []events.Event
+		select {
+		case evs = <-result:
+		case err = <-errs:
+			return written, err
+		case <-ctx.Done():
+			// A failed fetch reports its error before canceling.
+			select {
+			case err = <-errs:
+			default:
+				err = ctx.Err()
+			}
+			return written, err
+		}
+
+		// Duplicates come from pages shifting within a window, or events on the boundary between windows.
+		// Events from before this window can't be returned again, so they're forgotten.
+		for id, at := range seen {
+			if at.Before(windows[i].from) {
+				delete(seen, id)
+			}
+		}
+		for _, e := range evs {
+			id, _ := events.Field(e, "event_id")
+			if id != "" {
+				if _, dup := seen[id]; dup {
+					continue
+				}
+				seen[id] = eventTime(e)
+			}
+			if err = encodeEvent(enc, e); err != nil {
+				return written, err
+			}
+			written++
+		}
+		<-room
+	}
+	return written, nil
+}
+
+// exporter holds the state shared by the requests for an export.
+type exporter struct {
+	c         *Client
+	query     MessageEventsQuery
+	threshold int
+	sem       chan struct{}
+}
+
+func (x *exporter) params(from, to time.Time, perPage int) map[string]string {
+	q := x.query
+	q.From, q.To, q.PerPage = from, to, perPage
+	params, _ := q.Params()
+	return params
+}
+
+// plan returns windows covering from up to to, each with at most threshold events, in time order.
+func (x *exporter) plan(ctx context.Context, from, to time.Time) ([]exportWindow, error) {
+	x.sem <- struct{}{}
+	page := &EventsPage{}
+	path := fmt.Sprintf(MessageEventsPathFormat, x.c.Config.ApiVersion)
+	_, err := x.c.getPage(ctx, listHref(path, x.params(from, to, 1)), page)
+	<-x.sem
+	if err != nil {
+		return nil, err
+	}
+
+	if page.TotalCount <= x.threshold {
+		if page.TotalCount == 0 {
+			return nil, nil
+		}
+		return []exportWindow{{from, to, page.TotalCount}}, nil
+	}
+	if to.Sub(from) <= time.Minute {
+		return nil, errors.Errorf("%d events from %s to %s is more than the threshold of %d, and the window can't be split",
+			page.TotalCount, from.Format(messageEventsTimeFormat), to.Format(messageEventsTimeFormat), x.threshold)
+	}
+
+	mid := from.Add(to.Sub(from) / 2).Truncate(time.Minute)
+	if !mid.After(from) {
+		mid = from.Add(time.Minute)
+	}
+	var early, late []exportWindow
+	var earlyErr, lateErr error
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		early, earlyErr = x.plan(ctx, from, mid)
+	}()
+	late, lateErr = x.plan(ctx, mid, to)
+	wg.Wait()
+	if earlyErr != nil {
+		return nil, earlyErr
+	} else if lateErr != nil {
+		return nil, lateErr
+	}
+	return append(early, late...), nil
+}
+
+// fetch returns all of the events in a window, in timestamp order.
+func (x *exporter) fetch(ctx context.Context, win exportWindow) ([]events.Event, error) {
+	x.sem <- struct{}{}
+	defer func() { <-x.sem }()
+
+	perPage := win.count
+	if perPage > MessageEventsMaxPerPage {
+		perPage = MessageEventsMaxPerPage
+	}
+	params := x.params(win.from, win.to, perPage)
+	evs, err := x.c.MessageEventsSearchIter(params).Collect(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fetching events from %s", params["from"])
+	}
+	sort.SliceStable(evs, func(i, j int) bool { return eventTime(evs[i]).Before(eventTime(evs[j])) })
+	return evs, nil
+}
+
+// encodeEvent writes an event as a line of JSON. Unknown events are written as they were received.
+func encodeEvent(enc *json.Encoder, e events.Event) error {
+	if u, ok := e.(*events.Unknown); ok {
+		return enc.Encode(u.RawJSON)
+	}
+	return enc.Encode(e)
+}
@@ -0,0 +1,222 @@
+package gosparkpost_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+)
+
+// exportServer serves message events from a fixed list, filtered by from and to (inclusive, to the minute),
+// newest first, paged by per_page and page. If onRequest is set, it's called with each request's query.
+func exportServer(t *testing.T, times []time.Time, onRequest func(url.Values)) *int {
+	var mu sync.Mutex
+	requests := 0
+	path := fmt.Sprintf(sp.MessageEventsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests++
+		mu.Unlock()
+		q := r.URL.Query()
+		if onRequest != nil {
+			onRequest(q)
+		}
+		from, err := time.Parse("2006-01-02T15:04", q.Get("from"))
+		if err != nil {
+			t.Errorf("from %q: %v", q.Get("from"), err)
+		}
+		to, err := time.Parse("2006-01-02T15:04", q.Get("to"))
+		if err != nil {
+			t.Errorf("to %q: %v", q.Get("to"), err)
+		}
+		perPage, _ := strconv.Atoi(q.Get("per_page"))
+		pageNum, _ := strconv.Atoi(q.Get("page"))
+		if pageNum == 0 {
+			pageNum = 1
+		}
+
+		var matched []string
+		for i := len(times) - 1; i >= 0; i-- {
+			if ts := times[i]; !ts.Before(from) && !ts.After(to) {
+				matched = append(matched, fmt.Sprintf(`{"type":"delivery","event_id":"%d","timestamp":%q}`,
+					i, ts.Format("2006-01-02T15:04:05.000-07:00")))
+			}
+		}
+		start, end := (pageNum-1)*perPage, pageNum*perPage
+		if start > len(matched) {
+			start = len(matched)
+		}
+		if end > len(matched) {
+			end = len(matched)
+		}
+		links := "[]"
+		if end < len(matched) {
+			q.Set("page", strconv.Itoa(pageNum+1))
+			links = fmt.Sprintf(`[{"href":%q,"rel":"next"}]`, r.URL.Path+"?"+q.Encode())
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		fmt.Fprintf(w, `{"results":[%s],"total_count":%d,"links":%s}`, strings.Join(matched[start:end], ","), len(matched), links)
+	})
+	return &requests
+}
+
+func TestExportMessageEvents(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// 60 events over an hour, with a burst in the first minute, and one on the hour.
+	start := time.Date(2016, 4, 18, 0, 0, 0, 0, time.UTC)
+	var times []time.Time
+	for i := 0; i < 8; i++ {
+		times = append(times, start.Add(time.Duration(i)*time.Second))
+	}
+	for len(times) < 59 {
+		times = append(times, start.Add(time.Duration(len(times))*time.Minute-30*time.Second))
+	}
+	times = append(times, start.Add(time.Hour))
+	requests := exportServer(t, times, nil)
+
+	var out strings.Builder
+	n, err := testClient.ExportMessageEvents(context.Background(), &sp.MessageEventsExport{
+		Query:       sp.MessageEventsQuery{From: start, To: start.Add(time.Hour), Events: []string{"delivery"}},
+		Threshold:   10,
+		Concurrency: 3,
+	}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != len(times) {
+		t.Errorf("ExportMessageEvents => %d events want %d", n, len(times))
+	}
+
+	// Every event is written once, in order.
+	scanner := bufio.NewScanner(strings.NewReader(out.String()))
+	lines := 0
+	for scanner.Scan() {
+		var e struct {
+			EventID   string `json:"event_id"`
+			Timestamp int64  `json:"timestamp"`
+		}
+		if err = json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			t.Fatalf("ExportMessageEvents => line %d: %v", lines, err)
+		}
+		if want := strconv.Itoa(lines); e.EventID != want {
+			t.Errorf("ExportMessageEvents => line %d has event %s want %s", lines, e.EventID, want)
+		}
+		if e.Timestamp != times[lines].Unix() {
+			t.Errorf("ExportMessageEvents => line %d at %d want %d", lines, e.Timestamp, times[lines].Unix())
+		}
+		lines++
+	}
+	if lines != len(times) {
+		t.Errorf("ExportMessageEvents => %d lines want %d", lines, len(times))
+	}
+	if *requests < 8 {
+		t.Errorf("ExportMessageEvents => only %d requests; the range wasn't split", *requests)
+	}
+}
+
+// blockedWriter blocks its first Write until release is closed.
+type blockedWriter struct {
+	strings.Builder
+	blocked chan struct{}
+	release chan struct{}
+	once    sync.Once
+}
+
+func (w *blockedWriter) Write(p []byte) (int, error) {
+	w.once.Do(func() {
+		close(w.blocked)
+		<-w.release
+	})
+	return w.Builder.Write(p)
+}
+
+func TestExportMessageEventsBounded(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// Two events a minute for half an hour, so that every window has more than one event,
+	// and only the requests fetching them have per_page above 1.
+	start := time.Date(2016, 4, 18, 0, 0, 0, 0, time.UTC)
+	var times []time.Time
+	for i := 0; i < 60; i++ {
+		times = append(times, start.Add(time.Duration(i)*30*time.Second))
+	}
+	var mu sync.Mutex
+	fetched := map[string]bool{}
+	exportServer(t, times, func(q url.Values) {
+		if q.Get("per_page") != "1" && q.Get("page") == "" {
+			mu.Lock()
+			fetched[q.Get("from")] = true
+			mu.Unlock()
+		}
+	})
+
+	out := &blockedWriter{blocked: make(chan struct{}), release: make(chan struct{})}
+	done := make(chan error)
+	go func() {
+		_, err := testClient.ExportMessageEvents(context.Background(), &sp.MessageEventsExport{
+			Query:       sp.MessageEventsQuery{From: start, To: start.Add(30 * time.Minute)},
+			Threshold:   10,
+			Concurrency: 2,
+		}, out)
+		done <- err
+	}()
+
+	// While the first window is being written, only one more is fetched.
+	<-out.blocked
+	time.Sleep(50 * time.Millisecond)
+	mu.Lock()
+	ahead := len(fetched)
+	mu.Unlock()
+	close(out.release)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	if ahead > 2 {
+		t.Errorf("ExportMessageEvents => %d windows fetched while writing the first, want at most 2", ahead)
+	}
+	if lines := strings.Count(out.String(), "\n"); lines != len(times) || len(fetched) <= 2 {
+		t.Errorf("ExportMessageEvents => %d lines from %d windows, want %d lines", lines, len(fetched), len(times))
+	}
+}
+
+func TestExportMessageEventsErrors(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	start := time.Date(2016, 4, 18, 0, 0, 0, 0, time.UTC)
+	var times []time.Time
+	for i := 0; i < 20; i++ {
+		times = append(times, start.Add(time.Duration(i)*time.Second))
+	}
+	exportServer(t, times, nil)
+
+	for idx, test := range []struct {
+		in  *sp.MessageEventsExport
+		err string
+	}{
+		{&sp.MessageEventsExport{Query: sp.MessageEventsQuery{From: start}},
+			"MessageEventsExport requires Query.From and Query.To"},
+		{&sp.MessageEventsExport{Query: sp.MessageEventsQuery{From: start, To: start.Add(time.Hour), Events: []string{"deliveries"}}},
+			"Invalid event type [deliveries]"},
+		{&sp.MessageEventsExport{Query: sp.MessageEventsQuery{From: start, To: start.Add(time.Hour)}, Threshold: 10},
+			"20 events from 2016-04-18T00:00 to 2016-04-18T00:01 is more than the threshold of 10, and the window can't be split"},
+	} {
+		var out strings.Builder
+		_, err := testClient.ExportMessageEvents(context.Background(), test.in, &out)
+		if err == nil || err.Error() != test.err {
+			t.Errorf("ExportMessageEvents[%d] => err %v want %q", idx, err, test.err)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package gosparkpost
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/SparkPost/gosparkpost/events"
+	"github.com/pkg/errors"
+)
+
+// Defaults for EventsFollow.
+var (
+	DefaultFollowInterval = 30 * time.Second
+	DefaultFollowWindow   = 10 * time.Minute
+)
+
+// followSaveTimeout limits how long FollowMessageEvents spends saving its checkpoint once ctx is done.
+const followSaveTimeout = 5 * time.Second
+
+// messageEventsTimeFormat is the format of the "from" and "to" message events parameters.
+const messageEventsTimeFormat = "2006-01-02T15:04"
+
+// EventsFollow describes the events for FollowMessageEvents to stream.
+type EventsFollow struct {
+	// Params filter events, as for MessageEventsSearch. The "from" and "to" params are managed by the follower.
+	Params map[string]string
+	// Interval is how long to wait between requests; DefaultFollowInterval if zero.
+	Interval time.Duration
+	// Window is how far back each request looks, to pick up events that are searchable some time after they happen.
+	// Events that show up later than this are missed. DefaultFollowWindow if zero.
+	Window time.Duration
+	// Since is when to start following from, if there's no checkpoint. The default is one Window ago.
+	Since time.Time
+	// Checkpoint, if set, saves progress after each request and when ctx is done, and is loaded on startup,
+	// so that a restarted follower resumes where the last one left off.
+	Checkpoint FollowCheckpoint
+	// OnError is called with errors from requests and checkpoints, which are retried on the next interval.
+	OnError func(error)
+}
+
+// FollowState is the progress of FollowMessageEvents: the start of the current window,
+// and the IDs of events in the window that have already been sent, with their timestamps.
+type FollowState struct {
+	Since time.Time            `json:"since"`
+	Seen  map[string]time.Time `json:"seen"`
+}
+
+// FollowCheckpoint stores FollowState between runs.
+type FollowCheckpoint interface {
+	// Load returns the saved state, or nil if there isn't any.
+	Load(ctx context.Context) (*FollowState, error)
+	// Save replaces the saved state.
+	Save(ctx context.Context, state *FollowState) error
+}
+
+// FileCheckpoint is a FollowCheckpoint that keeps state in a JSON file.
+type FileCheckpoint struct {
+	Path string
+}
+
+// Load implements FollowCheckpoint.
+func (f FileCheckpoint) Load(ctx context.Context) (*FollowState, error) {
+	data, err := os.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	state := &FollowState{}
+	if err = json.Unmarshal(data, state); err != nil {
+		return nil, errors.Wrap(err, "parsing checkpoint")
+	}
+	return state, nil
+}
+
+// Save implements FollowCheckpoint. The file is replaced atomically, so an interrupted save can't corrupt it.
+func (f FileCheckpoint) Save(ctx context.Context, state *FollowState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(f.Path), filepath.Base(f.Path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err == nil {
+		err = tmp.Close()
+	} else {
+		tmp.Close()
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), f.Path)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return err
+}
+
+// FollowMessageEvents polls the Message Events API for events matching follow, like `tail -f`.
+// Each request searches a window ending now, and events already sent are skipped by event ID,
+// so each event is sent once, in timestamp order within each request.
+// The returned channel is closed when ctx is done.
+func (c *Client) FollowMessageEvents(ctx context.Context, follow EventsFollow) (<-chan events.Event, error) {
+	if follow.Interval <= 0 {
+		follow.Interval = DefaultFollowInterval
+	}
+	if follow.Window <= 0 {
+		follow.Window = DefaultFollowWindow
+	}
+
+	var state *FollowState
+	var err error
+	if follow.Checkpoint != nil {
+		if state, err = follow.Checkpoint.Load(ctx); err != nil {
+			return nil, errors.Wrap(err, "loading checkpoint")
+		}
+	}
+	if state == nil {
+		state = &FollowState{Since: follow.Since}
+		if state.Since.IsZero() {
+			state.Since = time.Now().Add(-follow.Window)
+		}
+	}
+	if state.Seen == nil {
+		state.Seen = map[string]time.Time{}
+	}
+
+	out := make(chan events.Event)
+	go func() {
+		defer close(out)
+		for {
+			if err := c.followOnce(ctx, &follow, state, out); err != nil {
+				// Errors caused by ctx being done aren't worth reporting, but a failed checkpoint save is.
+				if follow.OnError != nil && (ctx.Err() == nil || !errors.Is(err, ctx.Err())) {
+					follow.OnError(err)
+				}
+				if ctx.Err() != nil {
+					return
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(follow.Interval):
+			}
+		}
+	}()
+	return out, nil
+}
+
+// followOnce sends the events in the current window that haven't been seen, then slides the window forward.
+func (c *Client) followOnce(ctx context.Context, follow *EventsFollow, state *FollowState, out chan<- events.Event) error {
+	now := time.Now()
+	from := state.Since.UTC().Truncate(time.Minute)
+	params := map[string]string{}
+	for k, v := range follow.Params {
+		params[k] = v
+	}
+	params["from"] = from.Format(messageEventsTimeFormat)
+	delete(params, "to")
+
+	type timedEvent struct {
+		event events.Event
+		id    string
+		at    time.Time
+	}
+	var found []timedEvent
+	for e, err := range c.MessageEventsSearchIter(params).All(ctx) {
+		if err != nil {
+			return err
+		}
+		id, _ := events.Field(e, "event_id")
+		at := eventTime(e)
+		if _, seen := state.Seen[id]; (id != "" && seen) || (!at.IsZero() && at.Before(from)) {
+			continue
+		}
+		found = append(found, timedEvent{e, id, at})
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].at.Before(found[j].at) })
+
+	for _, te := range found {
+		select {
+		case <-ctx.Done():
+			// Record the events sent so far, so that a follower resuming from the checkpoint doesn't send them again.
+			saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), followSaveTimeout)
+			defer cancel()
+			if err := saveFollowState(saveCtx, follow, state); err != nil {
+				return err
+			}
+			return ctx.Err()
+		case out <- te.event:
+		}
+		if te.id != "" {
+			if te.at.IsZero() {
+				te.at = now
+			}
+			state.Seen[te.id] = te.at
+		}
+	}
+
+	// Slide the window forward, forgetting events that can no longer be returned.
+	if since := now.Add(-follow.Window); since.After(state.Since) {
+		state.Since = since
+	}
+	cutoff := state.Since.UTC().Truncate(time.Minute)
+	for id, at := range state.Seen {
+		if at.Before(cutoff) {
+			delete(state.Seen, id)
+		}
+	}
+
+	return saveFollowState(ctx, follow, state)
+}
+
+// saveFollowState saves state to the follower's checkpoint, if it has one.
+func saveFollowState(ctx context.Context, follow *EventsFollow, state *FollowState) error {
+	if follow.Checkpoint == nil {
+		return nil
+	}
+	return errors.Wrap(follow.Checkpoint.Save(ctx, state), "saving checkpoint")
+}
+
+// eventTime returns the time an event happened, or the zero time if it doesn't have one.
+func eventTime(e events.Event) time.Time {
+	if u, ok := e.(*events.Unknown); ok {
+		var fields struct {
+			Timestamp events.Timestamp `json:"timestamp"`
+		}
+		if json.Unmarshal(u.RawJSON, &fields) != nil {
+			return time.Time{}
+		}
+		return time.Time(fields.Timestamp)
+	}
+
+	v := reflect.ValueOf(e)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return time.Time{}
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return time.Time{}
+	}
+	if f := v.FieldByName("Timestamp"); f.IsValid() {
+		if ts, ok := f.Interface().(events.Timestamp); ok {
+			return time.Time(ts)
+		}
+	}
+	return time.Time{}
+}
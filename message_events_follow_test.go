@@ -0,0 +1,242 @@
+package gosparkpost_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/SparkPost/gosparkpost/events"
+)
+
+// followEvent returns a delivery event that happened ago before now.
+func followEvent(id string, ago time.Duration) string {
+	ts := time.Now().Add(-ago).Format("2006-01-02T15:04:05.000-07:00")
+	return fmt.Sprintf(`{"type":"delivery","event_id":%q,"timestamp":%q}`, id, ts)
+}
+
+// followServer responds to each message events request with the next set of events,
+// repeating the last set once they run out.
+func followServer(t *testing.T, responses ...[]string) *[]string {
+	var mu sync.Mutex
+	var froms []string
+	path := fmt.Sprintf(sp.MessageEventsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		mu.Lock()
+		idx := len(froms)
+		froms = append(froms, r.URL.Query().Get("from")+" "+r.URL.Query().Get("events"))
+		mu.Unlock()
+		if idx >= len(responses) {
+			idx = len(responses) - 1
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		fmt.Fprintf(w, `{"results":[%s],"total_count":%d,"links":[]}`,
+			strings.Join(responses[idx], ","), len(responses[idx]))
+	})
+	return &froms
+}
+
+func receiveIDs(t *testing.T, ch <-chan events.Event, n int) []string {
+	t.Helper()
+	var ids []string
+	for len(ids) < n {
+		select {
+		case e, ok := <-ch:
+			if !ok {
+				t.Fatalf("channel closed after %v", ids)
+			}
+			id, _ := events.Field(e, "event_id")
+			ids = append(ids, id)
+		case <-time.After(2 * time.Second):
+			t.Fatalf("timed out after %v", ids)
+		}
+	}
+	return ids
+}
+
+func TestFollowMessageEvents(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	a, b, c := followEvent("1", 3*time.Minute), followEvent("2", 2*time.Minute), followEvent("3", time.Minute)
+	old := followEvent("0", 20*time.Minute)
+	// Results are newest first, and events already sent show up again while they're in the window.
+	froms := followServer(t, []string{b, a, old}, []string{c, b, a}, []string{c, b})
+
+	checkpoint := sp.FileCheckpoint{Path: filepath.Join(t.TempDir(), "follow.json")}
+	var errs []error
+	start := time.Now()
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := testClient.FollowMessageEvents(ctx, sp.EventsFollow{
+		Params:     map[string]string{"events": "delivery"},
+		Interval:   10 * time.Millisecond,
+		Window:     10 * time.Minute,
+		Checkpoint: checkpoint,
+		OnError:    func(err error) { errs = append(errs, err) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ids := strings.Join(receiveIDs(t, ch, 3), ","); ids != "1,2,3" {
+		t.Errorf("FollowMessageEvents => %s want 1,2,3", ids)
+	}
+	// Give it time for a few more polls, which shouldn't send anything.
+	time.Sleep(50 * time.Millisecond)
+	select {
+	case e := <-ch:
+		t.Errorf("FollowMessageEvents => unexpected %v", e)
+	default:
+	}
+	cancel()
+	for range ch {
+	}
+	if len(errs) > 0 {
+		t.Errorf("FollowMessageEvents => errors %v", errs)
+	}
+
+	// The window starts one Window before the first request, in the minute format the API uses.
+	if len(*froms) == 0 {
+		t.Fatal("FollowMessageEvents => no requests")
+	}
+	from := (*froms)[0]
+	earliest := start.Add(-10*time.Minute).UTC().Format("2006-01-02T15:04") + " delivery"
+	latest := time.Now().Add(-10*time.Minute).UTC().Format("2006-01-02T15:04") + " delivery"
+	if from != earliest && from != latest {
+		t.Errorf("FollowMessageEvents => params %q want %q", from, earliest)
+	}
+
+	state, err := checkpoint.Load(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	} else if state == nil || len(state.Seen) != 3 {
+		t.Fatalf("Checkpoint => %+v", state)
+	}
+}
+
+func TestFollowMessageEventsResume(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	a, b, c := followEvent("1", 3*time.Minute), followEvent("2", 2*time.Minute), followEvent("3", time.Minute)
+	followServer(t, []string{c, b, a})
+
+	// A previous run sent the first two events.
+	checkpoint := sp.FileCheckpoint{Path: filepath.Join(t.TempDir(), "follow.json")}
+	err := checkpoint.Save(context.Background(), &sp.FollowState{
+		Since: time.Now().Add(-5 * time.Minute),
+		Seen:  map[string]time.Time{"1": time.Now().Add(-3 * time.Minute), "2": time.Now().Add(-2 * time.Minute)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := testClient.FollowMessageEvents(ctx, sp.EventsFollow{
+		Interval:   10 * time.Millisecond,
+		Checkpoint: checkpoint,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := strings.Join(receiveIDs(t, ch, 1), ","); ids != "3" {
+		t.Errorf("FollowMessageEvents => %s want 3", ids)
+	}
+}
+
+func TestFollowMessageEventsCancel(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	a, b, c := followEvent("1", 3*time.Minute), followEvent("2", 2*time.Minute), followEvent("3", time.Minute)
+	followServer(t, []string{c, b, a})
+	checkpoint := sp.FileCheckpoint{Path: filepath.Join(t.TempDir(), "follow.json")}
+	follow := sp.EventsFollow{Interval: 10 * time.Millisecond, Checkpoint: checkpoint}
+
+	// The first follower is stopped partway through the batch.
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := testClient.FollowMessageEvents(ctx, follow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sent := receiveIDs(t, ch, 2)
+	cancel()
+	for e := range ch {
+		id, _ := events.Field(e, "event_id")
+		sent = append(sent, id)
+	}
+
+	// The second resumes from the checkpoint, and only sends what the first didn't.
+	ctx, cancel = context.WithCancel(context.Background())
+	if ch, err = testClient.FollowMessageEvents(ctx, follow); err != nil {
+		t.Fatal(err)
+	}
+	sent = append(sent, receiveIDs(t, ch, 3-len(sent))...)
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	for e := range ch {
+		id, _ := events.Field(e, "event_id")
+		sent = append(sent, id)
+	}
+	if ids := strings.Join(sent, ","); ids != "1,2,3" {
+		t.Errorf("FollowMessageEvents => %s want 1,2,3", ids)
+	}
+}
+
+func TestFollowMessageEventsErrors(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	// Errors are reported, and the request retried.
+	var mu sync.Mutex
+	calls := 0
+	path := fmt.Sprintf(sp.MessageEventsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		n := calls
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		if n == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"errors":[{"message":"try again"}]}`))
+			return
+		}
+		fmt.Fprintf(w, `{"results":[%s]}`, followEvent("1", time.Minute))
+	})
+
+	errs := make(chan error, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	ch, err := testClient.FollowMessageEvents(ctx, sp.EventsFollow{
+		Interval: 10 * time.Millisecond,
+		OnError:  func(err error) { errs <- err },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := strings.Join(receiveIDs(t, ch, 1), ","); ids != "1" {
+		t.Errorf("FollowMessageEvents => %s want 1", ids)
+	}
+	select {
+	case err = <-errs:
+		if !strings.Contains(err.Error(), "try again") {
+			t.Errorf("FollowMessageEvents => err %q", err)
+		}
+	default:
+		t.Error("FollowMessageEvents => no error reported")
+	}
+
+	// A checkpoint that can't be loaded is an error up front.
+	bad := sp.FileCheckpoint{Path: t.TempDir()}
+	if _, err = testClient.FollowMessageEvents(ctx, sp.EventsFollow{Checkpoint: bad}); err == nil {
+		t.Error("FollowMessageEvents => nil error for unreadable checkpoint")
+	}
+}
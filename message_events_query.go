@@ -0,0 +1,111 @@
+package gosparkpost
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/SparkPost/gosparkpost/events"
+	"github.com/pkg/errors"
+)
+
+// MessageEventsMaxPerPage is the largest page size the Message Events API supports.
+const MessageEventsMaxPerPage = 10000
+
+// MessageEventsQuery selects events for MessageEventsSearch, as an alternative to building EventsPage.Params by hand.
+// Empty fields don't filter, and list fields match any of their values. Mistakes are caught by Params,
+// before any request is made.
+//
+//	page, err := (&MessageEventsQuery{Events: []string{"bounce"}, From: time.Now().Add(-time.Hour)}).EventsPage()
+//	if err == nil {
+//		res, err = client.MessageEventsSearch(page)
+//	}
+type MessageEventsQuery struct {
+	// From and To bound the time events happened, to the minute. The API defaults to the last 24 hours.
+	From time.Time
+	To   time.Time
+
+	Events        []string
+	Recipients    []string
+	CampaignIDs   []string
+	TemplateIDs   []string
+	BounceClasses []int
+
+	// PerPage is the number of events in each page, up to MessageEventsMaxPerPage. Zero uses the API default.
+	PerPage int
+}
+
+// Params validates the query, and returns it as EventsPage.Params.
+func (q *MessageEventsQuery) Params() (map[string]string, error) {
+	params := map[string]string{}
+
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		return nil, errors.New("MessageEventsQuery From must be before To")
+	}
+	if !q.From.IsZero() {
+		params["from"] = q.From.UTC().Format(messageEventsTimeFormat)
+	}
+	if !q.To.IsZero() {
+		params["to"] = q.To.UTC().Format(messageEventsTimeFormat)
+	}
+
+	for _, etype := range q.Events {
+		if !events.ValidEventType(etype) {
+			return nil, fmt.Errorf("Invalid event type [%s]", etype)
+		}
+	}
+	bounceClasses := make([]string, len(q.BounceClasses))
+	for i, class := range q.BounceClasses {
+		if class < 1 || class > 100 {
+			return nil, fmt.Errorf("Invalid bounce class [%d]", class)
+		}
+		bounceClasses[i] = strconv.Itoa(class)
+	}
+	for param, values := range map[string][]string{
+		"events":         q.Events,
+		"recipients":     q.Recipients,
+		"campaign_ids":   q.CampaignIDs,
+		"template_ids":   q.TemplateIDs,
+		"bounce_classes": bounceClasses,
+	} {
+		for _, v := range values {
+			if v == "" || strings.Contains(v, ",") {
+				return nil, fmt.Errorf("Invalid %s value [%s]", param, v)
+			}
+		}
+		if len(values) > 0 {
+			params[param] = strings.Join(values, ",")
+		}
+	}
+
+	// Bounce classes only apply to events that have one.
+	if len(q.BounceClasses) > 0 && len(q.Events) > 0 {
+		ok := false
+		for _, etype := range q.Events {
+			if _, has := events.Field(events.EventForName(etype), "bounce_class"); has {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return nil, errors.New("MessageEventsQuery BounceClasses requires an event type with a bounce class")
+		}
+	}
+
+	if q.PerPage < 0 || q.PerPage > MessageEventsMaxPerPage {
+		return nil, fmt.Errorf("MessageEventsQuery PerPage must be between 0 and %d", MessageEventsMaxPerPage)
+	} else if q.PerPage > 0 {
+		params["per_page"] = strconv.Itoa(q.PerPage)
+	}
+	return params, nil
+}
+
+// EventsPage returns an EventsPage for MessageEventsSearch, with Params set from the query.
+func (q *MessageEventsQuery) EventsPage() (*EventsPage, error) {
+	params, err := q.Params()
+	if err != nil {
+		return nil, err
+	}
+	return &EventsPage{Params: params}, nil
+}
@@ -0,0 +1,104 @@
+package gosparkpost_test
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/pkg/errors"
+)
+
+func TestMessageEventsQueryParams(t *testing.T) {
+	from := time.Date(2016, 4, 18, 10, 25, 59, 0, time.FixedZone("EDT", -4*3600))
+	for idx, test := range []struct {
+		in     *sp.MessageEventsQuery
+		params map[string]string
+		err    error
+	}{
+		{&sp.MessageEventsQuery{}, map[string]string{}, nil},
+		{&sp.MessageEventsQuery{
+			From:          from,
+			To:            from.Add(24 * time.Hour),
+			Events:        []string{"bounce", "delay"},
+			Recipients:    []string{"recipient@example.com", "other@example.com"},
+			CampaignIDs:   []string{"Summer Sale"},
+			TemplateIDs:   []string{"summer-sale"},
+			BounceClasses: []int{10, 30},
+			PerPage:       100,
+		}, map[string]string{
+			"from":           "2016-04-18T14:25",
+			"to":             "2016-04-19T14:25",
+			"events":         "bounce,delay",
+			"recipients":     "recipient@example.com,other@example.com",
+			"campaign_ids":   "Summer Sale",
+			"template_ids":   "summer-sale",
+			"bounce_classes": "10,30",
+			"per_page":       "100",
+		}, nil},
+		{&sp.MessageEventsQuery{BounceClasses: []int{1}}, map[string]string{"bounce_classes": "1"}, nil},
+
+		{&sp.MessageEventsQuery{From: from, To: from}, nil, errors.New("MessageEventsQuery From must be before To")},
+		{&sp.MessageEventsQuery{Events: []string{"bounces"}}, nil, errors.New("Invalid event type [bounces]")},
+		{&sp.MessageEventsQuery{BounceClasses: []int{0}}, nil, errors.New("Invalid bounce class [0]")},
+		{&sp.MessageEventsQuery{Recipients: []string{"a@example.com,b@example.com"}}, nil,
+			errors.New("Invalid recipients value [a@example.com,b@example.com]")},
+		{&sp.MessageEventsQuery{CampaignIDs: []string{""}}, nil, errors.New("Invalid campaign_ids value []")},
+		{&sp.MessageEventsQuery{Events: []string{"open", "click"}, BounceClasses: []int{10}}, nil,
+			errors.New("MessageEventsQuery BounceClasses requires an event type with a bounce class")},
+		{&sp.MessageEventsQuery{PerPage: -1}, nil, errors.New("MessageEventsQuery PerPage must be between 0 and 10000")},
+		{&sp.MessageEventsQuery{PerPage: 10001}, nil, errors.New("MessageEventsQuery PerPage must be between 0 and 10000")},
+	} {
+		params, err := test.in.Params()
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("MessageEventsQuery.Params[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("MessageEventsQuery.Params[%d] => err %q want %q", idx, err, test.err)
+		} else if !reflect.DeepEqual(params, test.params) {
+			t.Errorf("MessageEventsQuery.Params[%d] => got/want:\n%v\n%v", idx, params, test.params)
+		}
+	}
+}
+
+func TestMessageEventsQuerySearch(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+
+	var query string
+	path := fmt.Sprintf(sp.MessageEventsPathFormat, testClient.Config.ApiVersion)
+	testMux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		query = r.URL.RawQuery
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(loadTestFile(t, "test/json/message-events_search_200.json")))
+	})
+
+	q := &sp.MessageEventsQuery{
+		From:    time.Date(2017, 4, 26, 0, 0, 0, 0, time.UTC),
+		Events:  []string{"click", "open"},
+		PerPage: 10,
+	}
+	page, err := q.EventsPage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = testClient.MessageEventsSearch(page); err != nil {
+		t.Fatal(err)
+	}
+	if want := "events=click%2Copen&from=2017-04-26T00%3A00&per_page=10"; query != want {
+		t.Errorf("MessageEventsQuery => query %q want %q", query, want)
+	}
+	if len(page.Events) == 0 {
+		t.Error("MessageEventsQuery => no events")
+	}
+
+	// Invalid queries never make a request.
+	query = ""
+	if _, err = (&sp.MessageEventsQuery{Events: []string{"clicks"}}).EventsPage(); err == nil {
+		t.Error("MessageEventsQuery.EventsPage => nil error for invalid event type")
+	} else if query != "" {
+		t.Errorf("MessageEventsQuery.EventsPage => made a request %q", query)
+	}
+}
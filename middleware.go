@@ -0,0 +1,65 @@
+package gosparkpost
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// RoundTripFunc sends a single HTTP request and returns its response.
+type RoundTripFunc func(*http.Request) (*http.Response, error)
+
+// Middleware wraps a RoundTripFunc, to observe or modify requests and responses.
+// It's useful for logging, metrics, request signing, header injection and fault injection in tests.
+// Middleware runs once per attempt, after the rate limiter, so retried requests pass through it again.
+type Middleware func(next RoundTripFunc) RoundTripFunc
+
+// Use adds middleware to the Client. The first middleware added is the outermost,
+// and sees requests first and responses last.
+// Like Headers, Use must be called before Client is exposed to possible concurrent use.
+func (c *Client) Use(mw ...Middleware) {
+	c.Middleware = append(c.Middleware, mw...)
+}
+
+// roundTrip sends the request through the configured middleware, and then the http.Client.
+func (c *Client) roundTrip(req *http.Request) (*http.Response, error) {
+	next := RoundTripFunc(c.Client.Do)
+	for i := len(c.Middleware) - 1; i >= 0; i-- {
+		next = c.Middleware[i](next)
+	}
+	return next(req)
+}
+
+type contextKey int
+
+const headerKey contextKey = iota
+
+// WithHeader returns a copy of ctx carrying headers to send with requests made using it.
+// These headers replace any with the same name set in Client.Headers.
+func WithHeader(ctx context.Context, header http.Header) context.Context {
+	return context.WithValue(ctx, headerKey, header)
+}
+
+// HeaderFromContext returns the headers set using WithHeader, if any.
+func HeaderFromContext(ctx context.Context) (http.Header, bool) {
+	header, ok := ctx.Value(headerKey).(http.Header)
+	if !ok {
+		// Deprecated: before WithHeader, headers were stored under this string key.
+		header, ok = ctx.Value("http.Header").(http.Header)
+	}
+	return header, ok
+}
+
+// SubaccountHeader is the header that scopes a request to a subaccount.
+const SubaccountHeader = "X-MSYS-SUBACCOUNT"
+
+// WithSubaccount returns a copy of ctx that scopes requests made using it to the specified subaccount,
+// keeping any other headers set using WithHeader.
+func WithSubaccount(ctx context.Context, id int) context.Context {
+	header := http.Header{}
+	if existing, ok := HeaderFromContext(ctx); ok {
+		header = existing.Clone()
+	}
+	header.Set(SubaccountHeader, strconv.Itoa(id))
+	return WithHeader(ctx, header)
+}
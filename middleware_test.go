@@ -0,0 +1,108 @@
+package gosparkpost_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/pkg/errors"
+)
+
+func TestMiddleware(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	defer func() { testClient.Middleware = nil }()
+
+	testMux.HandleFunc("/middleware", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Seen", r.Header.Get("X-Signature")+","+r.Header.Get("X-Context"))
+		w.WriteHeader(http.StatusOK)
+	})
+
+	var calls []string
+	trace := func(name string) sp.Middleware {
+		return func(next sp.RoundTripFunc) sp.RoundTripFunc {
+			return func(req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" in")
+				res, err := next(req)
+				calls = append(calls, name+" out")
+				return res, err
+			}
+		}
+	}
+	sign := func(next sp.RoundTripFunc) sp.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Signature", "signed")
+			return next(req)
+		}
+	}
+	testClient.Use(trace("outer"), trace("inner"), sign)
+
+	ctx := sp.WithHeader(context.Background(), http.Header{"X-Context": []string{"ctx"}})
+	res, err := testClient.HttpGet(ctx, testClient.Config.BaseUrl+"/middleware")
+	if err != nil {
+		testFailVerbose(t, res, "Middleware GET returned error: %v", err)
+	}
+
+	if got := res.HTTP.Header.Get("X-Seen"); got != "signed,ctx" {
+		t.Errorf("Middleware => server saw headers %q, want %q", got, "signed,ctx")
+	}
+	if got, want := strings.Join(calls, ", "), "outer in, inner in, inner out, outer out"; got != want {
+		t.Errorf("Middleware => call order %q, want %q", got, want)
+	}
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	defer func() { testClient.Middleware = nil }()
+
+	testMux.HandleFunc("/middleware", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	failures := 2
+	testClient.Use(func(next sp.RoundTripFunc) sp.RoundTripFunc {
+		return func(req *http.Request) (*http.Response, error) {
+			if failures > 0 {
+				failures--
+				return nil, errors.New("injected fault")
+			}
+			return next(req)
+		}
+	})
+
+	_, err := testClient.HttpGet(nil, testClient.Config.BaseUrl+"/middleware")
+	if err == nil || err.Error() != "error response: injected fault" {
+		t.Errorf("FaultInjection => err %v, want %q", err, "error response: injected fault")
+	}
+
+	testClient.Config.Retry = &sp.RetryPolicy{MaxAttempts: 2, MinBackoff: time.Millisecond}
+	res, err := testClient.HttpGet(nil, testClient.Config.BaseUrl+"/middleware")
+	if err != nil {
+		testFailVerbose(t, res, "FaultInjection => retried request failed: %v", err)
+	} else if res.HTTP.StatusCode != http.StatusOK {
+		t.Errorf("FaultInjection => status %d, want 200", res.HTTP.StatusCode)
+	}
+}
+
+func TestHeaderFromContext(t *testing.T) {
+	header := http.Header{"X-Foo": []string{"bar"}}
+	for idx, test := range []struct {
+		ctx context.Context
+		ok  bool
+	}{
+		{context.Background(), false},
+		{sp.WithHeader(context.Background(), header), true},
+		{context.WithValue(context.Background(), "http.Header", header), true},
+	} {
+		got, ok := sp.HeaderFromContext(test.ctx)
+		if ok != test.ok {
+			t.Errorf("HeaderFromContext[%d] => ok %t, want %t", idx, ok, test.ok)
+		} else if ok && got.Get("X-Foo") != "bar" {
+			t.Errorf("HeaderFromContext[%d] => %v", idx, got)
+		}
+	}
+}
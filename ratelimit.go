@@ -0,0 +1,165 @@
+package gosparkpost
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointFamily names a group of API endpoints that share a rate limit.
+// It's the first path segment after the API version, for example "templates" for /api/v1/templates/my-template.
+type EndpointFamily string
+
+// Endpoint families with their own documented rate limits.
+// Any other path segment may also be used as a key in Client.RateLimits.
+const (
+	FamilyDefault         EndpointFamily = ""
+	FamilyTransmissions   EndpointFamily = "transmissions"
+	FamilyMessageEvents   EndpointFamily = "message-events"
+	FamilySuppressionList EndpointFamily = "suppression-list"
+	FamilyMetrics         EndpointFamily = "metrics"
+)
+
+// endpointFamily returns the family the provided url belongs to.
+func endpointFamily(urlStr string) EndpointFamily {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return FamilyDefault
+	}
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	// paths look like /api/v1/<family>/...
+	if len(parts) < 3 || parts[0] != "api" {
+		return FamilyDefault
+	}
+	return EndpointFamily(parts[2])
+}
+
+// RateLimiter is a token bucket limiter that's safe for concurrent use.
+// Tokens are added at a steady rate, up to a maximum of burst tokens,
+// and each request to the API takes one.
+type RateLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	burst   int
+	tokens  float64
+	last    time.Time
+	waiting int
+	allowed uint64
+	delayed uint64
+}
+
+// RateLimiterState is a snapshot of a RateLimiter, suitable for monitoring.
+type RateLimiterState struct {
+	Rate    float64 // tokens added per second
+	Burst   int     // maximum number of tokens
+	Tokens  float64 // tokens currently available, negative when callers are waiting
+	Waiting int     // callers currently blocked in Wait
+	Allowed uint64  // total calls to Wait that returned without error
+	Delayed uint64  // calls to Wait that had to block before returning
+}
+
+// NewRateLimiter returns a limiter allowing perSecond requests on average, with bursts of up to burst requests.
+// The bucket starts out full.
+func NewRateLimiter(perSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   perSecond,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// refill adds tokens for the time elapsed since the last refill. The caller must hold l.mu.
+func (l *RateLimiter) refill(now time.Time) {
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens += elapsed.Seconds() * l.rate
+		if l.tokens > float64(l.burst) {
+			l.tokens = float64(l.burst)
+		}
+	}
+	l.last = now
+}
+
+// Wait blocks until a token is available, or the context is done.
+// A token reserved by a caller whose context is done is returned to the bucket.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	l.refill(time.Now())
+	l.tokens--
+	if l.tokens >= 0 {
+		l.allowed++
+		l.mu.Unlock()
+		return nil
+	}
+	if l.rate <= 0 {
+		l.tokens++
+		l.mu.Unlock()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.waiting++
+	l.delayed++
+	l.mu.Unlock()
+
+	err := sleepContext(ctx, wait)
+
+	l.mu.Lock()
+	l.waiting--
+	if err != nil {
+		l.tokens++
+	} else {
+		l.allowed++
+	}
+	l.mu.Unlock()
+	return err
+}
+
+// State returns a snapshot of the limiter's current state.
+func (l *RateLimiter) State() RateLimiterState {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.refill(time.Now())
+	return RateLimiterState{
+		Rate:    l.rate,
+		Burst:   l.burst,
+		Tokens:  l.tokens,
+		Waiting: l.waiting,
+		Allowed: l.allowed,
+		Delayed: l.delayed,
+	}
+}
+
+// rateLimiter returns the limiter that applies to the provided url, or nil if there isn't one.
+func (c *Client) rateLimiter(urlStr string) *RateLimiter {
+	if len(c.RateLimits) == 0 {
+		return nil
+	}
+	if l, ok := c.RateLimits[endpointFamily(urlStr)]; ok {
+		return l
+	}
+	return c.RateLimits[FamilyDefault]
+}
+
+// RateLimitState returns a snapshot of each configured rate limiter, keyed by endpoint family.
+func (c *Client) RateLimitState() map[EndpointFamily]RateLimiterState {
+	states := make(map[EndpointFamily]RateLimiterState, len(c.RateLimits))
+	for family, l := range c.RateLimits {
+		if l != nil {
+			states[family] = l.State()
+		}
+	}
+	return states
+}
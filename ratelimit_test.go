@@ -0,0 +1,94 @@
+package gosparkpost_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+)
+
+func TestRateLimiter(t *testing.T) {
+	l := sp.NewRateLimiter(50, 2)
+
+	var wg sync.WaitGroup
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := l.Wait(context.Background()); err != nil {
+				t.Errorf("RateLimiter.Wait => unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// two tokens are available immediately, the other two take 20ms each
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Errorf("RateLimiter => 4 calls took %s, expected at least 30ms", elapsed)
+	}
+	state := l.State()
+	if state.Allowed != 4 || state.Delayed != 2 || state.Waiting != 0 || state.Burst != 2 {
+		t.Errorf("RateLimiter.State => %+v", state)
+	}
+}
+
+func TestRateLimiterCanceled(t *testing.T) {
+	l := sp.NewRateLimiter(0.01, 1)
+	if err := l.Wait(context.Background()); err != nil {
+		t.Fatalf("RateLimiter.Wait => unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	if err := l.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("RateLimiter.Wait => err %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	// the canceled caller's token must be given back
+	if state := l.State(); state.Tokens < 0 || state.Waiting != 0 || state.Allowed != 1 {
+		t.Errorf("RateLimiter.State => %+v", state)
+	}
+}
+
+func TestClientRateLimits(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	testClient.RateLimits = map[sp.EndpointFamily]*sp.RateLimiter{
+		sp.FamilyTransmissions: sp.NewRateLimiter(0.01, 1),
+	}
+	defer func() { testClient.RateLimits = nil }()
+
+	for _, format := range []string{sp.TransmissionsPathFormat, sp.TemplatesPathFormat} {
+		mockRestResponseBuilderFormat(t, "GET", http.StatusOK, format, `{"results":[]}`)
+	}
+
+	for idx, test := range []struct {
+		format string
+		err    string
+	}{
+		{sp.TransmissionsPathFormat, ""},
+		{sp.TransmissionsPathFormat, "waiting for rate limiter: context deadline exceeded"},
+		{sp.TemplatesPathFormat, ""},
+		{sp.TemplatesPathFormat, ""},
+	} {
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		u := testClient.Config.BaseUrl + fmt.Sprintf(test.format, testClient.Config.ApiVersion)
+		_, err := testClient.HttpGet(ctx, u)
+		cancel()
+		if test.err == "" && err != nil {
+			t.Errorf("RateLimits[%d] => unexpected error: %v", idx, err)
+		} else if test.err != "" && (err == nil || err.Error() != test.err) {
+			t.Errorf("RateLimits[%d] => err %v, want %q", idx, err, test.err)
+		}
+	}
+
+	states := testClient.RateLimitState()
+	if state, ok := states[sp.FamilyTransmissions]; !ok || state.Allowed != 1 {
+		t.Errorf("RateLimitState => %+v", states)
+	}
+}
@@ -0,0 +1,270 @@
+package gosparkpost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/SparkPost/gosparkpost/events"
+	"github.com/pkg/errors"
+)
+
+// RelayWebhooksPathFormat provides an easy way to fill out the path including the version.
+// https://developers.sparkpost.com/api/relay-webhooks/
+var RelayWebhooksPathFormat = "/api/v%d/relay-webhooks"
+
+// RelayWebhook is the JSON structure accepted by and returned from the SparkPost Relay Webhooks API.
+// Mail received for Match.Domain, which must be an InboundDomain, is posted to Target as events.RelayMessage
+// objects, with AuthToken in the X-MessageSystems-Webhook-Token header.
+type RelayWebhook struct {
+	ID                 string                   `json:"id,omitempty"`
+	Name               string                   `json:"name,omitempty"`
+	Target             string                   `json:"target,omitempty"`
+	AuthToken          string                   `json:"auth_token,omitempty"`
+	AuthType           string                   `json:"auth_type,omitempty"`
+	AuthRequestDetails *RelayWebhookAuthRequest `json:"auth_request_details,omitempty"`
+	CustomHeaders      map[string]string        `json:"custom_headers,omitempty"`
+	Match              *RelayWebhookMatch       `json:"match,omitempty"`
+}
+
+// RelayWebhookAuthRequest contains the details SparkPost uses to fetch an OAuth2 token
+// when AuthType is "oauth2".
+type RelayWebhookAuthRequest struct {
+	URL  string `json:"url,omitempty"`
+	Body struct {
+		ClientID     string `json:"client_id,omitempty"`
+		ClientSecret string `json:"client_secret,omitempty"`
+	} `json:"body,omitempty"`
+}
+
+// RelayWebhookMatch selects the inbound messages that are relayed.
+// Protocol is "SMTP" (the default) or "ESME", which uses EsmeAddress instead of Domain.
+type RelayWebhookMatch struct {
+	Protocol    string `json:"protocol,omitempty"`
+	Domain      string `json:"domain,omitempty"`
+	EsmeAddress string `json:"esme_address,omitempty"`
+}
+
+// Validate runs sanity checks on a RelayWebhook struct.
+// This should catch most errors before attempting a doomed API call.
+func (r *RelayWebhook) Validate() error {
+	if r == nil {
+		return errors.New("Can't Validate a nil RelayWebhook")
+	}
+	if r.Target == "" {
+		return errors.New("RelayWebhook requires a non-empty Target")
+	}
+	if r.Match == nil || r.Match.Domain == "" && r.Match.EsmeAddress == "" {
+		return errors.New("RelayWebhook requires a Match with a Domain or EsmeAddress")
+	}
+	if len(r.Name) > 1024 {
+		return errors.New("RelayWebhook name may not be longer than 1024 bytes")
+	}
+	return nil
+}
+
+// RelayWebhookCreate creates the provided relay webhook, returning its id.
+func (c *Client) RelayWebhookCreate(r *RelayWebhook) (id string, res *Response, err error) {
+	return c.RelayWebhookCreateContext(context.Background(), r)
+}
+
+// RelayWebhookCreateContext is the same as RelayWebhookCreate, and it allows the caller to provide a context.
+func (c *Client) RelayWebhookCreateContext(ctx context.Context, r *RelayWebhook) (id string, res *Response, err error) {
+	if r == nil {
+		err = errors.New("Create called with nil RelayWebhook")
+		return
+	}
+
+	if err = r.Validate(); err != nil {
+		return
+	}
+
+	// A RelayWebhook that makes it past Validate() will always Marshal
+	jsonBytes, _ := json.Marshal(r)
+
+	path := fmt.Sprintf(RelayWebhooksPathFormat, c.Config.ApiVersion)
+	res, err = c.HttpPost(ctx, c.Config.BaseUrl+path, jsonBytes)
+	if err != nil {
+		return
+	}
+
+	if err = res.ParseResponse(); err != nil {
+		return
+	}
+
+	if Is2XX(res.HTTP.StatusCode) {
+		var ok bool
+		var results map[string]interface{}
+		if results, ok = res.Results.(map[string]interface{}); !ok {
+			err = errors.New("Unexpected response to RelayWebhook creation (results)")
+		} else if id, ok = results["id"].(string); !ok {
+			err = errors.New("Unexpected response to RelayWebhook creation (id)")
+		}
+	} else {
+		err = res.HTTPError()
+	}
+	return
+}
+
+// RelayWebhook looks up the relay webhook with the provided id.
+func (c *Client) RelayWebhook(id string) (*RelayWebhook, *Response, error) {
+	return c.RelayWebhookContext(context.Background(), id)
+}
+
+// RelayWebhookContext is the same as RelayWebhook, and it allows the caller to provide a context.
+func (c *Client) RelayWebhookContext(ctx context.Context, id string) (r *RelayWebhook, res *Response, err error) {
+	if id == "" {
+		err = errors.New("RelayWebhook called with blank id")
+		return
+	}
+
+	path := fmt.Sprintf(RelayWebhooksPathFormat, c.Config.ApiVersion)
+	res, err = c.HttpGet(ctx, fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(id)))
+	if err != nil {
+		return
+	}
+
+	var body []byte
+	if body, err = res.AssertJson(); err != nil {
+		return
+	}
+
+	if Is2XX(res.HTTP.StatusCode) {
+		wrapper := map[string]*RelayWebhook{}
+		if err = json.Unmarshal(body, &wrapper); err != nil {
+		} else if r = wrapper["results"]; r == nil {
+			err = errors.New("Unexpected response to RelayWebhook")
+		} else if r.ID == "" {
+			r.ID = id
+		}
+	} else {
+		err = res.ParseResponse()
+		if err == nil {
+			err = res.HTTPError()
+		}
+	}
+
+	return
+}
+
+// RelayWebhooks returns all relay webhooks.
+func (c *Client) RelayWebhooks() ([]RelayWebhook, *Response, error) {
+	return c.RelayWebhooksContext(context.Background())
+}
+
+// RelayWebhooksContext is the same as RelayWebhooks, and it allows the caller to provide a context.
+func (c *Client) RelayWebhooksContext(ctx context.Context) ([]RelayWebhook, *Response, error) {
+	it := c.RelayWebhooksIter()
+	webhooks, err := it.Collect(ctx)
+	return webhooks, it.Response(), err
+}
+
+// RelayWebhooksIter returns an Iterator over all relay webhooks.
+func (c *Client) RelayWebhooksIter() *Iterator[RelayWebhook] {
+	path := fmt.Sprintf(RelayWebhooksPathFormat, c.Config.ApiVersion)
+	return listPageIterator[RelayWebhook](c, path)
+}
+
+// RelayWebhookUpdate updates the relay webhook identified by r.ID.
+func (c *Client) RelayWebhookUpdate(r *RelayWebhook) (*Response, error) {
+	return c.RelayWebhookUpdateContext(context.Background(), r)
+}
+
+// RelayWebhookUpdateContext is the same as RelayWebhookUpdate, and it allows the caller to provide a context.
+func (c *Client) RelayWebhookUpdateContext(ctx context.Context, r *RelayWebhook) (*Response, error) {
+	if r == nil {
+		return nil, errors.New("Update called with nil RelayWebhook")
+	} else if r.ID == "" {
+		return nil, errors.New("Update called with blank id")
+	}
+
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+
+	// The id is part of the path, and may not be sent in the body
+	update := *r
+	update.ID = ""
+	jsonBytes, _ := json.Marshal(update)
+
+	path := fmt.Sprintf(RelayWebhooksPathFormat, c.Config.ApiVersion)
+	return c.HttpPutJson(ctx, fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(r.ID)), jsonBytes)
+}
+
+// RelayWebhookDelete deletes the relay webhook with the provided id.
+func (c *Client) RelayWebhookDelete(id string) (*Response, error) {
+	return c.RelayWebhookDeleteContext(context.Background(), id)
+}
+
+// RelayWebhookDeleteContext is the same as RelayWebhookDelete, and it allows the caller to provide a context.
+func (c *Client) RelayWebhookDeleteContext(ctx context.Context, id string) (*Response, error) {
+	if id == "" {
+		return nil, errors.New("Delete called with blank id")
+	}
+
+	path := fmt.Sprintf(RelayWebhooksPathFormat, c.Config.ApiVersion)
+	res, err := c.HttpDelete(ctx, fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(id)))
+	if err != nil {
+		return res, err
+	}
+
+	// We get an empty response on success. If there are errors we get JSON.
+	if _, err = res.AssertJson(); err == nil {
+		if err = res.ParseResponse(); err != nil {
+			return res, err
+		}
+	}
+
+	return res, res.HTTPError()
+}
+
+// RelayWebhookValidate sends the provided test message to the target of the relay webhook
+// with the provided id, and returns how the target responded.
+func (c *Client) RelayWebhookValidate(id string, msg *events.RelayMessage) (*WebhookValidation, *Response, error) {
+	return c.RelayWebhookValidateContext(context.Background(), id, msg)
+}
+
+// RelayWebhookValidateContext is the same as RelayWebhookValidate, and it allows the caller to provide a context.
+func (c *Client) RelayWebhookValidateContext(ctx context.Context, id string, msg *events.RelayMessage) (v *WebhookValidation, res *Response, err error) {
+	if id == "" {
+		err = errors.New("Validate called with blank id")
+		return
+	} else if msg == nil {
+		err = errors.New("Validate called with nil RelayMessage")
+		return
+	}
+
+	jsonBytes, err := json.Marshal(map[string]*events.RelayMessage{"msg": msg})
+	if err != nil {
+		return
+	}
+
+	path := fmt.Sprintf(RelayWebhooksPathFormat, c.Config.ApiVersion)
+	res, err = c.HttpPost(ctx, fmt.Sprintf("%s%s/%s/validate", c.Config.BaseUrl, path, url.PathEscape(id)), jsonBytes)
+	if err != nil {
+		return
+	}
+
+	var body []byte
+	if body, err = res.AssertJson(); err != nil {
+		return
+	}
+
+	if err = res.ParseResponse(); err != nil {
+		return
+	}
+
+	if Is2XX(res.HTTP.StatusCode) {
+		wrapper := map[string]*WebhookValidation{}
+		if err = json.Unmarshal(body, &wrapper); err != nil {
+			err = errors.Wrap(err, "parsing api response")
+		} else if v = wrapper["results"]; v == nil {
+			err = errors.New("Unexpected response to RelayWebhook validation (results)")
+		}
+	} else {
+		err = res.HTTPError()
+	}
+
+	return
+}
@@ -0,0 +1,212 @@
+package gosparkpost_test
+
+import (
+	"reflect"
+	"testing"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/SparkPost/gosparkpost/events"
+	"github.com/pkg/errors"
+)
+
+func TestRelayWebhookCreate(t *testing.T) {
+	var match = &sp.RelayWebhookMatch{Domain: "email.example.com"}
+
+	for idx, test := range []struct {
+		in     *sp.RelayWebhook
+		err    error
+		status int
+		body   string
+		json   string
+		id     string
+	}{
+		{nil, errors.New("Create called with nil RelayWebhook"), 0, "", "", ""},
+		{&sp.RelayWebhook{Match: match}, errors.New("RelayWebhook requires a non-empty Target"), 0, "", "", ""},
+		{&sp.RelayWebhook{Target: "https://example.com/relay", Match: &sp.RelayWebhookMatch{Protocol: "SMTP"}},
+			errors.New("RelayWebhook requires a Match with a Domain or EsmeAddress"), 0, "", "", ""},
+		{&sp.RelayWebhook{Target: "https://example.com/relay", Match: match},
+			errors.New(`[{"message":"invalid params","code":"1200","description":""}]`), 400, "",
+			`{"errors":[{"message":"invalid params","code":"1200"}]}`, ""},
+		{&sp.RelayWebhook{Target: "https://example.com/relay", Match: match},
+			errors.New("Unexpected response to RelayWebhook creation (id)"), 200, "", `{"results":{}}`, ""},
+
+		{&sp.RelayWebhook{Name: "Replies", Target: "https://example.com/relay", AuthToken: "secret", Match: match}, nil, 200,
+			`{"name":"Replies","target":"https://example.com/relay","auth_token":"secret","match":{"domain":"email.example.com"}}`,
+			`{"results":{"id":"12013026328707075"}}`, "12013026328707075"},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestRequestResponseBuilderFormat(t, "POST", test.status, sp.RelayWebhooksPathFormat, test.body, test.json)
+
+		id, _, err := testClient.RelayWebhookCreate(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("RelayWebhookCreate[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("RelayWebhookCreate[%d] => err %q want %q", idx, err, test.err)
+		} else if id != test.id {
+			t.Errorf("RelayWebhookCreate[%d] => id %q want %q", idx, id, test.id)
+		}
+	}
+}
+
+func TestRelayWebhook(t *testing.T) {
+	var res200 = loadTestFile(t, "test/json/relay_webhook_200.json")
+	var webhook200 = &sp.RelayWebhook{
+		ID:        "12013026328707075",
+		Name:      "Replies Webhook",
+		Target:    "https://webhooks.customer.example/replies",
+		AuthToken: "5ebe2294ecd0e0f08eab7690d2a6ee69",
+		Match:     &sp.RelayWebhookMatch{Protocol: "SMTP", Domain: "email.example.com"},
+	}
+
+	for idx, test := range []struct {
+		in     string
+		err    error
+		status int
+		json   string
+		out    *sp.RelayWebhook
+	}{
+		{"", errors.New("RelayWebhook called with blank id"), 0, "", nil},
+		{"12013026328707075", errors.New("Unexpected response to RelayWebhook"), 200, `{"foo":{}}`, nil},
+		{"12013026328707075", errors.New(`[{"message":"resource not found","code":"1600","description":""}]`), 404,
+			`{"errors":[{"message":"resource not found","code":"1600"}]}`, nil},
+		{"12013026328707075", nil, 200, res200, webhook200},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "GET", test.status, sp.RelayWebhooksPathFormat+"/12013026328707075", test.json)
+
+		r, _, err := testClient.RelayWebhook(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("RelayWebhook[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("RelayWebhook[%d] => err %q want %q", idx, err, test.err)
+		} else if test.out != nil && !reflect.DeepEqual(r, test.out) {
+			t.Errorf("RelayWebhook[%d] => got/want:\n%+v\n%+v", idx, r, test.out)
+		}
+	}
+}
+
+func TestRelayWebhooks(t *testing.T) {
+	var res200 = loadTestFile(t, "test/json/relay_webhooks_200.json")
+
+	for idx, test := range []struct {
+		err    error
+		status int
+		json   string
+		out    int
+	}{
+		{errors.New(`[{"message":"error","code":"","description":""}]`), 400, `{"errors":[{"message":"error"}]}`, 0},
+		{nil, 200, res200, 2},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "GET", test.status, sp.RelayWebhooksPathFormat, test.json)
+
+		webhooks, _, err := testClient.RelayWebhooks()
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("RelayWebhooks[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("RelayWebhooks[%d] => err %q want %q", idx, err, test.err)
+		} else if len(webhooks) != test.out {
+			t.Errorf("RelayWebhooks[%d] => %d webhooks, want %d", idx, len(webhooks), test.out)
+		} else if err == nil && (webhooks[1].Match.EsmeAddress != "12345" ||
+			webhooks[1].AuthRequestDetails.Body.ClientID != "<oauth client id>" ||
+			webhooks[1].CustomHeaders["x-customer-header"] != "value") {
+			t.Errorf("RelayWebhooks[%d] => %+v", idx, webhooks[1])
+		}
+	}
+}
+
+func TestRelayWebhookUpdate(t *testing.T) {
+	var match = &sp.RelayWebhookMatch{Domain: "email.example.com"}
+
+	for idx, test := range []struct {
+		in     *sp.RelayWebhook
+		err    error
+		status int
+		body   string
+		json   string
+	}{
+		{nil, errors.New("Update called with nil RelayWebhook"), 0, "", ""},
+		{&sp.RelayWebhook{Target: "https://example.com/relay", Match: match}, errors.New("Update called with blank id"), 0, "", ""},
+		{&sp.RelayWebhook{ID: "12013026328707075", Match: match}, errors.New("RelayWebhook requires a non-empty Target"), 0, "", ""},
+		{&sp.RelayWebhook{ID: "12013026328707075", Target: "https://example.com/relay", Match: match},
+			errors.New(`[{"message":"error","code":"","description":""}]`), 400, "",
+			`{"errors":[{"message":"error"}]}`},
+		{&sp.RelayWebhook{ID: "12013026328707075", Target: "https://example.com/relay", Match: match}, nil, 200,
+			`{"target":"https://example.com/relay","match":{"domain":"email.example.com"}}`,
+			`{"results":{"id":"12013026328707075"}}`},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestRequestResponseBuilderFormat(t, "PUT", test.status, sp.RelayWebhooksPathFormat+"/12013026328707075", test.body, test.json)
+
+		_, err := testClient.RelayWebhookUpdate(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("RelayWebhookUpdate[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("RelayWebhookUpdate[%d] => err %q want %q", idx, err, test.err)
+		}
+	}
+}
+
+func TestRelayWebhookDelete(t *testing.T) {
+	for idx, test := range []struct {
+		in     string
+		err    error
+		status int
+		json   string
+	}{
+		{"", errors.New("Delete called with blank id"), 0, ""},
+		{"12013026328707075", errors.New(`[{"message":"resource not found","code":"1600","description":""}]`), 404,
+			`{"errors":[{"message":"resource not found","code":"1600"}]}`},
+		{"12013026328707075", nil, 204, ""},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "DELETE", test.status, sp.RelayWebhooksPathFormat+"/12013026328707075", test.json)
+
+		_, err := testClient.RelayWebhookDelete(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("RelayWebhookDelete[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("RelayWebhookDelete[%d] => err %q want %q", idx, err, test.err)
+		}
+	}
+}
+
+func TestRelayWebhookValidate(t *testing.T) {
+	var msg = &events.RelayMessage{From: "me@example.com", To: "you@email.example.com"}
+
+	for idx, test := range []struct {
+		id     string
+		msg    *events.RelayMessage
+		err    error
+		status int
+		json   string
+		out    int
+	}{
+		{"", msg, errors.New("Validate called with blank id"), 0, "", 0},
+		{"12013026328707075", nil, errors.New("Validate called with nil RelayMessage"), 0, "", 0},
+		{"12013026328707075", msg, errors.New("Unexpected response to RelayWebhook validation (results)"), 200,
+			`{"foo":{}}`, 0},
+		{"12013026328707075", msg, errors.New(`[{"message":"resource not found","code":"1600","description":""}]`), 404,
+			`{"errors":[{"message":"resource not found","code":"1600"}]}`, 0},
+		{"12013026328707075", msg, nil, 200,
+			`{"results":{"msg":"Test POST to endpoint succeeded","response":{"status":200,"headers":{"Content-Type":"text/plain"},"body":"OK"}}}`, 200},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "POST", test.status, sp.RelayWebhooksPathFormat+"/12013026328707075/validate", test.json)
+
+		v, _, err := testClient.RelayWebhookValidate(test.id, test.msg)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("RelayWebhookValidate[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("RelayWebhookValidate[%d] => err %q want %q", idx, err, test.err)
+		} else if err == nil && (v.Response.Status != test.out || v.Response.Body != "OK") {
+			t.Errorf("RelayWebhookValidate[%d] => %+v", idx, v)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package gosparkpost
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls whether and how DoRequest retries failed requests.
+// A nil policy (the default) means every request is attempted exactly once.
+// Requests are retried after network errors, and after 429, 502, 503 and 504 responses.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// MinBackoff is the delay before the first retry, which doubles with each following attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the computed delay. It doesn't apply to delays requested using Retry-After.
+	MaxBackoff time.Duration
+	// RetryPost allows retrying POST requests, which aren't idempotent.
+	// Retrying a POST to the Transmissions API can cause duplicate email to be sent.
+	RetryPost bool
+}
+
+// DefaultRetryPolicy is a reasonable starting point for Config.Retry.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  30 * time.Second,
+}
+
+// retryStatuses are the HTTP response codes that indicate a request may succeed if retried.
+var retryStatuses = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// maxAttempts returns the number of attempts allowed for the specified method.
+func (p *RetryPolicy) maxAttempts(method string) int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	if strings.EqualFold(method, http.MethodPost) && !p.RetryPost {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// backoff returns how long to wait before making the next attempt, and whether that attempt should be made.
+// The attempt parameter is the number of the attempt that just completed, starting from 1.
+func (p *RetryPolicy) backoff(attempt int, method string, res *http.Response, err error) (time.Duration, bool) {
+	if attempt >= p.maxAttempts(method) {
+		return 0, false
+	}
+	if err == nil && (res == nil || !retryStatuses[res.StatusCode]) {
+		return 0, false
+	}
+
+	if res != nil && (res.StatusCode == http.StatusTooManyRequests ||
+		res.StatusCode == http.StatusServiceUnavailable) {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After"), time.Now()); ok {
+			return wait, true
+		}
+	}
+
+	wait := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff <= 0 || wait < p.MaxBackoff); i++ {
+		wait *= 2
+	}
+	if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+		wait = p.MaxBackoff
+	}
+	// "Equal jitter": wait somewhere between half and all of the computed delay.
+	if half := int64(wait / 2); half > 0 {
+		wait = time.Duration(half + rand.Int63n(half))
+	}
+	return wait, true
+}
+
+// retryAfter parses the value of a Retry-After header, which is either a number of seconds or an HTTP date.
+func retryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(value); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if when, err := http.ParseTime(value); err == nil {
+		if wait := when.Sub(now); wait > 0 {
+			return wait, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
+
+// sleepContext waits for the specified duration, returning early if the context is done.
+func sleepContext(ctx context.Context, wait time.Duration) error {
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
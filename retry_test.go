@@ -0,0 +1,101 @@
+package gosparkpost_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+)
+
+func TestRetry(t *testing.T) {
+	policy := &sp.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+	for idx, test := range []struct {
+		name     string
+		method   string
+		policy   *sp.RetryPolicy
+		statuses []int
+		header   string
+		timeout  time.Duration
+		attempts int
+		status   int
+	}{
+		{"no policy", "GET", nil, []int{503, 200}, "", 0, 1, 503},
+		{"success", "GET", policy, []int{200}, "", 0, 1, 200},
+		{"retry then success", "GET", policy, []int{503, 502, 200}, "", 0, 3, 200},
+		{"give up", "DELETE", policy, []int{504, 504, 504, 200}, "", 0, 3, 504},
+		{"not retryable", "PUT", policy, []int{400, 200}, "", 0, 1, 400},
+		{"post not retried", "POST", policy, []int{429, 200}, "", 0, 1, 429},
+		{"post opt in", "POST", &sp.RetryPolicy{MaxAttempts: 2, RetryPost: true}, []int{429, 200}, "", 0, 2, 200},
+		{"retry-after", "GET", policy, []int{429, 200}, "0", 0, 2, 200},
+		{"retry-after past deadline", "GET", policy, []int{503, 200}, "30", 100 * time.Millisecond, 1, 503},
+	} {
+		testSetup(t)
+		testClient.Config.Retry = test.policy
+		testClient.Config.Verbose = true
+
+		attempts := 0
+		testMux.HandleFunc("/retry", func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, test.method)
+			status := test.statuses[attempts]
+			attempts++
+			if test.header != "" {
+				w.Header().Set("Retry-After", test.header)
+			}
+			w.Header().Set("Content-Type", "application/json; charset=utf8")
+			w.WriteHeader(status)
+			w.Write([]byte(`{}`))
+		})
+
+		ctx := context.Background()
+		if test.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, test.timeout)
+			defer cancel()
+		}
+
+		res, err := testClient.DoRequest(ctx, test.method, testClient.Config.BaseUrl+"/retry", []byte(`{}`))
+		if err != nil {
+			t.Errorf("Retry[%d] (%s) => unexpected error: %v", idx, test.name, err)
+		} else if attempts != test.attempts {
+			t.Errorf("Retry[%d] (%s) => %d attempts, want %d", idx, test.name, attempts, test.attempts)
+		} else if res.HTTP.StatusCode != test.status {
+			t.Errorf("Retry[%d] (%s) => status %d, want %d", idx, test.name, res.HTTP.StatusCode, test.status)
+		} else {
+			for i := 1; i <= test.attempts; i++ {
+				if _, ok := res.Verbose[fmt.Sprintf("http_attempt_%d", i)]; !ok {
+					t.Errorf("Retry[%d] (%s) => attempt %d not recorded", idx, test.name, i)
+				}
+			}
+			if extra, ok := res.Verbose[fmt.Sprintf("http_attempt_%d", test.attempts+1)]; ok {
+				t.Errorf("Retry[%d] (%s) => unexpected attempt recorded: %s", idx, test.name, extra)
+			}
+		}
+		if body, err := res.ReadBody(); err != nil || string(body) != `{}` {
+			t.Errorf("Retry[%d] (%s) => body %q (%v)", idx, test.name, body, err)
+		}
+		testTeardown()
+	}
+}
+
+func TestRetryCanceled(t *testing.T) {
+	testSetup(t)
+	defer testTeardown()
+	testClient.Config.Retry = &sp.RetryPolicy{MaxAttempts: 5, MinBackoff: time.Minute, MaxBackoff: time.Minute}
+
+	testMux.HandleFunc("/retry", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(50*time.Millisecond, cancel)
+
+	res, err := testClient.DoRequest(ctx, "GET", testClient.Config.BaseUrl+"/retry", nil)
+	if err == nil || err.Error() != "waiting to retry: context canceled" {
+		t.Errorf("RetryCanceled => err %v, want %q", err, "waiting to retry: context canceled")
+	} else if res == nil || res.HTTP == nil || res.HTTP.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("RetryCanceled => expected the last response to be returned")
+	}
+}
@@ -0,0 +1,299 @@
+package gosparkpost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+// SendingDomainsPathFormat provides an easy way to fill out the path including the version.
+// https://developers.sparkpost.com/api/sending-domains/
+var SendingDomainsPathFormat = "/api/v%d/sending-domains"
+
+// SendingDomain is the JSON structure accepted by and returned from the SparkPost Sending Domains API.
+// Status is read-only, and is only returned by SendingDomain and SendingDomains.
+type SendingDomain struct {
+	Domain                string               `json:"domain,omitempty"`
+	TrackingDomain        string               `json:"tracking_domain,omitempty"`
+	Status                *SendingDomainStatus `json:"status,omitempty"`
+	DKIM                  *DKIM                `json:"dkim,omitempty"`
+	GenerateDKIM          *bool                `json:"generate_dkim,omitempty"`
+	DKIMKeyLength         int                  `json:"dkim_key_length,omitempty"`
+	SharedWithSubaccounts bool                 `json:"shared_with_subaccounts,omitempty"`
+	IsDefaultBounceDomain bool                 `json:"is_default_bounce_domain,omitempty"`
+	SubaccountID          int                  `json:"subaccount_id,omitempty"`
+}
+
+// SendingDomainStatus contains the verification status of a sending domain.
+// Statuses are one of "unverified", "pending", "valid" or "invalid".
+type SendingDomainStatus struct {
+	OwnershipVerified         bool   `json:"ownership_verified"`
+	DKIMStatus                string `json:"dkim_status,omitempty"`
+	SPFStatus                 string `json:"spf_status,omitempty"`
+	CNAMEStatus               string `json:"cname_status,omitempty"`
+	MXStatus                  string `json:"mx_status,omitempty"`
+	AbuseAtStatus             string `json:"abuse_at_status,omitempty"`
+	PostmasterAtStatus        string `json:"postmaster_at_status,omitempty"`
+	VerificationMailboxStatus string `json:"verification_mailbox_status,omitempty"`
+	VerificationMailbox       string `json:"verification_mailbox,omitempty"`
+	ComplianceStatus          string `json:"compliance_status,omitempty"`
+}
+
+// SendingDomainVerify selects which verifications SendingDomainVerify requests.
+// The token fields complete verification using a token received by email at the corresponding mailbox.
+type SendingDomainVerify struct {
+	DKIMVerify                bool   `json:"dkim_verify,omitempty"`
+	SPFVerify                 bool   `json:"spf_verify,omitempty"`
+	CNAMEVerify               bool   `json:"cname_verify,omitempty"`
+	MXVerify                  bool   `json:"mx_verify,omitempty"`
+	AbuseAtVerify             bool   `json:"abuse_at_verify,omitempty"`
+	PostmasterAtVerify        bool   `json:"postmaster_at_verify,omitempty"`
+	VerificationMailboxVerify bool   `json:"verification_mailbox_verify,omitempty"`
+	VerificationMailbox       string `json:"verification_mailbox,omitempty"`
+	AbuseAtToken              string `json:"abuse_at_token,omitempty"`
+	PostmasterAtToken         string `json:"postmaster_at_token,omitempty"`
+	VerificationMailboxToken  string `json:"verification_mailbox_token,omitempty"`
+}
+
+// SendingDomainVerifyResult is the updated status returned by SendingDomainVerify,
+// along with the DNS records that were checked and any errors found with them.
+type SendingDomainVerifyResult struct {
+	SendingDomainStatus
+	DNS *SendingDomainDNS `json:"dns,omitempty"`
+}
+
+// SendingDomainDNS describes the DNS records checked during verification.
+type SendingDomainDNS struct {
+	DKIMRecord string `json:"dkim_record,omitempty"`
+	SPFRecord  string `json:"spf_record,omitempty"`
+	DKIMError  string `json:"dkim_error,omitempty"`
+	SPFError   string `json:"spf_error,omitempty"`
+	CNAMEError string `json:"cname_error,omitempty"`
+	MXError    string `json:"mx_error,omitempty"`
+}
+
+// SendingDomainCreate registers the provided sending domain.
+// When the response includes DKIM details, such as a generated public key, d.DKIM is updated with them.
+func (c *Client) SendingDomainCreate(d *SendingDomain) (res *Response, err error) {
+	return c.SendingDomainCreateContext(context.Background(), d)
+}
+
+// SendingDomainCreateContext is the same as SendingDomainCreate, and it allows the caller to provide a context.
+func (c *Client) SendingDomainCreateContext(ctx context.Context, d *SendingDomain) (res *Response, err error) {
+	if d == nil {
+		err = errors.New("Create called with nil SendingDomain")
+		return
+	} else if d.Domain == "" {
+		err = errors.New("SendingDomain requires a non-empty Domain")
+		return
+	}
+
+	// Marshaling a static type won't fail
+	jsonBytes, _ := json.Marshal(d)
+
+	path := fmt.Sprintf(SendingDomainsPathFormat, c.Config.ApiVersion)
+	res, err = c.HttpPost(ctx, c.Config.BaseUrl+path, jsonBytes)
+	if err != nil {
+		return
+	}
+
+	var body []byte
+	if body, err = res.AssertJson(); err != nil {
+		return
+	}
+
+	if err = res.ParseResponse(); err != nil {
+		return
+	}
+
+	if Is2XX(res.HTTP.StatusCode) {
+		var created map[string]struct {
+			DKIM *DKIM `json:"dkim"`
+		}
+		if err = json.Unmarshal(body, &created); err != nil {
+			err = errors.Wrap(err, "parsing api response")
+		} else if results, ok := created["results"]; !ok {
+			err = errors.New("Unexpected response to SendingDomain creation (results)")
+		} else if results.DKIM != nil {
+			if d.DKIM != nil && results.DKIM.Private == "" {
+				// the private key isn't returned
+				results.DKIM.Private = d.DKIM.Private
+			}
+			d.DKIM = results.DKIM
+		}
+	} else {
+		err = res.HTTPError()
+	}
+
+	return
+}
+
+// SendingDomain looks up the sending domain with the provided name.
+func (c *Client) SendingDomain(domain string) (*SendingDomain, *Response, error) {
+	return c.SendingDomainContext(context.Background(), domain)
+}
+
+// SendingDomainContext is the same as SendingDomain, and it allows the caller to provide a context.
+func (c *Client) SendingDomainContext(ctx context.Context, domain string) (d *SendingDomain, res *Response, err error) {
+	if domain == "" {
+		err = errors.New("SendingDomain called with blank domain")
+		return
+	}
+
+	path := fmt.Sprintf(SendingDomainsPathFormat, c.Config.ApiVersion)
+	res, err = c.HttpGet(ctx, fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(domain)))
+	if err != nil {
+		return
+	}
+
+	var body []byte
+	if body, err = res.AssertJson(); err != nil {
+		return
+	}
+
+	if Is2XX(res.HTTP.StatusCode) {
+		dlist := map[string]SendingDomain{}
+		err = json.Unmarshal(body, &dlist)
+		if err != nil {
+		} else if sd, ok := dlist["results"]; ok {
+			// the domain isn't included in the response
+			sd.Domain = domain
+			d = &sd
+		} else {
+			err = errors.New("Unexpected response to SendingDomain")
+		}
+	} else {
+		err = res.ParseResponse()
+		if err == nil {
+			err = res.HTTPError()
+		}
+	}
+
+	return
+}
+
+// SendingDomains returns the sending domains matching the provided filters, such as "dkim_status" or
+// "ownership_verified", which may be nil.
+func (c *Client) SendingDomains(filters map[string]string) ([]SendingDomain, *Response, error) {
+	return c.SendingDomainsContext(context.Background(), filters)
+}
+
+// SendingDomainsContext is the same as SendingDomains, and it allows the caller to provide a context.
+func (c *Client) SendingDomainsContext(ctx context.Context, filters map[string]string) ([]SendingDomain, *Response, error) {
+	it := c.SendingDomainsIter(filters)
+	domains, err := it.Collect(ctx)
+	return domains, it.Response(), err
+}
+
+// SendingDomainsIter returns an Iterator over the sending domains matching the provided filters.
+func (c *Client) SendingDomainsIter(filters map[string]string) *Iterator[SendingDomain] {
+	path := fmt.Sprintf(SendingDomainsPathFormat, c.Config.ApiVersion)
+	return listPageIterator[SendingDomain](c, listHref(path, filters))
+}
+
+// SendingDomainChanges lists the fields changed by SendingDomainUpdate.
+// Nil fields are left as they are, and pointers to empty or false values clear them.
+type SendingDomainChanges struct {
+	TrackingDomain        *string `json:"tracking_domain,omitempty"`
+	DKIM                  *DKIM   `json:"dkim,omitempty"`
+	SharedWithSubaccounts *bool   `json:"shared_with_subaccounts,omitempty"`
+	IsDefaultBounceDomain *bool   `json:"is_default_bounce_domain,omitempty"`
+}
+
+// SendingDomainUpdate applies the provided changes to the named sending domain.
+func (c *Client) SendingDomainUpdate(domain string, changes *SendingDomainChanges) (*Response, error) {
+	return c.SendingDomainUpdateContext(context.Background(), domain, changes)
+}
+
+// SendingDomainUpdateContext is the same as SendingDomainUpdate, and it allows the caller to provide a context.
+func (c *Client) SendingDomainUpdateContext(ctx context.Context, domain string, changes *SendingDomainChanges) (*Response, error) {
+	if domain == "" {
+		return nil, errors.New("Update called with blank domain")
+	} else if changes == nil {
+		return nil, errors.New("Update called with nil SendingDomainChanges")
+	}
+
+	// Marshaling a static type won't fail
+	jsonBytes, _ := json.Marshal(changes)
+
+	path := fmt.Sprintf(SendingDomainsPathFormat, c.Config.ApiVersion)
+	return c.HttpPutJson(ctx, fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(domain)), jsonBytes)
+}
+
+// SendingDomainDelete deletes the sending domain with the provided name.
+func (c *Client) SendingDomainDelete(domain string) (*Response, error) {
+	return c.SendingDomainDeleteContext(context.Background(), domain)
+}
+
+// SendingDomainDeleteContext is the same as SendingDomainDelete, and it allows the caller to provide a context.
+func (c *Client) SendingDomainDeleteContext(ctx context.Context, domain string) (*Response, error) {
+	if domain == "" {
+		return nil, errors.New("Delete called with blank domain")
+	}
+
+	path := fmt.Sprintf(SendingDomainsPathFormat, c.Config.ApiVersion)
+	res, err := c.HttpDelete(ctx, fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(domain)))
+	if err != nil {
+		return res, err
+	}
+
+	// We get an empty response on success. If there are errors we get JSON.
+	if _, err = res.AssertJson(); err == nil {
+		if err = res.ParseResponse(); err != nil {
+			return res, err
+		}
+	}
+
+	return res, res.HTTPError()
+}
+
+// SendingDomainVerify requests verification of the sending domain with the provided name,
+// returning its updated status.
+func (c *Client) SendingDomainVerify(domain string, v *SendingDomainVerify) (*SendingDomainVerifyResult, *Response, error) {
+	return c.SendingDomainVerifyContext(context.Background(), domain, v)
+}
+
+// SendingDomainVerifyContext is the same as SendingDomainVerify, and it allows the caller to provide a context.
+func (c *Client) SendingDomainVerifyContext(ctx context.Context, domain string, v *SendingDomainVerify) (result *SendingDomainVerifyResult, res *Response, err error) {
+	if domain == "" {
+		err = errors.New("Verify called with blank domain")
+		return
+	} else if v == nil {
+		err = errors.New("Verify called with nil SendingDomainVerify")
+		return
+	}
+
+	// Marshaling a static type won't fail
+	jsonBytes, _ := json.Marshal(v)
+
+	path := fmt.Sprintf(SendingDomainsPathFormat, c.Config.ApiVersion)
+	res, err = c.HttpPost(ctx, fmt.Sprintf("%s%s/%s/verify", c.Config.BaseUrl, path, url.PathEscape(domain)), jsonBytes)
+	if err != nil {
+		return
+	}
+
+	var body []byte
+	if body, err = res.AssertJson(); err != nil {
+		return
+	}
+
+	if err = res.ParseResponse(); err != nil {
+		return
+	}
+
+	if Is2XX(res.HTTP.StatusCode) {
+		verified := map[string]*SendingDomainVerifyResult{}
+		if err = json.Unmarshal(body, &verified); err != nil {
+			err = errors.Wrap(err, "parsing api response")
+		} else if result = verified["results"]; result == nil {
+			err = errors.New("Unexpected response to SendingDomain verification (results)")
+		}
+	} else {
+		err = res.HTTPError()
+	}
+
+	return
+}
@@ -0,0 +1,258 @@
+package gosparkpost_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/pkg/errors"
+)
+
+const testDKIMPublic = "MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQC+W6scd3XWwvC/hPRksfDYFi3ztgyS9OSqnnjtNQeDdTSD1DRx/xFar2wjmzxp2+SnJ5pspaF77VZveN3P/HVmXZVghr3asoV9WBx/uW1nDIUxU35L4juXiTwsMAbgMyh3NqIKTNKyMDy4P8vpEhtH1iv/BrwMdBjHDVCycB8WnwIDAQAB"
+
+func TestSendingDomainCreate(t *testing.T) {
+	var res200 = loadTestFile(t, "test/json/sending_domain_create_200.json")
+	var generate = true
+
+	for idx, test := range []struct {
+		in     *sp.SendingDomain
+		err    error
+		status int
+		body   string
+		json   string
+		out    *sp.SendingDomain
+	}{
+		{nil, errors.New("Create called with nil SendingDomain"), 0, "", "", nil},
+		{&sp.SendingDomain{}, errors.New("SendingDomain requires a non-empty Domain"), 0, "", "", nil},
+
+		{&sp.SendingDomain{Domain: "example1.com"},
+			errors.New("Unexpected response to SendingDomain creation (results)"), 200, "",
+			strings.Replace(res200, `"results"`, `"foo"`, 1), nil},
+		{&sp.SendingDomain{Domain: "example1.com"},
+			errors.New(`[{"message":"error","code":"","description":""}]`), 400, "",
+			`{"errors":[{"message":"error"}]}`, nil},
+
+		{&sp.SendingDomain{Domain: "example1.com", GenerateDKIM: &generate, DKIMKeyLength: 1024}, nil, 200,
+			`{"domain":"example1.com","generate_dkim":true,"dkim_key_length":1024}`, res200,
+			&sp.SendingDomain{Domain: "example1.com", GenerateDKIM: &generate, DKIMKeyLength: 1024,
+				DKIM: &sp.DKIM{Public: testDKIMPublic, Selector: "hello_selector",
+					SigningDomain: "example1.com", Headers: "from:to:subject:date"}}},
+		{&sp.SendingDomain{Domain: "example1.com", DKIM: &sp.DKIM{Private: "private", Public: testDKIMPublic, Selector: "hello_selector"}}, nil, 200,
+			`{"domain":"example1.com","dkim":{"private":"private","public":"` + testDKIMPublic + `","selector":"hello_selector"}}`, res200,
+			&sp.SendingDomain{Domain: "example1.com",
+				DKIM: &sp.DKIM{Private: "private", Public: testDKIMPublic, Selector: "hello_selector",
+					SigningDomain: "example1.com", Headers: "from:to:subject:date"}}},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestRequestResponseBuilderFormat(t, "POST", test.status, sp.SendingDomainsPathFormat, test.body, test.json)
+
+		_, err := testClient.SendingDomainCreate(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("SendingDomainCreate[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("SendingDomainCreate[%d] => err %q want %q", idx, err, test.err)
+		} else if test.out != nil && !reflect.DeepEqual(test.in, test.out) {
+			t.Errorf("SendingDomainCreate[%d] => got/want:\n%+v\n%+v", idx, test.in.DKIM, test.out.DKIM)
+		}
+	}
+}
+
+func TestSendingDomain(t *testing.T) {
+	var res200 = loadTestFile(t, "test/json/sending_domain_200.json")
+	var domain200 = &sp.SendingDomain{
+		Domain:         "example1.com",
+		TrackingDomain: "click.example1.com",
+		Status: &sp.SendingDomainStatus{
+			OwnershipVerified:         true,
+			SPFStatus:                 "unverified",
+			AbuseAtStatus:             "unverified",
+			DKIMStatus:                "valid",
+			CNAMEStatus:               "valid",
+			MXStatus:                  "unverified",
+			ComplianceStatus:          "valid",
+			PostmasterAtStatus:        "unverified",
+			VerificationMailboxStatus: "unverified",
+		},
+		DKIM: &sp.DKIM{Public: testDKIMPublic, Selector: "hello_selector",
+			SigningDomain: "example1.com", Headers: "from:to:subject:date"},
+	}
+
+	for idx, test := range []struct {
+		in     string
+		err    error
+		status int
+		json   string
+		out    *sp.SendingDomain
+	}{
+		{"", errors.New("SendingDomain called with blank domain"), 0, "", nil},
+		{"example1.com", errors.New("unexpected end of JSON input"), 200, "{", nil},
+		{"example1.com", errors.New("Unexpected response to SendingDomain"), 200, `{"foo":{}}`, nil},
+		{"example1.com", errors.New(`[{"message":"resource not found","code":"1600","description":""}]`), 404,
+			`{"errors":[{"message":"resource not found","code":"1600"}]}`, nil},
+
+		{"example1.com", nil, 200, res200, domain200},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "GET", test.status, sp.SendingDomainsPathFormat+"/"+test.in, test.json)
+
+		d, _, err := testClient.SendingDomain(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("SendingDomain[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("SendingDomain[%d] => err %q want %q", idx, err, test.err)
+		} else if test.out != nil && !reflect.DeepEqual(d, test.out) {
+			t.Errorf("SendingDomain[%d] => got/want:\n%+v\n%+v", idx, d, test.out)
+		}
+	}
+}
+
+func TestSendingDomains(t *testing.T) {
+	var res200 = loadTestFile(t, "test/json/sending_domains_200.json")
+
+	for idx, test := range []struct {
+		filters map[string]string
+		err     error
+		status  int
+		json    string
+		out     []string
+	}{
+		{nil, errors.New("unmarshaling response: unexpected end of JSON input"), 200, `{"results":[`, nil},
+		{nil, errors.New(`[{"message":"error","code":"","description":""}]`), 400,
+			`{"errors":[{"message":"error"}]}`, nil},
+
+		{nil, nil, 200, res200, []string{"example1.com", "example2.com"}},
+		{map[string]string{"cname_status": "pending"}, nil, 200, res200, []string{"example1.com", "example2.com"}},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "GET", test.status, sp.SendingDomainsPathFormat, test.json)
+
+		domains, res, err := testClient.SendingDomains(test.filters)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("SendingDomains[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("SendingDomains[%d] => err %q want %q", idx, err, test.err)
+		} else if err == nil {
+			var names []string
+			for _, d := range domains {
+				names = append(names, d.Domain)
+			}
+			if !reflect.DeepEqual(names, test.out) {
+				t.Errorf("SendingDomains[%d] => got/want:\n%q\n%q", idx, names, test.out)
+			} else if domains[1].SubaccountID != 123 || !domains[1].SharedWithSubaccounts || domains[1].Status.CNAMEStatus != "pending" {
+				t.Errorf("SendingDomains[%d] => %+v", idx, domains[1])
+			}
+			if want := test.filters["cname_status"]; res.HTTP.Request.URL.Query().Get("cname_status") != want {
+				t.Errorf("SendingDomains[%d] => query %q", idx, res.HTTP.Request.URL.RawQuery)
+			}
+		}
+	}
+}
+
+func TestSendingDomainUpdate(t *testing.T) {
+	tracking, empty, yes, no := "click.example1.com", "", true, false
+	for idx, test := range []struct {
+		domain  string
+		changes *sp.SendingDomainChanges
+		err     error
+		status  int
+		body    string
+		json    string
+	}{
+		{"", &sp.SendingDomainChanges{}, errors.New("Update called with blank domain"), 0, "", ""},
+		{"example1.com", nil, errors.New("Update called with nil SendingDomainChanges"), 0, "", ""},
+		{"example1.com", &sp.SendingDomainChanges{},
+			errors.New(`[{"message":"error","code":"","description":""}]`), 400, "",
+			`{"errors":[{"message":"error"}]}`},
+
+		{"example1.com", &sp.SendingDomainChanges{TrackingDomain: &tracking, IsDefaultBounceDomain: &yes}, nil, 200,
+			`{"tracking_domain":"click.example1.com","is_default_bounce_domain":true}`,
+			`{"results":{"message":"Successfully Updated Domain.","domain":"example1.com"}}`},
+		{"example1.com", &sp.SendingDomainChanges{TrackingDomain: &empty, SharedWithSubaccounts: &no}, nil, 200,
+			`{"tracking_domain":"","shared_with_subaccounts":false}`,
+			`{"results":{"message":"Successfully Updated Domain.","domain":"example1.com"}}`},
+		{"example1.com", &sp.SendingDomainChanges{DKIM: &sp.DKIM{Selector: "scph0118"}}, nil, 200,
+			`{"dkim":{"selector":"scph0118"}}`,
+			`{"results":{"message":"Successfully Updated Domain.","domain":"example1.com"}}`},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestRequestResponseBuilderFormat(t, "PUT", test.status, sp.SendingDomainsPathFormat+"/example1.com", test.body, test.json)
+
+		_, err := testClient.SendingDomainUpdate(test.domain, test.changes)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("SendingDomainUpdate[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("SendingDomainUpdate[%d] => err %q want %q", idx, err, test.err)
+		}
+	}
+}
+
+func TestSendingDomainDelete(t *testing.T) {
+	for idx, test := range []struct {
+		in     string
+		err    error
+		status int
+		json   string
+	}{
+		{"", errors.New("Delete called with blank domain"), 0, ""},
+		{"example1.com", errors.New(`[{"message":"resource not found","code":"1600","description":""}]`), 404,
+			`{"errors":[{"message":"resource not found","code":"1600"}]}`},
+		{"example1.com", nil, 204, ""},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "DELETE", test.status, sp.SendingDomainsPathFormat+"/example1.com", test.json)
+
+		_, err := testClient.SendingDomainDelete(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("SendingDomainDelete[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("SendingDomainDelete[%d] => err %q want %q", idx, err, test.err)
+		}
+	}
+}
+
+func TestSendingDomainVerify(t *testing.T) {
+	var res200 = loadTestFile(t, "test/json/sending_domain_verify_200.json")
+
+	for idx, test := range []struct {
+		domain string
+		in     *sp.SendingDomainVerify
+		err    error
+		status int
+		body   string
+		json   string
+	}{
+		{"", &sp.SendingDomainVerify{}, errors.New("Verify called with blank domain"), 0, "", ""},
+		{"example1.com", nil, errors.New("Verify called with nil SendingDomainVerify"), 0, "", ""},
+		{"example1.com", &sp.SendingDomainVerify{DKIMVerify: true},
+			errors.New("Unexpected response to SendingDomain verification (results)"), 200, "", `{"foo":{}}`},
+		{"example1.com", &sp.SendingDomainVerify{DKIMVerify: true},
+			errors.New(`[{"message":"error","code":"","description":""}]`), 400, "",
+			`{"errors":[{"message":"error"}]}`},
+
+		{"example1.com", &sp.SendingDomainVerify{DKIMVerify: true, SPFVerify: true, CNAMEVerify: true}, nil, 200,
+			`{"dkim_verify":true,"spf_verify":true,"cname_verify":true}`, res200},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestRequestResponseBuilderFormat(t, "POST", test.status, sp.SendingDomainsPathFormat+"/example1.com/verify", test.body, test.json)
+
+		result, _, err := testClient.SendingDomainVerify(test.domain, test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("SendingDomainVerify[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("SendingDomainVerify[%d] => err %q want %q", idx, err, test.err)
+		} else if err == nil {
+			if !result.OwnershipVerified || result.DKIMStatus != "valid" || result.CNAMEStatus != "invalid" ||
+				result.DNS == nil || result.DNS.SPFRecord != "v=spf1 a mx ~all" ||
+				result.DNS.CNAMEError != "CNAME record for example1.com not found" {
+				t.Errorf("SendingDomainVerify[%d] => %+v %+v", idx, result, result.DNS)
+			}
+		}
+	}
+}
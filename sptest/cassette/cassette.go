@@ -0,0 +1,366 @@
+// Package cassette records HTTP interactions with the SparkPost API to a file, and replays them later.
+//
+// Record once, against the real API, using a Recorder as the transport of Client.Client:
+//
+//	rec := cassette.NewRecorder("testdata/events.json", http.DefaultTransport)
+//	client.Client = &http.Client{Transport: rec}
+//	// ... make requests ...
+//	err := rec.Save()
+//
+// Then replay the recording in CI, without credentials or network access:
+//
+//	rep, err := cassette.NewReplayer("testdata/events.json")
+//	client.Client = &http.Client{Transport: rep}
+//
+// API keys are never written to disk, and email addresses are replaced with placeholders derived from a hash
+// of the address. Replayed requests are scrubbed the same way before they're matched, so code under test can
+// keep using real addresses. Pagination links in recorded responses are stored relative to the API host,
+// so following a chain of pages using EventsPage.Next replays correctly whatever BaseUrl is configured.
+package cassette
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// ScrubDomain is the domain of the placeholder addresses that replace email addresses in recordings.
+const ScrubDomain = "scrubbed.invalid"
+
+// ErrUnmatched is returned by Replayer when a request doesn't match any remaining recorded interaction.
+var ErrUnmatched = errors.New("cassette: no recorded interaction matches request")
+
+// droppedHeaders are response headers that aren't recorded, since they vary or aren't useful on replay.
+var droppedHeaders = []string{"Date", "Set-Cookie", "Content-Length"}
+
+// Cassette is a list of recorded interactions, in the order they happened.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Interaction is a request and the response it got.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Request is a scrubbed HTTP request. URL is relative to the API host, with its query parameters sorted.
+type Request struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response is a scrubbed HTTP response.
+type Response struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Load reads a cassette from the specified file.
+func Load(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading cassette")
+	}
+	c := &Cassette{}
+	if err = json.Unmarshal(data, c); err != nil {
+		return nil, errors.Wrap(err, "parsing cassette")
+	}
+	return c, nil
+}
+
+// Save writes the cassette to the specified file, replacing any existing contents.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encoding cassette")
+	}
+	return errors.Wrap(os.WriteFile(path, append(data, '\n'), 0644), "writing cassette")
+}
+
+// Recorder is an http.RoundTripper that passes requests on to Transport, recording each interaction.
+// It's safe for concurrent use.
+type Recorder struct {
+	// Transport makes the actual requests. If nil, http.DefaultTransport is used.
+	Transport http.RoundTripper
+
+	path     string
+	mu       sync.Mutex
+	cassette Cassette
+}
+
+// NewRecorder returns a Recorder that saves to the specified file.
+func NewRecorder(path string, transport http.RoundTripper) *Recorder {
+	return &Recorder{Transport: transport, path: path}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading request body")
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		return res, err
+	}
+	resBody, err := readBody(&res.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading response body")
+	}
+
+	s := scrubber{secret: req.Header.Get("Authorization")}
+	header := res.Header.Clone()
+	for _, h := range droppedHeaders {
+		header.Del(h)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: Request{
+			Method: req.Method,
+			URL:    s.url(req.URL),
+			Body:   s.text(reqBody),
+		},
+		Response: Response{
+			StatusCode: res.StatusCode,
+			Header:     header,
+			Body:       s.responseBody(resBody),
+		},
+	})
+	return res, nil
+}
+
+// Interactions returns the number of interactions recorded so far.
+func (r *Recorder) Interactions() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.cassette.Interactions)
+}
+
+// Save writes everything recorded so far to the Recorder's file.
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.cassette.Save(r.path)
+}
+
+// Replayer is an http.RoundTripper that serves responses from a cassette, without making any requests.
+// Each recorded interaction is used once; when a request matches several, they're used in recorded order.
+// Requests match when their method, url and body are the same once scrubbed. JSON bodies are compared
+// ignoring formatting and key order. It's safe for concurrent use.
+type Replayer struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	used     []bool
+}
+
+// NewReplayer returns a Replayer serving the interactions in the specified file.
+func NewReplayer(path string) (*Replayer, error) {
+	c, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewCassetteReplayer(c), nil
+}
+
+// NewCassetteReplayer returns a Replayer serving the interactions in c.
+func NewCassetteReplayer(c *Cassette) *Replayer {
+	return &Replayer{cassette: c, used: make([]bool, len(c.Interactions))}
+}
+
+// RoundTrip implements http.RoundTripper.
+// Requests that don't match a recorded interaction fail with an error wrapping ErrUnmatched.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "reading request body")
+	}
+	s := scrubber{}
+	want := Request{Method: req.Method, URL: s.url(req.URL), Body: s.text(reqBody)}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || !matches(in.Request, want) {
+			continue
+		}
+		r.used[i] = true
+		header := in.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, errors.Wrapf(ErrUnmatched, "%s %s (%d of %d interactions used)",
+		want.Method, want.URL, r.usedCount(), len(r.used))
+}
+
+// usedCount returns the number of interactions that have been replayed. The caller must hold r.mu.
+func (r *Replayer) usedCount() int {
+	n := 0
+	for _, used := range r.used {
+		if used {
+			n++
+		}
+	}
+	return n
+}
+
+// Unused returns the recorded interactions that haven't been replayed yet.
+// Tests can check it's empty to make sure the code under test made every recorded request.
+func (r *Replayer) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*Interaction
+	for i, in := range r.cassette.Interactions {
+		if !r.used[i] {
+			out = append(out, in)
+		}
+	}
+	return out
+}
+
+// matches returns true if a recorded request is equivalent to a scrubbed incoming one.
+func matches(recorded, req Request) bool {
+	if !strings.EqualFold(recorded.Method, req.Method) || recorded.URL != req.URL {
+		return false
+	}
+	if recorded.Body == req.Body {
+		return true
+	}
+	var a, b interface{}
+	if json.Unmarshal([]byte(recorded.Body), &a) != nil || json.Unmarshal([]byte(req.Body), &b) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(a)
+	jb, _ := json.Marshal(b)
+	return bytes.Equal(ja, jb)
+}
+
+// readBody reads and replaces *body, so it can still be read by whoever it's passed on to.
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, err
+}
+
+var emailRegexp = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+
+// Placeholder returns the address that replaces email in recordings.
+// The same address always gets the same placeholder, and placeholders are left as they are.
+func Placeholder(email string) string {
+	lower := strings.ToLower(email)
+	if strings.HasSuffix(lower, "@"+ScrubDomain) {
+		return email
+	}
+	sum := sha256.Sum256([]byte(lower))
+	return "rcpt-" + hex.EncodeToString(sum[:6]) + "@" + ScrubDomain
+}
+
+// scrubber removes sensitive values from recorded data.
+type scrubber struct {
+	// secret is removed wherever it appears, in addition to email addresses.
+	secret string
+}
+
+// text replaces email addresses and the secret in a string.
+func (s scrubber) text(in []byte) string {
+	out := emailRegexp.ReplaceAllStringFunc(string(in), Placeholder)
+	if s.secret != "" {
+		out = strings.ReplaceAll(out, s.secret, "[REDACTED]")
+	}
+	return out
+}
+
+// url returns the path and sorted query of u, with addresses scrubbed from both.
+func (s scrubber) url(u *url.URL) string {
+	path := &url.URL{Path: s.text([]byte(u.Path))}
+	out := path.EscapedPath()
+	q := u.Query()
+	for k, vs := range q {
+		for i, v := range vs {
+			vs[i] = s.text([]byte(v))
+		}
+		q[k] = vs
+	}
+	if len(q) > 0 {
+		out += "?" + q.Encode()
+	}
+	return out
+}
+
+// responseBody scrubs a response body. Pagination links are rewritten the same way as request urls,
+// so that following them during replay produces requests that match what was recorded.
+func (s scrubber) responseBody(in []byte) string {
+	var wrapper map[string]json.RawMessage
+	if json.Unmarshal(in, &wrapper) != nil || wrapper["links"] == nil {
+		return s.text(in)
+	}
+	var links []map[string]interface{}
+	if json.Unmarshal(wrapper["links"], &links) != nil {
+		return s.text(in)
+	}
+	for _, link := range links {
+		href, ok := link["href"].(string)
+		if !ok {
+			continue
+		}
+		if u, err := url.Parse(href); err == nil {
+			link["href"] = s.url(u)
+		}
+	}
+	raw, err := marshal(links)
+	if err != nil {
+		return s.text(in)
+	}
+	wrapper["links"] = raw
+	out, err := marshal(wrapper)
+	if err != nil {
+		return s.text(in)
+	}
+	return s.text(out)
+}
+
+// marshal is json.Marshal without escaping the ampersands in urls.
+func marshal(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(buf.Bytes(), "\n"), nil
+}
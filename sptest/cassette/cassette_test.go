@@ -0,0 +1,151 @@
+package cassette_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/SparkPost/gosparkpost/events"
+	"github.com/SparkPost/gosparkpost/sptest"
+	"github.com/SparkPost/gosparkpost/sptest/cassette"
+	"github.com/pkg/errors"
+)
+
+// searchAll follows the Next chain of a message events search, returning every event.
+func searchAll(t *testing.T, client *sp.Client, params map[string]string) []events.Event {
+	page := &sp.EventsPage{Params: params}
+	if _, err := client.MessageEventsSearch(page); err != nil {
+		t.Fatalf("MessageEventsSearch => %v", err)
+	}
+	var all []events.Event
+	for page != nil {
+		all = append(all, page.Events...)
+		var err error
+		if page, _, err = page.Next(); err != nil {
+			t.Fatalf("EventsPage.Next => %v", err)
+		}
+	}
+	return all
+}
+
+func TestRecordReplay(t *testing.T) {
+	srv := sptest.NewServer()
+	defer srv.Close()
+
+	now := time.Now().Truncate(time.Second)
+	var evs []events.Event
+	for i := 0; i < 5; i++ {
+		evs = append(evs, &events.Delivery{
+			EventCommon: events.EventCommon{Type: "delivery"},
+			Recipient:   "gopher@example.com",
+			Timestamp:   events.Timestamp(now.Add(-time.Duration(i) * time.Minute)),
+		})
+	}
+	if err := srv.AddEvents(evs...); err != nil {
+		t.Fatalf("AddEvents => %v", err)
+	}
+	params := map[string]string{
+		"recipients": "gopher@example.com",
+		"from":       now.Add(-time.Hour).UTC().Format("2006-01-02T15:04"),
+		"per_page":   "2",
+	}
+
+	// record
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	client := srv.Client()
+	rec := cassette.NewRecorder(path, client.Client.Transport)
+	client.Client = &http.Client{Transport: rec}
+	if got := searchAll(t, client, params); len(got) != 5 {
+		t.Fatalf("record => %d events, want 5", len(got))
+	}
+	if _, err := client.SuppressionUpsert([]sp.WritableSuppressionEntry{
+		{Recipient: "gopher@example.com", Type: "transactional"},
+	}); err != nil {
+		t.Fatalf("SuppressionUpsert => %v", err)
+	}
+	if _, err := client.SuppressionDelete("gopher@example.com"); err != nil {
+		t.Fatalf("SuppressionDelete => %v", err)
+	}
+	if err := rec.Save(); err != nil {
+		t.Fatalf("Recorder.Save => %v", err)
+	}
+	if rec.Interactions() != 5 {
+		t.Errorf("Recorder.Interactions => %d, want 5", rec.Interactions())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{sptest.APIKey, "gopher@example.com", "gopher%40example.com", srv.URL} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	// replay, against a server that doesn't exist
+	rep, err := cassette.NewReplayer(path)
+	if err != nil {
+		t.Fatalf("NewReplayer => %v", err)
+	}
+	replay := &sp.Client{Client: &http.Client{Transport: rep}}
+	replay.Init(&sp.Config{BaseUrl: "https://replay.invalid", ApiKey: "not-a-real-key"})
+
+	got := searchAll(t, replay, params)
+	if len(got) != 5 {
+		t.Fatalf("replay => %d events, want 5", len(got))
+	}
+	want := cassette.Placeholder("gopher@example.com")
+	for i, e := range got {
+		if d, ok := e.(*events.Delivery); !ok || d.Recipient != want {
+			t.Errorf("replay[%d] => %+v, want recipient %s", i, e, want)
+		}
+	}
+	if _, err = replay.SuppressionUpsert([]sp.WritableSuppressionEntry{
+		{Recipient: "Gopher@Example.com", Type: "transactional"},
+	}); err != nil {
+		t.Errorf("SuppressionUpsert => %v", err)
+	}
+	if _, err = replay.SuppressionDelete("GOPHER@example.com"); err != nil {
+		t.Errorf("SuppressionDelete => %v", err)
+	}
+	if unused := rep.Unused(); len(unused) != 0 {
+		t.Errorf("Replayer.Unused => %d interactions", len(unused))
+	}
+
+	// every interaction has been used, so repeating a request fails
+	_, err = replay.SuppressionDelete("gopher@example.com")
+	if !errors.Is(err, cassette.ErrUnmatched) {
+		t.Errorf("SuppressionDelete => err %v, want %v", err, cassette.ErrUnmatched)
+	}
+}
+
+func TestMatchJSONBody(t *testing.T) {
+	header := http.Header{"Content-Type": {"application/json"}}
+	rep := cassette.NewCassetteReplayer(&cassette.Cassette{Interactions: []*cassette.Interaction{
+		{
+			Request:  cassette.Request{Method: "PUT", URL: "/api/v1/suppression-list", Body: `{"recipients":[{"type":"transactional","recipient":"` + cassette.Placeholder("a@example.com") + `"}]}`},
+			Response: cassette.Response{StatusCode: 200, Header: header, Body: `{"results":{"message":"first"}}`},
+		},
+		{
+			Request:  cassette.Request{Method: "PUT", URL: "/api/v1/suppression-list", Body: `{"recipients":[{"recipient":"` + cassette.Placeholder("a@example.com") + `","type":"transactional"}]}`},
+			Response: cassette.Response{StatusCode: 200, Header: header, Body: `{"results":{"message":"second"}}`},
+		},
+	}})
+	client := &sp.Client{Client: &http.Client{Transport: rep}}
+	client.Init(&sp.Config{BaseUrl: "https://replay.invalid", ApiKey: "key"})
+
+	for idx, want := range []string{"first", "second"} {
+		res, err := client.SuppressionUpsert([]sp.WritableSuppressionEntry{{Recipient: "a@example.com", Type: "transactional"}})
+		if err != nil {
+			t.Fatalf("SuppressionUpsert[%d] => %v", idx, err)
+		}
+		if !strings.Contains(string(res.Body), want) {
+			t.Errorf("SuppressionUpsert[%d] => %s, want %s", idx, res.Body, want)
+		}
+	}
+}
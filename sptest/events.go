@@ -0,0 +1,230 @@
+package sptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/SparkPost/gosparkpost/events"
+)
+
+// messageEventsTimeFormat is the format of the from and to parameters accepted by the Message Events API.
+const messageEventsTimeFormat = "2006-01-02T15:04"
+
+// messageEventFilters maps Message Events API parameters to the event fields they filter on.
+var messageEventFilters = map[string]string{
+	"events":           "type",
+	"recipients":       "rcpt_to",
+	"campaign_ids":     "campaign_id",
+	"template_ids":     "template_id",
+	"transmission_ids": "transmission_id",
+	"message_ids":      "message_id",
+	"subaccounts":      "subaccount_id",
+	"bounce_classes":   "bounce_class",
+}
+
+// AddEvents stores events to be returned by the Message Events API.
+// Each event needs its type and a timestamp set.
+func (s *Server) AddEvents(evs ...events.Event) error {
+	raws := make([]json.RawMessage, 0, len(evs))
+	for _, e := range evs {
+		raw, err := json.Marshal(e)
+		if err != nil {
+			return err
+		}
+		raws = append(raws, raw)
+	}
+	return s.AddEventsJSON(raws...)
+}
+
+// AddEventsJSON stores events, in the JSON format used by the Message Events API.
+func (s *Server) AddEventsJSON(raws ...json.RawMessage) error {
+	for _, raw := range raws {
+		var fields map[string]interface{}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return err
+		}
+		if _, ok := eventTime(fields); !ok {
+			return fmt.Errorf("event has no valid timestamp: %s", raw)
+		}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events = append(s.events, raws...)
+	return nil
+}
+
+// eventTime returns the timestamp of a decoded event.
+func eventTime(fields map[string]interface{}) (time.Time, bool) {
+	var ts events.Timestamp
+	raw, err := json.Marshal(fields["timestamp"])
+	if err != nil || ts.UnmarshalJSON(raw) != nil {
+		return time.Time{}, false
+	}
+	return time.Time(ts), true
+}
+
+func (s *Server) messageEventsHandler(w http.ResponseWriter, r *http.Request, rest []string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+	if len(rest) == 2 && rest[0] == "events" && rest[1] == "samples" {
+		samples := []map[string]interface{}{}
+		for _, etype := range strings.Split(r.URL.Query().Get("events"), ",") {
+			if etype != "" {
+				samples = append(samples, map[string]interface{}{"type": etype, "timestamp": time.Now().Unix()})
+			}
+		}
+		writeResults(w, samples)
+		return
+	} else if len(rest) != 0 {
+		notFound(w, r.URL.Path)
+		return
+	}
+
+	q := r.URL.Query()
+	from, to := time.Now().Add(-24*time.Hour), time.Now()
+	var errs []sp.SPError
+	for _, p := range []struct {
+		name string
+		t    *time.Time
+	}{{"from", &from}, {"to", &to}} {
+		if v := q.Get(p.name); v != "" {
+			t, err := time.Parse(messageEventsTimeFormat, v)
+			if err != nil {
+				errs = append(errs, sp.SPError{Message: fmt.Sprintf("%s must be in the format YYYY-MM-DDTHH:MM", p.name)})
+			}
+			*p.t = t
+		}
+	}
+	if from.After(to) {
+		errs = append(errs, sp.SPError{Message: "from must be before to"})
+	}
+	if len(errs) > 0 {
+		writeErrors(w, http.StatusBadRequest, errs...)
+		return
+	}
+
+	type match struct {
+		raw json.RawMessage
+		ts  time.Time
+	}
+	s.mu.Lock()
+	matches := []match{}
+	for _, raw := range s.events {
+		var fields map[string]interface{}
+		json.Unmarshal(raw, &fields)
+		ts, _ := eventTime(fields)
+		if ts.Before(from) || !ts.Before(to.Add(time.Minute)) {
+			continue
+		}
+		if !matchesFilters(fields, q) {
+			continue
+		}
+		matches = append(matches, match{raw, ts})
+	}
+	s.mu.Unlock()
+
+	// newest first, like the real thing
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].ts.After(matches[j].ts) })
+
+	perPage, err := strconv.Atoi(q.Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	page, err := strconv.Atoi(q.Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	lastPage := (len(matches) + perPage - 1) / perPage
+	if lastPage < 1 {
+		lastPage = 1
+	}
+
+	results := []json.RawMessage{}
+	for i := (page - 1) * perPage; i < page*perPage && i < len(matches); i++ {
+		results = append(results, matches[i].raw)
+	}
+
+	pageLink := func(rel string, n int) map[string]string {
+		params := url.Values{}
+		for k, v := range q {
+			params[k] = v
+		}
+		params.Set("page", strconv.Itoa(n))
+		params.Set("per_page", strconv.Itoa(perPage))
+		return map[string]string{"href": r.URL.Path + "?" + params.Encode(), "rel": rel}
+	}
+	links := []map[string]string{}
+	if page < lastPage {
+		links = append(links, pageLink("next", page+1))
+	}
+	if page > 1 {
+		links = append(links, pageLink("previous", page-1), pageLink("first", 1))
+	}
+	if page < lastPage {
+		links = append(links, pageLink("last", lastPage))
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"results":     results,
+		"links":       links,
+		"total_count": len(matches),
+	})
+}
+
+// matchesFilters returns true if the event matches every list-valued filter parameter.
+func matchesFilters(fields map[string]interface{}, q url.Values) bool {
+	for param, field := range messageEventFilters {
+		list := q.Get(param)
+		if list == "" {
+			continue
+		}
+		value := fmt.Sprint(fields[field])
+		found := false
+		for _, want := range strings.Split(list, ",") {
+			if strings.EqualFold(strings.TrimSpace(want), value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// AddMetrics stores items to be returned by the Deliverability Metrics API.
+func (s *Server) AddMetrics(items ...sp.MetricItem) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.metrics = append(s.metrics, items...)
+}
+
+func (s *Server) metricsHandler(w http.ResponseWriter, r *http.Request, rest []string) {
+	if r.Method != http.MethodGet {
+		methodNotAllowed(w)
+		return
+	}
+	from := r.URL.Query().Get("from")
+	if from == "" {
+		writeErrors(w, http.StatusBadRequest, sp.SPError{Message: "from is required"})
+		return
+	} else if _, err := time.Parse(messageEventsTimeFormat, from); err != nil {
+		writeErrors(w, http.StatusBadRequest, sp.SPError{Message: "from must be in the format YYYY-MM-DDTHH:MM"})
+		return
+	}
+
+	s.mu.Lock()
+	items := append([]sp.MetricItem{}, s.metrics...)
+	s.mu.Unlock()
+	writeResults(w, items)
+}
@@ -0,0 +1,235 @@
+package sptest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+)
+
+// defaultPerPage is the page size used when a request doesn't specify per_page.
+const defaultPerPage = 1000
+
+func (s *Server) recipientListsHandler(w http.ResponseWriter, r *http.Request, rest []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		rl := &sp.RecipientList{}
+		if !decode(w, r, rl) {
+			return
+		}
+		if rl.ID == "" {
+			rl.ID = s.newID()
+		} else if _, exists := s.recipientLists[rl.ID]; exists {
+			writeErrors(w, http.StatusConflict, sp.SPError{Message: "resource conflict", Code: "1602",
+				Description: fmt.Sprintf("Recipient list (id: %s) already exists", rl.ID)})
+			return
+		}
+		accepted := len(rl.Recipients)
+		rl.Accepted = &accepted
+		s.recipientLists[rl.ID] = rl
+		s.listOrder = append(s.listOrder, rl.ID)
+		writeResults(w, map[string]interface{}{
+			"id":                        rl.ID,
+			"name":                      rl.Name,
+			"total_accepted_recipients": accepted,
+			"total_rejected_recipients": 0,
+		})
+
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		lists := []sp.RecipientList{}
+		for _, id := range s.listOrder {
+			rl := *s.recipientLists[id]
+			rl.Recipients = nil
+			lists = append(lists, rl)
+		}
+		writeResults(w, lists)
+
+	case len(rest) == 1:
+		rl, ok := s.recipientLists[rest[0]]
+		if !ok {
+			notFound(w, "recipient list "+rest[0])
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			out := *rl
+			if r.URL.Query().Get("show_recipients") != "true" {
+				out.Recipients = nil
+			}
+			writeResults(w, out)
+		case http.MethodPut:
+			updated := &sp.RecipientList{}
+			if !decode(w, r, updated) {
+				return
+			}
+			updated.ID = rl.ID
+			accepted := len(updated.Recipients)
+			updated.Accepted = &accepted
+			s.recipientLists[rl.ID] = updated
+			writeResults(w, map[string]interface{}{"id": rl.ID, "total_accepted_recipients": accepted})
+		case http.MethodDelete:
+			delete(s.recipientLists, rl.ID)
+			for i, id := range s.listOrder {
+				if id == rl.ID {
+					s.listOrder = append(s.listOrder[:i], s.listOrder[i+1:]...)
+					break
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w)
+		}
+
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// Suppressions returns the current suppression list, sorted by recipient.
+func (s *Server) Suppressions() []sp.SuppressionEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]sp.SuppressionEntry, 0, len(s.suppressions))
+	for _, email := range s.sortedSuppressions() {
+		out = append(out, *s.suppressions[email])
+	}
+	return out
+}
+
+// sortedSuppressions returns the keys of the suppression list in order. The caller must hold s.mu.
+func (s *Server) sortedSuppressions() []string {
+	emails := make([]string, 0, len(s.suppressions))
+	for email := range s.suppressions {
+		emails = append(emails, email)
+	}
+	sort.Strings(emails)
+	return emails
+}
+
+func (s *Server) suppressionsHandler(w http.ResponseWriter, r *http.Request, rest []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		s.searchSuppressions(w, r)
+
+	case len(rest) == 0 && r.Method == http.MethodPut:
+		var wrapper struct {
+			Recipients []sp.WritableSuppressionEntry `json:"recipients"`
+		}
+		if !decode(w, r, &wrapper) {
+			return
+		}
+		now := time.Now().UTC().Format(time.RFC3339)
+		for _, in := range wrapper.Recipients {
+			if in.Type != "transactional" && in.Type != "non_transactional" {
+				invalid(w, "type must be one of: transactional, non_transactional")
+				return
+			}
+			key := strings.ToLower(in.Recipient)
+			entry, ok := s.suppressions[key]
+			if !ok {
+				entry = &sp.SuppressionEntry{Recipient: in.Recipient, Source: "Manually Added", Created: now}
+				s.suppressions[key] = entry
+			}
+			entry.Type = in.Type
+			entry.Description = in.Description
+			entry.Updated = now
+			if in.Type == "transactional" {
+				entry.Transactional = true
+			} else {
+				entry.NonTransactional = true
+			}
+		}
+		writeResults(w, map[string]string{"message": "Suppression List successfully updated"})
+
+	case len(rest) == 1:
+		key := strings.ToLower(rest[0])
+		entry, ok := s.suppressions[key]
+		if !ok {
+			writeErrors(w, http.StatusNotFound, sp.SPError{Message: "Recipient could not be found"})
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"results":     []*sp.SuppressionEntry{entry},
+				"total_count": 1,
+			})
+		case http.MethodDelete:
+			delete(s.suppressions, key)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w)
+		}
+
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// searchSuppressions returns one page of the suppression list, with a cursor link to the next.
+// The caller must hold s.mu.
+func (s *Server) searchSuppressions(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	perPage, err := strconv.Atoi(q.Get("per_page"))
+	if err != nil || perPage < 1 {
+		perPage = defaultPerPage
+	}
+	cursor := q.Get("cursor")
+	if cursor == "initial" {
+		cursor = ""
+	}
+	types := q.Get("types")
+
+	matches := []*sp.SuppressionEntry{}
+	for _, email := range s.sortedSuppressions() {
+		entry := s.suppressions[email]
+		if types == "transactional" && !entry.Transactional ||
+			types == "non_transactional" && !entry.NonTransactional {
+			continue
+		}
+		matches = append(matches, entry)
+	}
+
+	start := 0
+	if cursor != "" {
+		start = len(matches)
+		for i, entry := range matches {
+			if strings.ToLower(entry.Recipient) >= cursor {
+				start = i
+				break
+			}
+		}
+	}
+	end := start + perPage
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	links := []map[string]string{}
+	if end < len(matches) {
+		next := url.Values{}
+		for k, v := range q {
+			next[k] = v
+		}
+		next.Set("cursor", strings.ToLower(matches[end].Recipient))
+		next.Set("per_page", strconv.Itoa(perPage))
+		links = append(links, map[string]string{"href": r.URL.Path + "?" + next.Encode(), "rel": "next"})
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"results":     matches[start:end],
+		"links":       links,
+		"total_count": len(matches),
+	})
+}
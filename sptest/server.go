@@ -0,0 +1,252 @@
+// Package sptest provides an in-process fake of the SparkPost API, for testing code that uses gosparkpost.
+//
+// The fake keeps state in memory, so for example a template created using one call may be
+// retrieved using another. It covers transmissions, templates, recipient lists, the suppression list,
+// webhooks, subaccounts, message events and deliverability metrics.
+// Errors and latency may be injected to exercise failure handling.
+//
+//	srv := sptest.NewServer()
+//	defer srv.Close()
+//	client := srv.Client()
+//	id, _, err := client.Send(tx)
+package sptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+)
+
+// APIKey is the only API key accepted by the fake server. Requests using any other key get a 401.
+const APIKey = "sptest-0000000000000000000000000000000000"
+
+// Fault describes an error response to inject in place of the normal one.
+type Fault struct {
+	// Method and Path select which requests fail. An empty Method matches every method,
+	// and Path matches any request path it is a prefix of.
+	Method string
+	Path   string
+	// Status is the HTTP status code returned. Errors are returned as JSON if present.
+	Status int
+	Errors []sp.SPError
+	// Times is how many requests fail before the fault is removed. Zero means it's permanent.
+	Times int
+}
+
+// Request is a summary of a request received by the server.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   []byte
+}
+
+// Server is a fake SparkPost API. It's safe for concurrent use.
+type Server struct {
+	// URL is the base url of the server, suitable for Config.BaseUrl.
+	URL string
+
+	srv *httptest.Server
+	mux *http.ServeMux
+
+	mu             sync.Mutex
+	latency        time.Duration
+	faults         []*Fault
+	requests       []Request
+	nextID         int
+	transmissions  []*sp.Transmission
+	templates      map[string]*templateVersions
+	templateOrder  []string
+	recipientLists map[string]*sp.RecipientList
+	listOrder      []string
+	suppressions   map[string]*sp.SuppressionEntry
+	webhooks       map[string]*sp.WebhookItem
+	webhookOrder   []string
+	webhookStatus  map[string][]sp.WebhookStatus
+	subaccounts    []*sp.Subaccount
+	events         []json.RawMessage
+	metrics        []sp.MetricItem
+}
+
+// NewServer starts a fake SparkPost API server. Call Close when finished with it.
+func NewServer() *Server {
+	s := &Server{
+		mux:            http.NewServeMux(),
+		templates:      map[string]*templateVersions{},
+		recipientLists: map[string]*sp.RecipientList{},
+		suppressions:   map[string]*sp.SuppressionEntry{},
+		webhooks:       map[string]*sp.WebhookItem{},
+		webhookStatus:  map[string][]sp.WebhookStatus{},
+	}
+	s.route(sp.TransmissionsPathFormat, s.transmissionsHandler)
+	s.route(sp.TemplatesPathFormat, s.templatesHandler)
+	s.route(sp.RecipientListsPathFormat, s.recipientListsHandler)
+	s.route(sp.SuppressionListsPathFormat, s.suppressionsHandler)
+	s.route(sp.WebhooksPathFormat, s.webhooksHandler)
+	s.route(sp.SubaccountsPathFormat, s.subaccountsHandler)
+	s.route(sp.MessageEventsPathFormat, s.messageEventsHandler)
+	s.route(sp.MetricsPathFormat, s.metricsHandler)
+
+	s.srv = httptest.NewTLSServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.srv.URL
+	return s
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+// Client returns a Client configured to use the server.
+func (s *Server) Client() *sp.Client {
+	client := &sp.Client{Client: s.srv.Client()}
+	// Init only fails for non-https urls
+	client.Init(&sp.Config{BaseUrl: s.URL, ApiKey: APIKey})
+	return client
+}
+
+// SetLatency delays every response by the specified duration.
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// AddFault causes matching requests to fail. Faults are checked in the order they were added.
+func (s *Server) AddFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes all injected faults.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns every request received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// route registers a handler for everything under the provided path format.
+func (s *Server) route(pathFormat string, h func(w http.ResponseWriter, r *http.Request, rest []string)) {
+	prefix := fmt.Sprintf(pathFormat, 1)
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		rest := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, prefix), "/"), "/")
+		if len(rest) == 1 && rest[0] == "" {
+			rest = nil
+		}
+		h(w, r, rest)
+	}
+	s.mux.HandleFunc(prefix, handler)
+	s.mux.HandleFunc(prefix+"/", handler)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(strings.NewReader(string(body)))
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: body})
+	latency := s.latency
+	fault := s.matchFault(r)
+	s.mu.Unlock()
+
+	if latency > 0 {
+		select {
+		case <-time.After(latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if r.Header.Get("Authorization") != APIKey {
+		writeErrors(w, http.StatusUnauthorized, sp.SPError{Message: "Unauthorized."})
+		return
+	}
+	if fault != nil {
+		if len(fault.Errors) > 0 {
+			writeErrors(w, fault.Status, fault.Errors...)
+		} else {
+			w.WriteHeader(fault.Status)
+		}
+		return
+	}
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// matchFault returns the first fault matching the request, if any. The caller must hold s.mu.
+func (s *Server) matchFault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if f.Method != "" && !strings.EqualFold(f.Method, r.Method) {
+			continue
+		}
+		if !strings.HasPrefix(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// newID returns a unique numeric id, which is also a valid transmission id.
+// The caller must hold s.mu.
+func (s *Server) newID() string {
+	s.nextID++
+	return fmt.Sprintf("%d", 84537445295705700+s.nextID)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeResults(w http.ResponseWriter, results interface{}) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{"results": results})
+}
+
+func writeErrors(w http.ResponseWriter, status int, errs ...sp.SPError) {
+	writeJSON(w, status, map[string]interface{}{"errors": errs})
+}
+
+func notFound(w http.ResponseWriter, what string) {
+	writeErrors(w, http.StatusNotFound, sp.SPError{Message: "resource not found", Code: "1600", Description: what + " does not exist"})
+}
+
+func methodNotAllowed(w http.ResponseWriter) {
+	writeErrors(w, http.StatusMethodNotAllowed, sp.SPError{Message: "method not allowed"})
+}
+
+func invalid(w http.ResponseWriter, description string) {
+	writeErrors(w, http.StatusUnprocessableEntity, sp.SPError{Message: "invalid data format/type", Code: "1300", Description: description})
+}
+
+// decode reads a JSON request body, writing an error response and returning false if that fails.
+func decode(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeErrors(w, http.StatusBadRequest, sp.SPError{Message: "invalid json", Description: err.Error()})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,300 @@
+package sptest_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/SparkPost/gosparkpost/events"
+	"github.com/SparkPost/gosparkpost/sptest"
+	"github.com/pkg/errors"
+)
+
+func TestTransmissions(t *testing.T) {
+	srv := sptest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	if _, err := client.SuppressionUpsert([]sp.WritableSuppressionEntry{
+		{Recipient: "suppressed@example.com", Type: "non_transactional"},
+	}); err != nil {
+		t.Fatalf("SuppressionUpsert => %v", err)
+	}
+
+	content := sp.Content{From: "from@example.com", Subject: "sptest", Text: "hello"}
+	id, _, err := client.Send(&sp.Transmission{
+		CampaignID: "sptest",
+		Recipients: []string{"a@example.com", "suppressed@example.com"},
+		Content:    content,
+	})
+	if err != nil {
+		t.Fatalf("Send => %v", err)
+	}
+
+	_, _, err = client.Send(&sp.Transmission{Recipients: []string{"suppressed@example.com"}, Content: content})
+	if !errors.Is(err, sp.ErrSuppressedRecipient) {
+		t.Errorf("Send to suppressed recipient => err %v, want %v", err, sp.ErrSuppressedRecipient)
+	}
+
+	tx := &sp.Transmission{ID: id}
+	if _, err = client.Transmission(tx); err != nil {
+		t.Fatalf("Transmission => %v", err)
+	} else if tx.CampaignID != "sptest" || *tx.TotalRecipients != 1 {
+		t.Errorf("Transmission => %+v", tx)
+	}
+
+	list, _, err := client.Transmissions(&sp.Transmission{CampaignID: "sptest"})
+	if err != nil || len(list) != 1 {
+		t.Errorf("Transmissions => %d, %v", len(list), err)
+	}
+
+	if _, err = client.TransmissionDelete(tx); err != nil {
+		t.Errorf("TransmissionDelete => %v", err)
+	}
+	if _, err = client.Transmission(tx); !errors.Is(err, sp.ErrNotFound) {
+		t.Errorf("Transmission after delete => err %v, want %v", err, sp.ErrNotFound)
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	srv := sptest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	tmpl := &sp.Template{Name: "Welcome Mail", Content: sp.Content{From: "from@example.com", Subject: "Hi {{name}}", Text: "hello"}}
+	id, _, err := client.TemplateCreate(tmpl)
+	if err != nil {
+		t.Fatalf("TemplateCreate => %v", err)
+	} else if id != "welcome-mail" {
+		t.Errorf("TemplateCreate => id %q", id)
+	}
+
+	// drafts can't be used until they're published
+	_, _, err = client.Send(&sp.Transmission{Recipients: []string{"a@example.com"},
+		Content: map[string]string{"template_id": id}})
+	if !errors.Is(err, sp.ErrValidation) {
+		t.Errorf("Send with draft template => err %v, want %v", err, sp.ErrValidation)
+	}
+	if _, err = client.TemplateGet(&sp.Template{ID: id}, false); !errors.Is(err, sp.ErrNotFound) {
+		t.Errorf("TemplateGet published => err %v, want %v", err, sp.ErrNotFound)
+	}
+
+	if _, err = client.TemplatePublish(id); err != nil {
+		t.Fatalf("TemplatePublish => %v", err)
+	}
+	got := &sp.Template{ID: id}
+	if _, err = client.TemplateGet(got, false); err != nil || !got.Published || got.Content.Subject != "Hi {{name}}" {
+		t.Errorf("TemplateGet => %+v, %v", got, err)
+	}
+
+	tmpl.ID = id
+	tmpl.Content.Subject = "Hello {{name}}"
+	if _, err = client.TemplateUpdate(tmpl, false); err != nil {
+		t.Fatalf("TemplateUpdate => %v", err)
+	}
+	draft := &sp.Template{ID: id}
+	if _, err = client.TemplateGet(draft, true); err != nil || draft.Content.Subject != "Hello {{name}}" {
+		t.Errorf("TemplateGet draft => %+v, %v", draft, err)
+	}
+
+	res, err := client.TemplatePreview(id, &sp.PreviewOptions{SubstitutionData: map[string]interface{}{"name": "Gopher"}})
+	if err != nil {
+		t.Fatalf("TemplatePreview => %v", err)
+	} else if results, _ := res.Results.(map[string]interface{}); results["subject"] != "Hi Gopher" {
+		t.Errorf("TemplatePreview => %v", res.Results)
+	}
+
+	list, _, err := client.Templates()
+	if err != nil || len(list) != 1 || !list[0].Published {
+		t.Errorf("Templates => %+v, %v", list, err)
+	}
+	if _, err = client.TemplateDelete(id); err != nil {
+		t.Errorf("TemplateDelete => %v", err)
+	}
+}
+
+func TestSuppressionPaging(t *testing.T) {
+	srv := sptest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	entries := []sp.WritableSuppressionEntry{}
+	for _, r := range []string{"e@example.com", "a@example.com", "d@example.com", "c@example.com", "b@example.com"} {
+		entries = append(entries, sp.WritableSuppressionEntry{Recipient: r, Type: "transactional"})
+	}
+	if _, err := client.SuppressionUpsert(entries); err != nil {
+		t.Fatalf("SuppressionUpsert => %v", err)
+	}
+
+	page := &sp.SuppressionPage{Params: map[string]string{"per_page": "2", "cursor": "initial"}}
+	if _, err := client.SuppressionSearch(page); err != nil {
+		t.Fatalf("SuppressionSearch => %v", err)
+	}
+	var seen []string
+	for page != nil {
+		for _, e := range page.Results {
+			seen = append(seen, e.Recipient)
+		}
+		if page.TotalCount != 5 {
+			t.Errorf("SuppressionSearch => total %d, want 5", page.TotalCount)
+		}
+		var err error
+		if page, _, err = page.Next(); err != nil {
+			t.Fatalf("SuppressionPage.Next => %v", err)
+		}
+	}
+	if len(seen) != 5 || seen[0] != "a@example.com" || seen[4] != "e@example.com" {
+		t.Errorf("SuppressionSearch => %v", seen)
+	}
+
+	if _, err := client.SuppressionDelete("c@example.com"); err != nil {
+		t.Errorf("SuppressionDelete => %v", err)
+	}
+	retrieved := &sp.SuppressionPage{}
+	if res, err := client.SuppressionRetrieve("c@example.com", retrieved); err != nil || res.HTTP.StatusCode != 404 {
+		t.Errorf("SuppressionRetrieve => %v, want a 404", err)
+	} else if len(retrieved.Errors) != 1 || retrieved.Errors[0].Message != "Recipient could not be found" {
+		t.Errorf("SuppressionRetrieve => errors %+v", retrieved.Errors)
+	}
+	if got := len(srv.Suppressions()); got != 4 {
+		t.Errorf("Suppressions => %d entries, want 4", got)
+	}
+}
+
+func TestRecipientListsWebhooksSubaccounts(t *testing.T) {
+	srv := sptest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	listID, _, err := client.RecipientListCreate(&sp.RecipientList{ID: "list", Name: "List",
+		Recipients: []sp.Recipient{{Address: "a@example.com"}, {Address: sp.Address{Email: "b@example.com"}}}})
+	if err != nil || listID != "list" {
+		t.Fatalf("RecipientListCreate => %q, %v", listID, err)
+	}
+	if lists, _, err := client.RecipientLists(); err != nil || len(lists) != 1 || *lists[0].Accepted != 2 {
+		t.Errorf("RecipientLists => %+v, %v", lists, err)
+	}
+	if _, _, err = client.Send(&sp.Transmission{Recipients: map[string]string{"list_id": listID},
+		Content: sp.Content{From: "from@example.com", Subject: "s", Text: "t"}}); err != nil {
+		t.Errorf("Send to list => %v", err)
+	}
+
+	srv.SetWebhookStatus("missing", nil)
+	hooks := &sp.WebhookListWrapper{}
+	if _, err = client.Webhooks(hooks); err != nil || len(hooks.Results) != 0 {
+		t.Errorf("Webhooks => %+v, %v", hooks, err)
+	}
+
+	sub := &sp.Subaccount{Name: "sub"}
+	if _, err = client.SubaccountCreate(sub); err != nil || sub.ID != 1 || sub.ShortKey == "" {
+		t.Fatalf("SubaccountCreate => %+v, %v", sub, err)
+	}
+	sub.Status = "suspended"
+	if _, err = client.SubaccountUpdate(sub); err != nil {
+		t.Errorf("SubaccountUpdate => %v", err)
+	}
+	if got, _, err := client.Subaccount(1); err != nil || got.Status != "suspended" {
+		t.Errorf("Subaccount => %+v, %v", got, err)
+	}
+	if subs, _, err := client.Subaccounts(); err != nil || len(subs) != 1 {
+		t.Errorf("Subaccounts => %+v, %v", subs, err)
+	}
+}
+
+func TestMessageEvents(t *testing.T) {
+	srv := sptest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	now := time.Now().Truncate(time.Second)
+	var evs []events.Event
+	for i := 0; i < 5; i++ {
+		evs = append(evs, &events.Delivery{
+			EventCommon: events.EventCommon{Type: "delivery"},
+			CampaignID:  "sptest",
+			Recipient:   "a@example.com",
+			Timestamp:   events.Timestamp(now.Add(-time.Duration(i) * time.Minute)),
+		})
+	}
+	evs = append(evs, &events.Bounce{EventCommon: events.EventCommon{Type: "bounce"},
+		Recipient: "b@example.com", Timestamp: events.Timestamp(now)})
+	if err := srv.AddEvents(evs...); err != nil {
+		t.Fatalf("AddEvents => %v", err)
+	}
+
+	page := &sp.EventsPage{Params: map[string]string{
+		"events":   "delivery",
+		"from":     now.Add(-time.Hour).UTC().Format("2006-01-02T15:04"),
+		"per_page": "2",
+	}}
+	if _, err := client.MessageEventsSearch(page); err != nil {
+		t.Fatalf("MessageEventsSearch => %v", err)
+	}
+	count := 0
+	for page != nil {
+		for _, e := range page.Events {
+			if _, ok := e.(*events.Delivery); !ok {
+				t.Errorf("MessageEventsSearch => unexpected event %T", e)
+			}
+			count++
+		}
+		var err error
+		if page, _, err = page.Next(); err != nil {
+			t.Fatalf("EventsPage.Next => %v", err)
+		}
+	}
+	if count != 5 {
+		t.Errorf("MessageEventsSearch => %d events, want 5", count)
+	}
+
+	srv.AddMetrics(sp.MetricItem{CountInjected: 5, CountDelivered: 5})
+	m := &sp.Metrics{Params: map[string]string{"from": "2017-01-01T00:00", "metrics": "count_injected"}}
+	if _, err := client.QueryMetrics(m); err != nil || len(m.Results) != 1 || m.Results[0].CountDelivered != 5 {
+		t.Errorf("QueryMetrics => %+v, %v", m, err)
+	}
+}
+
+func TestFaults(t *testing.T) {
+	srv := sptest.NewServer()
+	defer srv.Close()
+	client := srv.Client()
+
+	srv.AddFault(sptest.Fault{Method: "GET", Path: "/api/v1/templates", Status: 503, Times: 1})
+	if _, _, err := client.Templates(); !errors.Is(err, sp.ErrServer) {
+		t.Errorf("Templates with fault => err %v, want %v", err, sp.ErrServer)
+	}
+	if _, _, err := client.Templates(); err != nil {
+		t.Errorf("Templates after fault => %v", err)
+	}
+
+	srv.AddFault(sptest.Fault{Path: "/api/v1/subaccounts", Status: 429,
+		Errors: []sp.SPError{{Message: "Too many requests"}}})
+	client.Config.Retry = &sp.RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond}
+	if _, _, err := client.Subaccounts(); !errors.Is(err, sp.ErrRateLimited) {
+		t.Errorf("Subaccounts with fault => err %v, want %v", err, sp.ErrRateLimited)
+	}
+	requests := 0
+	for _, r := range srv.Requests() {
+		if r.Path == "/api/v1/subaccounts" {
+			requests++
+		}
+	}
+	if requests != 3 {
+		t.Errorf("Requests => %d subaccount requests, want 3", requests)
+	}
+	srv.ClearFaults()
+
+	srv.SetLatency(100 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, _, err := client.SubaccountsContext(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Subaccounts with latency => err %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	client.Config.ApiKey = "wrong"
+	srv.SetLatency(0)
+	if _, _, err := client.Subaccounts(); !errors.Is(err, sp.ErrUnauthorized) {
+		t.Errorf("Subaccounts with bad key => err %v, want %v", err, sp.ErrUnauthorized)
+	}
+}
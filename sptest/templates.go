@@ -0,0 +1,191 @@
+package sptest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+)
+
+// templateVersions holds the draft and published versions of a template, either of which may be nil.
+type templateVersions struct {
+	draft     *sp.Template
+	published *sp.Template
+}
+
+// latest returns the most relevant version for listing.
+func (tv *templateVersions) latest() *sp.Template {
+	if tv.draft != nil {
+		return tv.draft
+	}
+	return tv.published
+}
+
+var nonIDChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+func (s *Server) templatesHandler(w http.ResponseWriter, r *http.Request, rest []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		t := &sp.Template{}
+		if !decode(w, r, t) {
+			return
+		}
+		if t.ID == "" {
+			t.ID = strings.Trim(nonIDChars.ReplaceAllString(strings.ToLower(t.Name), "-"), "-")
+		}
+		if t.ID == "" {
+			invalid(w, "template id or name is required")
+			return
+		}
+		if _, exists := s.templates[t.ID]; exists {
+			writeErrors(w, http.StatusConflict, sp.SPError{Message: "resource conflict", Code: "1602",
+				Description: fmt.Sprintf("Template (id: %s) already exists", t.ID)})
+			return
+		}
+		t.LastUpdate = time.Now().UTC()
+		tv := &templateVersions{}
+		if t.Published {
+			tv.published = t
+		} else {
+			tv.draft = t
+		}
+		s.templates[t.ID] = tv
+		s.templateOrder = append(s.templateOrder, t.ID)
+		writeResults(w, map[string]string{"id": t.ID})
+
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		list := []sp.Template{}
+		for _, id := range s.templateOrder {
+			tv := s.templates[id]
+			latest := tv.latest()
+			list = append(list, sp.Template{
+				ID:          id,
+				Name:        latest.Name,
+				Description: latest.Description,
+				Published:   tv.published != nil,
+				LastUpdate:  latest.LastUpdate,
+			})
+		}
+		writeResults(w, list)
+
+	case len(rest) >= 1:
+		tv, ok := s.templates[rest[0]]
+		if !ok {
+			notFound(w, "template "+rest[0])
+			return
+		}
+		if len(rest) == 2 && rest[1] == "preview" && r.Method == http.MethodPost {
+			s.previewTemplate(w, r, tv)
+			return
+		} else if len(rest) != 1 {
+			methodNotAllowed(w)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			version := tv.published
+			if r.URL.Query().Get("draft") == "true" {
+				version = tv.draft
+			}
+			if version == nil {
+				notFound(w, "requested version of template "+rest[0])
+				return
+			}
+			writeResults(w, version)
+
+		case http.MethodPut:
+			s.updateTemplate(w, r, tv, rest[0])
+
+		case http.MethodDelete:
+			delete(s.templates, rest[0])
+			for i, id := range s.templateOrder {
+				if id == rest[0] {
+					s.templateOrder = append(s.templateOrder[:i], s.templateOrder[i+1:]...)
+					break
+				}
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+
+		default:
+			methodNotAllowed(w)
+		}
+
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// updateTemplate publishes the draft, or replaces the draft or published version. The caller must hold s.mu.
+func (s *Server) updateTemplate(w http.ResponseWriter, r *http.Request, tv *templateVersions, id string) {
+	body, _ := io.ReadAll(r.Body)
+	fields := map[string]json.RawMessage{}
+	t := &sp.Template{}
+	if json.Unmarshal(body, &fields) != nil || json.Unmarshal(body, t) != nil {
+		invalid(w, "invalid template json")
+		return
+	}
+
+	_, hasContent := fields["content"]
+	if t.Published && !hasContent {
+		// publish the current draft
+		if tv.draft == nil {
+			invalid(w, "template "+id+" has no draft to publish")
+			return
+		}
+		tv.published, tv.draft = tv.draft, nil
+		tv.published.Published = true
+		tv.published.LastUpdate = time.Now().UTC()
+		writeResults(w, map[string]string{"id": id})
+		return
+	}
+
+	t.ID = id
+	t.LastUpdate = time.Now().UTC()
+	if r.URL.Query().Get("update_published") == "true" {
+		t.Published = true
+		tv.published = t
+	} else {
+		t.Published = false
+		tv.draft = t
+	}
+	writeResults(w, map[string]string{"id": id})
+}
+
+var substitution = regexp.MustCompile(`{{\s*([A-Za-z0-9_]+)\s*}}`)
+
+// previewTemplate renders the template, replacing simple {{variable}} references with substitution data.
+func (s *Server) previewTemplate(w http.ResponseWriter, r *http.Request, tv *templateVersions) {
+	opts := &sp.PreviewOptions{}
+	if !decode(w, r, opts) {
+		return
+	}
+	t := tv.published
+	if t == nil || r.URL.Query().Get("draft") == "true" {
+		t = tv.latest()
+	}
+	render := func(in string) string {
+		return substitution.ReplaceAllStringFunc(in, func(m string) string {
+			key := substitution.FindStringSubmatch(m)[1]
+			if v, ok := opts.SubstitutionData[key]; ok {
+				return fmt.Sprint(v)
+			}
+			return ""
+		})
+	}
+	writeResults(w, sp.Content{
+		Subject: render(t.Content.Subject),
+		HTML:    render(t.Content.HTML),
+		Text:    render(t.Content.Text),
+		From:    t.Content.From,
+		ReplyTo: t.Content.ReplyTo,
+	})
+}
@@ -0,0 +1,160 @@
+package sptest
+
+import (
+	"net/http"
+	"strings"
+
+	sp "github.com/SparkPost/gosparkpost"
+)
+
+// Transmissions returns every transmission accepted by the server, in the order they were sent.
+func (s *Server) Transmissions() []sp.Transmission {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]sp.Transmission, len(s.transmissions))
+	for i, t := range s.transmissions {
+		out[i] = *t
+	}
+	return out
+}
+
+func (s *Server) transmissionsHandler(w http.ResponseWriter, r *http.Request, rest []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		s.createTransmission(w, r)
+
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		campaign, byCampaign := r.URL.Query()["campaign_id"]
+		template, byTemplate := r.URL.Query()["template_id"]
+		list := []sp.Transmission{}
+		for _, t := range s.transmissions {
+			if byCampaign && t.CampaignID != campaign[0] {
+				continue
+			}
+			if byTemplate && contentTemplateID(t.Content) != template[0] {
+				continue
+			}
+			list = append(list, *t)
+		}
+		writeResults(w, list)
+
+	case len(rest) == 1:
+		idx := -1
+		for i, t := range s.transmissions {
+			if t.ID == rest[0] {
+				idx = i
+			}
+		}
+		if idx < 0 {
+			notFound(w, "transmission "+rest[0])
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeResults(w, map[string]interface{}{"transmission": s.transmissions[idx]})
+		case http.MethodDelete:
+			s.transmissions = append(s.transmissions[:idx], s.transmissions[idx+1:]...)
+			writeJSON(w, http.StatusOK, map[string]interface{}{})
+		default:
+			methodNotAllowed(w)
+		}
+
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+// createTransmission accepts a transmission, rejecting suppressed recipients. The caller must hold s.mu.
+func (s *Server) createTransmission(w http.ResponseWriter, r *http.Request) {
+	t := &sp.Transmission{}
+	if !decode(w, r, t) {
+		return
+	}
+	if t.Content == nil || t.Recipients == nil {
+		invalid(w, "recipients and content are required")
+		return
+	}
+	if id := contentTemplateID(t.Content); id != "" {
+		if tmpl, ok := s.templates[id]; !ok || tmpl.published == nil {
+			invalid(w, "template "+id+" is not published")
+			return
+		}
+	}
+
+	emails, ok := s.recipientEmails(t.Recipients)
+	if !ok {
+		invalid(w, "recipients must be a list or contain a valid list_id")
+		return
+	}
+	accepted, rejected := 0, 0
+	for _, email := range emails {
+		if _, suppressed := s.suppressions[strings.ToLower(email)]; suppressed {
+			rejected++
+		} else {
+			accepted++
+		}
+	}
+	if accepted == 0 {
+		writeErrors(w, http.StatusBadRequest, sp.SPError{
+			Message:     "Message generation rejected",
+			Code:        "1902",
+			Description: "recipient address was suppressed due to customer policy",
+		})
+		return
+	}
+
+	t.ID = s.newID()
+	t.State = "submitted"
+	t.TotalRecipients = &accepted
+	s.transmissions = append(s.transmissions, t)
+	writeResults(w, map[string]interface{}{
+		"id":                        t.ID,
+		"total_accepted_recipients": accepted,
+		"total_rejected_recipients": rejected,
+	})
+}
+
+// recipientEmails returns the addresses of inline recipients, or of a stored recipient list.
+// The caller must hold s.mu.
+func (s *Server) recipientEmails(recipients interface{}) ([]string, bool) {
+	switch val := recipients.(type) {
+	case map[string]interface{}:
+		id, _ := val["list_id"].(string)
+		list, ok := s.recipientLists[id]
+		if !ok {
+			return nil, false
+		}
+		emails := make([]string, 0, len(list.Recipients))
+		for _, r := range list.Recipients {
+			if addr, err := sp.ParseAddress(r.Address); err == nil {
+				emails = append(emails, addr.Email)
+			}
+		}
+		return emails, true
+
+	case []interface{}:
+		emails := make([]string, 0, len(val))
+		for _, r := range val {
+			obj, _ := r.(map[string]interface{})
+			addr, err := sp.ParseAddress(obj["address"])
+			if err != nil {
+				return nil, false
+			}
+			emails = append(emails, addr.Email)
+		}
+		return emails, true
+	}
+	return nil, false
+}
+
+// contentTemplateID returns the stored template a transmission uses, if any.
+func contentTemplateID(content interface{}) string {
+	if obj, ok := content.(map[string]interface{}); ok {
+		id, _ := obj["template_id"].(string)
+		return id
+	}
+	return ""
+}
@@ -0,0 +1,194 @@
+package sptest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/SparkPost/gosparkpost/events"
+)
+
+// SetWebhookStatus sets the batch status returned for the specified webhook.
+func (s *Server) SetWebhookStatus(id string, status []sp.WebhookStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.webhookStatus[id] = status
+}
+
+// validWebhook checks the fields the API requires, writing an error response and returning false if they're invalid.
+func validWebhook(w http.ResponseWriter, wh *sp.WebhookItem) bool {
+	if wh.Name == "" || wh.Target == "" || len(wh.Events) == 0 {
+		invalid(w, "name, target and events are required")
+		return false
+	}
+	for _, e := range wh.Events {
+		if !events.ValidEventType(e) {
+			invalid(w, fmt.Sprintf("invalid event type [%s]", e))
+			return false
+		}
+	}
+	return true
+}
+
+func (s *Server) webhooksHandler(w http.ResponseWriter, r *http.Request, rest []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		list := []sp.WebhookItem{}
+		for _, id := range s.webhookOrder {
+			list = append(list, *s.webhooks[id])
+		}
+		writeResults(w, list)
+
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		wh := &sp.WebhookItem{}
+		if !decode(w, r, wh) || !validWebhook(w, wh) {
+			return
+		}
+		wh.ID = "webhook-" + s.newID()
+		s.webhooks[wh.ID] = wh
+		s.webhookOrder = append(s.webhookOrder, wh.ID)
+		writeResults(w, map[string]string{"id": wh.ID})
+
+	case len(rest) >= 1:
+		wh, ok := s.webhooks[rest[0]]
+		if !ok {
+			notFound(w, "webhook "+rest[0])
+			return
+		}
+		if len(rest) == 2 && rest[1] == "batch-status" && r.Method == http.MethodGet {
+			status := s.webhookStatus[wh.ID]
+			if status == nil {
+				status = []sp.WebhookStatus{}
+			}
+			writeResults(w, status)
+			return
+		} else if len(rest) == 2 && rest[1] == "validate" && r.Method == http.MethodPost {
+			writeResults(w, map[string]interface{}{
+				"msg":      "Test POST to endpoint succeeded",
+				"response": map[string]interface{}{"status": 200, "headers": map[string]string{}, "body": ""},
+			})
+			return
+		} else if len(rest) != 1 {
+			methodNotAllowed(w)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeResults(w, wh)
+		case http.MethodPut:
+			updated := &sp.WebhookItem{}
+			if !decode(w, r, updated) {
+				return
+			}
+			// Fields that aren't sent are left unchanged.
+			if updated.Name != "" {
+				wh.Name = updated.Name
+			}
+			if updated.Target != "" {
+				wh.Target = updated.Target
+			}
+			if len(updated.Events) > 0 {
+				wh.Events = updated.Events
+			}
+			if updated.AuthType != "" {
+				wh.AuthType = updated.AuthType
+				wh.AuthCredentials = updated.AuthCredentials
+				wh.AuthRequestDetails = updated.AuthRequestDetails
+			}
+			if updated.AuthToken != "" {
+				wh.AuthToken = updated.AuthToken
+			}
+			if !validWebhook(w, wh) {
+				return
+			}
+			writeResults(w, map[string]string{"id": wh.ID})
+		case http.MethodDelete:
+			delete(s.webhooks, wh.ID)
+			for i, id := range s.webhookOrder {
+				if id == wh.ID {
+					s.webhookOrder = append(s.webhookOrder[:i], s.webhookOrder[i+1:]...)
+					break
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			methodNotAllowed(w)
+		}
+
+	default:
+		methodNotAllowed(w)
+	}
+}
+
+func (s *Server) subaccountsHandler(w http.ResponseWriter, r *http.Request, rest []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		list := []sp.Subaccount{}
+		for _, sa := range s.subaccounts {
+			list = append(list, *sa)
+		}
+		writeResults(w, list)
+
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		sa := &sp.Subaccount{}
+		if !decode(w, r, sa) {
+			return
+		}
+		if sa.Name == "" {
+			invalid(w, "name is required")
+			return
+		}
+		sa.ID = len(s.subaccounts) + 1
+		sa.Status = "active"
+		sa.ComplianceStatus = "active"
+		key := fmt.Sprintf("%040d", sa.ID)
+		sa.ShortKey = key[len(key)-4:]
+		s.subaccounts = append(s.subaccounts, sa)
+		writeResults(w, map[string]interface{}{
+			"subaccount_id": sa.ID,
+			"key":           key,
+			"label":         sa.KeyLabel,
+			"short_key":     sa.ShortKey,
+		})
+
+	case len(rest) == 1:
+		id, err := strconv.Atoi(rest[0])
+		if err != nil || id < 1 || id > len(s.subaccounts) {
+			notFound(w, "subaccount "+rest[0])
+			return
+		}
+		sa := s.subaccounts[id-1]
+		switch r.Method {
+		case http.MethodGet:
+			writeResults(w, sa)
+		case http.MethodPut:
+			updated := &sp.Subaccount{}
+			if !decode(w, r, updated) {
+				return
+			}
+			if updated.Name != "" {
+				sa.Name = updated.Name
+			}
+			if updated.Status != "" {
+				sa.Status = updated.Status
+			}
+			if updated.IPPool != "" {
+				sa.IPPool = updated.IPPool
+			}
+			writeResults(w, map[string]string{"message": "Successfully updated subaccount information"})
+		default:
+			methodNotAllowed(w)
+		}
+
+	default:
+		methodNotAllowed(w)
+	}
+}
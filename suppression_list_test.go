@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strings"
 	"testing"
 
 	"encoding/json"
@@ -564,8 +565,7 @@ func mockRestBuilder(t *testing.T, method string, path string, mockResponse stri
 }
 
 func mockRestResponseBuilderFormat(t *testing.T, method string, status int, pathFormat string, mockResponse string) {
-	path := fmt.Sprintf(pathFormat, testClient.Config.ApiVersion)
-	mockRestResponseBuilder(t, method, status, path, mockResponse)
+	mockRestResponseBuilder(t, method, status, mockPath(pathFormat), mockResponse)
 }
 
 func mockRestResponseBuilder(t *testing.T, method string, status int, path string, mockResponse string) {
@@ -573,8 +573,16 @@ func mockRestResponseBuilder(t *testing.T, method string, status int, path strin
 }
 
 func mockRestRequestResponseBuilderFormat(t *testing.T, method string, status int, pathFormat string, expectedBody string, mockResponse string) {
-	path := fmt.Sprintf(pathFormat, testClient.Config.ApiVersion)
-	mockRestRequestResponseBuilder(t, method, status, path, expectedBody, mockResponse)
+	mockRestRequestResponseBuilder(t, method, status, mockPath(pathFormat), expectedBody, mockResponse)
+}
+
+// mockPath fills in the API version, leaving paths without a format verb as they are.
+// ServeMux rejects patterns containing the "%!(EXTRA ...)" Sprintf would add.
+func mockPath(pathFormat string) string {
+	if !strings.Contains(pathFormat, "%") {
+		return pathFormat
+	}
+	return fmt.Sprintf(pathFormat, testClient.Config.ApiVersion)
 }
 
 func mockRestRequestResponseBuilder(t *testing.T, method string, status int, path string, expectedBody string, mockResponse string) {
@@ -1,36 +1,33 @@
 package test
 
 import (
-	"fmt"
-	"os"
 	"strconv"
+
+	sp "github.com/SparkPost/gosparkpost"
 )
 
-// Pull in required config from the environment.
-// Return a map instead of an api.Config object to avoid import cycles.
+// Pull in required config from the environment, using sp.ConfigFromEnv.
+// Return a map, in the format accepted by sp.NewConfig.
 func LoadConfig() (map[string]string, error) {
-	cfg := map[string]string{}
-
-	baseUrl := os.Getenv("SPARKPOST_BASEURL")
-	if baseUrl == "" {
-		return nil, fmt.Errorf("Base URL not set in environment: SPARKPOST_BASEURL")
+	cfg, err := sp.ConfigFromEnv()
+	if err != nil {
+		return nil, err
 	}
-	cfg["baseurl"] = baseUrl
 
-	apiKey := os.Getenv("SPARKPOST_API_KEY")
-	if apiKey == "" {
-		return nil, fmt.Errorf("API Key not set in environment: SPARKPOST_API_KEY")
-	}
-	cfg["apikey"] = apiKey
-
-	apiVer := os.Getenv("SPARKPOST_APIVER")
-	if apiVer == "" {
-		apiVer = "1"
+	baseUrl := cfg.BaseUrl
+	if baseUrl == "" {
+		if baseUrl, err = cfg.Region.BaseUrl(); err != nil {
+			return nil, err
+		}
 	}
-	if _, err := strconv.Atoi(apiVer); err != nil {
-		return nil, fmt.Errorf("API Version must be an integer: SPARKPOST_APIVER")
+	apiVer := cfg.ApiVersion
+	if apiVer == 0 {
+		apiVer = 1
 	}
-	cfg["apiver"] = apiVer
 
-	return cfg, nil
+	return map[string]string{
+		"baseurl": baseUrl,
+		"apikey":  cfg.ApiKey,
+		"apiver":  strconv.Itoa(apiVer),
+	}, nil
 }
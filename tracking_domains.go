@@ -0,0 +1,307 @@
+package gosparkpost
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// TrackingDomainsPathFormat provides an easy way to fill out the path including the version.
+// https://developers.sparkpost.com/api/tracking-domains/
+// Requests are scoped to a subaccount using WithSubaccount.
+var TrackingDomainsPathFormat = "/api/v%d/tracking-domains"
+
+// TrackingCNAMETargets maps each Region to the host that tracking domains point to.
+var TrackingCNAMETargets = map[Region]string{
+	RegionUS: "spgo.io",
+	RegionEU: "eu.spgo.io",
+}
+
+// TrackingDomain is the JSON structure accepted by and returned from the SparkPost Tracking Domains API.
+// Status, Port and SubaccountID are read-only.
+type TrackingDomain struct {
+	Domain       string                `json:"domain,omitempty"`
+	Secure       bool                  `json:"secure"`
+	Default      bool                  `json:"default"`
+	Port         int                   `json:"port,omitempty"`
+	Status       *TrackingDomainStatus `json:"status,omitempty"`
+	SubaccountID int                   `json:"subaccount_id,omitempty"`
+}
+
+// TrackingDomainStatus contains the verification status of a tracking domain.
+type TrackingDomainStatus struct {
+	Verified         bool   `json:"verified"`
+	CNAMEStatus      string `json:"cname_status,omitempty"`
+	ComplianceStatus string `json:"compliance_status,omitempty"`
+}
+
+// TrackingDomainsFilter narrows the tracking domains returned by TrackingDomains.
+// Default selects only default (true) or non-default (false) domains, and Subaccounts
+// selects domains belonging to the listed subaccounts.
+type TrackingDomainsFilter struct {
+	Default     *bool
+	Subaccounts []int
+}
+
+// DNSRecord is a DNS record that must be published for SparkPost to use a domain.
+type DNSRecord struct {
+	Name  string
+	Type  string
+	Value string
+}
+
+// String returns the record in zone file format.
+func (r DNSRecord) String() string {
+	return fmt.Sprintf("%s. IN %s %s", strings.TrimSuffix(r.Name, "."), r.Type, r.Value)
+}
+
+// TrackingDomainCNAME returns the CNAME record that routes clicks and opens for domain to SparkPost,
+// in the Client's configured Region. Secure (HTTPS) tracking domains need a CDN with a certificate for
+// the domain; the record then belongs on the CDN's origin, and the domain points to the CDN instead.
+func (c *Client) TrackingDomainCNAME(domain string) (DNSRecord, error) {
+	region := c.Config.Region
+	if region == "" {
+		// infer the region from the base url, defaulting to the US
+		region = RegionUS
+		for r, u := range RegionBaseUrls {
+			if strings.EqualFold(strings.TrimSuffix(c.Config.BaseUrl, "/"), u) {
+				region = r
+			}
+		}
+	}
+	target, ok := TrackingCNAMETargets[Region(strings.ToLower(string(region)))]
+	if !ok {
+		return DNSRecord{}, errors.Errorf("unknown region %q", string(region))
+	} else if domain == "" {
+		return DNSRecord{}, errors.New("TrackingDomainCNAME called with blank domain")
+	}
+	return DNSRecord{Name: strings.TrimSuffix(domain, "."), Type: "CNAME", Value: target + "."}, nil
+}
+
+// TrackingDomainCreate registers the provided tracking domain. It must be verified before it's used.
+func (c *Client) TrackingDomainCreate(d *TrackingDomain) (*Response, error) {
+	return c.TrackingDomainCreateContext(context.Background(), d)
+}
+
+// TrackingDomainCreateContext is the same as TrackingDomainCreate, and it allows the caller to provide a context.
+func (c *Client) TrackingDomainCreateContext(ctx context.Context, d *TrackingDomain) (res *Response, err error) {
+	if d == nil {
+		err = errors.New("Create called with nil TrackingDomain")
+		return
+	} else if d.Domain == "" {
+		err = errors.New("TrackingDomain requires a non-empty Domain")
+		return
+	}
+
+	// Marshaling a static type won't fail
+	jsonBytes, _ := json.Marshal(map[string]interface{}{
+		"domain":  d.Domain,
+		"secure":  d.Secure,
+		"default": d.Default,
+	})
+
+	path := fmt.Sprintf(TrackingDomainsPathFormat, c.Config.ApiVersion)
+	res, err = c.HttpPost(ctx, c.Config.BaseUrl+path, jsonBytes)
+	if err != nil {
+		return
+	}
+
+	if _, err = res.AssertJson(); err != nil {
+		return
+	}
+
+	if err = res.ParseResponse(); err != nil {
+		return
+	}
+
+	err = res.HTTPError()
+	return
+}
+
+// TrackingDomain looks up the tracking domain with the provided name.
+func (c *Client) TrackingDomain(domain string) (*TrackingDomain, *Response, error) {
+	return c.TrackingDomainContext(context.Background(), domain)
+}
+
+// TrackingDomainContext is the same as TrackingDomain, and it allows the caller to provide a context.
+func (c *Client) TrackingDomainContext(ctx context.Context, domain string) (d *TrackingDomain, res *Response, err error) {
+	if domain == "" {
+		err = errors.New("TrackingDomain called with blank domain")
+		return
+	}
+
+	path := fmt.Sprintf(TrackingDomainsPathFormat, c.Config.ApiVersion)
+	res, err = c.HttpGet(ctx, fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(domain)))
+	if err != nil {
+		return
+	}
+
+	var body []byte
+	if body, err = res.AssertJson(); err != nil {
+		return
+	}
+
+	if Is2XX(res.HTTP.StatusCode) {
+		dlist := map[string]TrackingDomain{}
+		err = json.Unmarshal(body, &dlist)
+		if err != nil {
+		} else if td, ok := dlist["results"]; ok {
+			d = &td
+		} else {
+			err = errors.New("Unexpected response to TrackingDomain")
+		}
+	} else {
+		err = res.ParseResponse()
+		if err == nil {
+			err = res.HTTPError()
+		}
+	}
+
+	return
+}
+
+// TrackingDomains returns the tracking domains matching the provided filter, which may be nil.
+func (c *Client) TrackingDomains(f *TrackingDomainsFilter) ([]TrackingDomain, *Response, error) {
+	return c.TrackingDomainsContext(context.Background(), f)
+}
+
+// TrackingDomainsContext is the same as TrackingDomains, and it allows the caller to provide a context.
+func (c *Client) TrackingDomainsContext(ctx context.Context, f *TrackingDomainsFilter) ([]TrackingDomain, *Response, error) {
+	it := c.TrackingDomainsIter(f)
+	domains, err := it.Collect(ctx)
+	return domains, it.Response(), err
+}
+
+// TrackingDomainsIter returns an Iterator over the tracking domains matching the provided filter, which may be nil.
+func (c *Client) TrackingDomainsIter(f *TrackingDomainsFilter) *Iterator[TrackingDomain] {
+	params := map[string]string{}
+	if f != nil {
+		if f.Default != nil {
+			params["default"] = strconv.FormatBool(*f.Default)
+		}
+		if len(f.Subaccounts) > 0 {
+			ids := make([]string, len(f.Subaccounts))
+			for i, id := range f.Subaccounts {
+				ids[i] = strconv.Itoa(id)
+			}
+			params["subaccounts"] = strings.Join(ids, ",")
+		}
+	}
+	path := fmt.Sprintf(TrackingDomainsPathFormat, c.Config.ApiVersion)
+	return listPageIterator[TrackingDomain](c, listHref(path, params))
+}
+
+// TrackingDomainUpdate updates the Secure and Default settings of the tracking domain named by d.Domain.
+// Making a domain the default replaces the previous default.
+func (c *Client) TrackingDomainUpdate(d *TrackingDomain) (*Response, error) {
+	return c.TrackingDomainUpdateContext(context.Background(), d)
+}
+
+// TrackingDomainUpdateContext is the same as TrackingDomainUpdate, and it allows the caller to provide a context.
+func (c *Client) TrackingDomainUpdateContext(ctx context.Context, d *TrackingDomain) (*Response, error) {
+	if d == nil {
+		return nil, errors.New("Update called with nil TrackingDomain")
+	} else if d.Domain == "" {
+		return nil, errors.New("Update called with blank domain")
+	}
+
+	// Marshaling a static type won't fail
+	jsonBytes, _ := json.Marshal(map[string]bool{
+		"secure":  d.Secure,
+		"default": d.Default,
+	})
+
+	path := fmt.Sprintf(TrackingDomainsPathFormat, c.Config.ApiVersion)
+	return c.HttpPutJson(ctx, fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(d.Domain)), jsonBytes)
+}
+
+// TrackingDomainSetDefault makes the tracking domain with the provided name the default,
+// keeping its Secure setting.
+func (c *Client) TrackingDomainSetDefault(domain string) (*Response, error) {
+	return c.TrackingDomainSetDefaultContext(context.Background(), domain)
+}
+
+// TrackingDomainSetDefaultContext is the same as TrackingDomainSetDefault, and it allows the caller to provide a context.
+func (c *Client) TrackingDomainSetDefaultContext(ctx context.Context, domain string) (*Response, error) {
+	d, res, err := c.TrackingDomainContext(ctx, domain)
+	if err != nil {
+		return res, err
+	}
+	d.Domain = domain
+	d.Default = true
+	return c.TrackingDomainUpdateContext(ctx, d)
+}
+
+// TrackingDomainDelete deletes the tracking domain with the provided name.
+func (c *Client) TrackingDomainDelete(domain string) (*Response, error) {
+	return c.TrackingDomainDeleteContext(context.Background(), domain)
+}
+
+// TrackingDomainDeleteContext is the same as TrackingDomainDelete, and it allows the caller to provide a context.
+func (c *Client) TrackingDomainDeleteContext(ctx context.Context, domain string) (*Response, error) {
+	if domain == "" {
+		return nil, errors.New("Delete called with blank domain")
+	}
+
+	path := fmt.Sprintf(TrackingDomainsPathFormat, c.Config.ApiVersion)
+	res, err := c.HttpDelete(ctx, fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(domain)))
+	if err != nil {
+		return res, err
+	}
+
+	// We get an empty response on success. If there are errors we get JSON.
+	if _, err = res.AssertJson(); err == nil {
+		if err = res.ParseResponse(); err != nil {
+			return res, err
+		}
+	}
+
+	return res, res.HTTPError()
+}
+
+// TrackingDomainVerify checks the CNAME record of the tracking domain with the provided name,
+// returning its updated status.
+func (c *Client) TrackingDomainVerify(domain string) (*TrackingDomainStatus, *Response, error) {
+	return c.TrackingDomainVerifyContext(context.Background(), domain)
+}
+
+// TrackingDomainVerifyContext is the same as TrackingDomainVerify, and it allows the caller to provide a context.
+func (c *Client) TrackingDomainVerifyContext(ctx context.Context, domain string) (status *TrackingDomainStatus, res *Response, err error) {
+	if domain == "" {
+		err = errors.New("Verify called with blank domain")
+		return
+	}
+
+	path := fmt.Sprintf(TrackingDomainsPathFormat, c.Config.ApiVersion)
+	res, err = c.HttpPost(ctx, fmt.Sprintf("%s%s/%s/verify", c.Config.BaseUrl, path, url.PathEscape(domain)), nil)
+	if err != nil {
+		return
+	}
+
+	var body []byte
+	if body, err = res.AssertJson(); err != nil {
+		return
+	}
+
+	if err = res.ParseResponse(); err != nil {
+		return
+	}
+
+	if Is2XX(res.HTTP.StatusCode) {
+		verified := map[string]*TrackingDomainStatus{}
+		if err = json.Unmarshal(body, &verified); err != nil {
+			err = errors.Wrap(err, "parsing api response")
+		} else if status = verified["results"]; status == nil {
+			err = errors.New("Unexpected response to TrackingDomain verification (results)")
+		}
+	} else {
+		err = res.HTTPError()
+	}
+
+	return
+}
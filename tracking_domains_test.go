@@ -0,0 +1,261 @@
+package gosparkpost_test
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/pkg/errors"
+)
+
+func TestTrackingDomainCreate(t *testing.T) {
+	for idx, test := range []struct {
+		in     *sp.TrackingDomain
+		err    error
+		status int
+		body   string
+		json   string
+	}{
+		{nil, errors.New("Create called with nil TrackingDomain"), 0, "", ""},
+		{&sp.TrackingDomain{}, errors.New("TrackingDomain requires a non-empty Domain"), 0, "", ""},
+		{&sp.TrackingDomain{Domain: "click.example.com"},
+			errors.New(`[{"message":"resource conflict","code":"1602","description":""}]`), 409, "",
+			`{"errors":[{"message":"resource conflict","code":"1602"}]}`},
+
+		{&sp.TrackingDomain{Domain: "click.example.com"}, nil, 200,
+			`{"domain":"click.example.com","secure":false,"default":false}`,
+			`{"results":{"domain":"click.example.com"}}`},
+		{&sp.TrackingDomain{Domain: "click.example.com", Secure: true, Default: true, Port: 443}, nil, 200,
+			`{"domain":"click.example.com","secure":true,"default":true}`,
+			`{"results":{"domain":"click.example.com"}}`},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestRequestResponseBuilderFormat(t, "POST", test.status, sp.TrackingDomainsPathFormat, test.body, test.json)
+
+		_, err := testClient.TrackingDomainCreate(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("TrackingDomainCreate[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("TrackingDomainCreate[%d] => err %q want %q", idx, err, test.err)
+		}
+	}
+}
+
+func TestTrackingDomain(t *testing.T) {
+	var res200 = loadTestFile(t, "test/json/tracking_domain_200.json")
+	var domain200 = &sp.TrackingDomain{
+		Domain: "example.domain.com",
+		Secure: true,
+		Port:   443,
+		Status: &sp.TrackingDomainStatus{CNAMEStatus: "pending", ComplianceStatus: "pending"},
+	}
+
+	for idx, test := range []struct {
+		in     string
+		err    error
+		status int
+		json   string
+		out    *sp.TrackingDomain
+	}{
+		{"", errors.New("TrackingDomain called with blank domain"), 0, "", nil},
+		{"example.domain.com", errors.New("unexpected end of JSON input"), 200, "{", nil},
+		{"example.domain.com", errors.New("Unexpected response to TrackingDomain"), 200, `{"foo":{}}`, nil},
+		{"example.domain.com", errors.New(`[{"message":"resource not found","code":"1600","description":""}]`), 404,
+			`{"errors":[{"message":"resource not found","code":"1600"}]}`, nil},
+
+		{"example.domain.com", nil, 200, res200, domain200},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "GET", test.status, sp.TrackingDomainsPathFormat+"/"+test.in, test.json)
+
+		d, _, err := testClient.TrackingDomain(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("TrackingDomain[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("TrackingDomain[%d] => err %q want %q", idx, err, test.err)
+		} else if test.out != nil && !reflect.DeepEqual(d, test.out) {
+			t.Errorf("TrackingDomain[%d] => got/want:\n%+v\n%+v", idx, d, test.out)
+		}
+	}
+}
+
+func TestTrackingDomains(t *testing.T) {
+	var res200 = loadTestFile(t, "test/json/tracking_domains_200.json")
+	var yes = true
+
+	for idx, test := range []struct {
+		in     *sp.TrackingDomainsFilter
+		query  string
+		err    error
+		status int
+		json   string
+		out    int
+	}{
+		{nil, "", errors.New(`[{"message":"error","code":"","description":""}]`), 400,
+			`{"errors":[{"message":"error"}]}`, 0},
+		{nil, "", nil, 200, res200, 2},
+		{&sp.TrackingDomainsFilter{Default: &yes, Subaccounts: []int{0, 215}}, "default=true&subaccounts=0%2C215",
+			nil, 200, res200, 2},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		var query, subaccount string
+		testMux.HandleFunc("/api/v1/tracking-domains", func(w http.ResponseWriter, r *http.Request) {
+			query, subaccount = r.URL.RawQuery, r.Header.Get(sp.SubaccountHeader)
+			w.Header().Set("Content-Type", "application/json; charset=utf8")
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.json))
+		})
+
+		ctx := sp.WithSubaccount(context.Background(), 215)
+		domains, _, err := testClient.TrackingDomainsContext(ctx, test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("TrackingDomains[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("TrackingDomains[%d] => err %q want %q", idx, err, test.err)
+		} else if len(domains) != test.out {
+			t.Errorf("TrackingDomains[%d] => %d domains, want %d", idx, len(domains), test.out)
+		} else if query != test.query || subaccount != "215" {
+			t.Errorf("TrackingDomains[%d] => query %q, subaccount %q", idx, query, subaccount)
+		} else if err == nil && (!domains[0].Default || domains[1].SubaccountID != 215 || !domains[1].Status.Verified) {
+			t.Errorf("TrackingDomains[%d] => %+v", idx, domains)
+		}
+	}
+}
+
+func TestTrackingDomainUpdate(t *testing.T) {
+	var res200 = loadTestFile(t, "test/json/tracking_domain_200.json")
+
+	for idx, test := range []struct {
+		in     *sp.TrackingDomain
+		err    error
+		status int
+		body   string
+		json   string
+	}{
+		{nil, errors.New("Update called with nil TrackingDomain"), 0, "", ""},
+		{&sp.TrackingDomain{}, errors.New("Update called with blank domain"), 0, "", ""},
+		{&sp.TrackingDomain{Domain: "example.domain.com"},
+			errors.New(`[{"message":"error","code":"","description":""}]`), 400, "",
+			`{"errors":[{"message":"error"}]}`},
+		{&sp.TrackingDomain{Domain: "example.domain.com", Secure: true, Port: 443}, nil, 200,
+			`{"secure":true,"default":false}`, `{"results":{"domain":"example.domain.com"}}`},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestRequestResponseBuilderFormat(t, "PUT", test.status, sp.TrackingDomainsPathFormat+"/example.domain.com", test.body, test.json)
+
+		_, err := testClient.TrackingDomainUpdate(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("TrackingDomainUpdate[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("TrackingDomainUpdate[%d] => err %q want %q", idx, err, test.err)
+		}
+	}
+
+	// SetDefault keeps the domain's secure setting
+	testSetup(t)
+	defer testTeardown()
+	testMux.HandleFunc("/api/v1/tracking-domains/example.domain.com", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf8")
+		switch r.Method {
+		case "GET":
+			w.Write([]byte(res200))
+		case "PUT":
+			body := make([]byte, 64)
+			n, _ := r.Body.Read(body)
+			if string(body[:n]) != `{"default":true,"secure":true}` {
+				t.Errorf("TrackingDomainSetDefault => sent %s", body[:n])
+			}
+			w.Write([]byte(`{"results":{"domain":"example.domain.com"}}`))
+		}
+	})
+	if _, err := testClient.TrackingDomainSetDefault("example.domain.com"); err != nil {
+		t.Errorf("TrackingDomainSetDefault => %v", err)
+	}
+}
+
+func TestTrackingDomainDelete(t *testing.T) {
+	for idx, test := range []struct {
+		in     string
+		err    error
+		status int
+		json   string
+	}{
+		{"", errors.New("Delete called with blank domain"), 0, ""},
+		{"example.domain.com", errors.New(`[{"message":"resource not found","code":"1600","description":""}]`), 404,
+			`{"errors":[{"message":"resource not found","code":"1600"}]}`},
+		{"example.domain.com", nil, 204, ""},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "DELETE", test.status, sp.TrackingDomainsPathFormat+"/example.domain.com", test.json)
+
+		_, err := testClient.TrackingDomainDelete(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("TrackingDomainDelete[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("TrackingDomainDelete[%d] => err %q want %q", idx, err, test.err)
+		}
+	}
+}
+
+func TestTrackingDomainVerify(t *testing.T) {
+	for idx, test := range []struct {
+		in     string
+		err    error
+		status int
+		json   string
+		out    *sp.TrackingDomainStatus
+	}{
+		{"", errors.New("Verify called with blank domain"), 0, "", nil},
+		{"example.domain.com", errors.New("Unexpected response to TrackingDomain verification (results)"), 200,
+			`{"foo":{}}`, nil},
+		{"example.domain.com", nil, 200,
+			`{"results":{"verified":true,"cname_status":"valid","compliance_status":"valid"}}`,
+			&sp.TrackingDomainStatus{Verified: true, CNAMEStatus: "valid", ComplianceStatus: "valid"}},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "POST", test.status, sp.TrackingDomainsPathFormat+"/example.domain.com/verify", test.json)
+
+		status, _, err := testClient.TrackingDomainVerify(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("TrackingDomainVerify[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("TrackingDomainVerify[%d] => err %q want %q", idx, err, test.err)
+		} else if test.out != nil && !reflect.DeepEqual(status, test.out) {
+			t.Errorf("TrackingDomainVerify[%d] => got/want:\n%+v\n%+v", idx, status, test.out)
+		}
+	}
+}
+
+func TestTrackingDomainCNAME(t *testing.T) {
+	for idx, test := range []struct {
+		cfg    *sp.Config
+		domain string
+		err    error
+		out    string
+	}{
+		{&sp.Config{}, "click.example.com", nil, "click.example.com. IN CNAME spgo.io."},
+		{&sp.Config{Region: sp.RegionEU}, "click.example.com.", nil, "click.example.com. IN CNAME eu.spgo.io."},
+		{&sp.Config{BaseUrl: "https://api.eu.sparkpost.com"}, "click.example.com", nil, "click.example.com. IN CNAME eu.spgo.io."},
+		{&sp.Config{BaseUrl: "https://sp.example.com"}, "click.example.com", nil, "click.example.com. IN CNAME spgo.io."},
+		{&sp.Config{Region: "mars"}, "click.example.com", errors.New(`unknown region "mars"`), ""},
+		{&sp.Config{}, "", errors.New("TrackingDomainCNAME called with blank domain"), ""},
+	} {
+		client := &sp.Client{Config: test.cfg}
+		record, err := client.TrackingDomainCNAME(test.domain)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("TrackingDomainCNAME[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("TrackingDomainCNAME[%d] => err %q want %q", idx, err, test.err)
+		} else if err == nil && record.String() != test.out {
+			t.Errorf("TrackingDomainCNAME[%d] => %q want %q", idx, record, test.out)
+		}
+	}
+}
@@ -42,7 +42,7 @@ func TestTransmissions_Post_Success(t *testing.T) {
 	// override one of the headers using a context
 	header := http.Header{}
 	header.Add("X-Foo", "bar")
-	ctx := context.WithValue(context.Background(), "http.Header", header)
+	ctx := sp.WithHeader(context.Background(), header)
 	tx := &sp.Transmission{
 		CampaignID: "Post_Success",
 		ReturnPath: "returnpath@example.com",
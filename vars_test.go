@@ -15,16 +15,20 @@ func init() {
 }
 
 var click1 = &events.Click{
-	EventCommon:     events.EventCommon{Type: "click"},
+	EventCommon:     events.EventCommon{Type: "click", EventID: "66567147053128920"},
+	AcceptLanguage:  "en-US,en;q=0.8",
 	CampaignID:      "",
 	CustomerID:      "42",
 	DeliveryMethod:  "esmtp",
 	GeoIP:           &events.GeoIP{Country: "US", Region: "NY", City: "Bronx", Latitude: 40.8499, Longitude: -73.8769},
 	IPAddress:       "66.102.8.2",
 	MessageID:       "0001441ead58afdeb21d",
+	IPPool:          "shared",
 	Metadata:        map[string]interface{}{},
 	Tags:            []string{},
 	Recipient:       "developers@sparkpost.com",
+	RawRecipient:    "developers@sparkpost.com",
+	SendingIP:       "52.38.191.220",
 	RecipientType:   "",
 	TargetLinkName:  "",
 	TargetLinkURL:   "https://sparkpost.com",
@@ -35,16 +39,19 @@ var click1 = &events.Click{
 }
 
 var open1 = &events.Open{
-	EventCommon:     events.EventCommon{Type: "open"},
+	EventCommon:     events.EventCommon{Type: "open", EventID: "102595298859691163"},
 	CampaignID:      "",
 	CustomerID:      "42",
 	DeliveryMethod:  "esmtp",
 	GeoIP:           &events.GeoIP{Country: "US", Region: "NY", City: "Bronx", Latitude: 40.8499, Longitude: -73.8769},
 	IPAddress:       "66.102.8.28",
 	MessageID:       "0001441ead58afdeb21d",
+	IPPool:          "shared",
 	Metadata:        map[string]interface{}{},
 	Tags:            []string{},
 	Recipient:       "developers@sparkpost.com",
+	RawRecipient:    "developers@sparkpost.com",
+	SendingIP:       "52.38.191.220",
 	RecipientType:   "",
 	TemplateID:      "best-template-ever",
 	TemplateVersion: "10",
@@ -53,16 +60,19 @@ var open1 = &events.Open{
 }
 
 var open2 = &events.Open{
-	EventCommon:     events.EventCommon{Type: "open"},
+	EventCommon:     events.EventCommon{Type: "open", EventID: "120608178786328010"},
 	CampaignID:      "",
 	CustomerID:      "42",
 	DeliveryMethod:  "esmtp",
 	GeoIP:           &events.GeoIP{Country: "US", Region: "NY", City: "Bronx", Latitude: 40.8499, Longitude: -73.8769},
 	IPAddress:       "66.102.8.2",
 	MessageID:       "00042a25ad58fc0145e1",
+	IPPool:          "shared",
 	Metadata:        map[string]interface{}{},
 	Tags:            []string{},
 	Recipient:       "sales@sparkpost.com",
+	RawRecipient:    "sales@sparkpost.com",
+	SendingIP:       "52.38.191.220",
 	RecipientType:   "",
 	TemplateID:      "best-template-ever",
 	TemplateVersion: "10",
@@ -0,0 +1,293 @@
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/SparkPost/gosparkpost/events"
+)
+
+// BatchIDHeader identifies each batch posted by an event webhook. Retries of a batch use the same id.
+const BatchIDHeader = "X-MessageSystems-Batch-ID"
+
+// Values for Handler.AuthType, matching WebhookItem.AuthType.
+const (
+	AuthNone   = "none"
+	AuthBasic  = "basic"
+	AuthOAuth2 = "oauth2"
+)
+
+// BatchStore records the batches a Handler has processed, so that retries are only handled once.
+// Implementations must be safe for concurrent use; a shared store lets several receivers dedupe together.
+type BatchStore interface {
+	// Seen returns true if the batch with the provided id has already been handled.
+	Seen(ctx context.Context, id string) (bool, error)
+	// Claim atomically reserves the batch with the provided id for handling. It returns false
+	// if the batch has already been handled, or is claimed by another request.
+	Claim(ctx context.Context, id string) (bool, error)
+	// Release gives up a claim on a batch that couldn't be handled, so that a retry can claim it.
+	Release(ctx context.Context, id string) error
+	// Done records that the claimed batch with the provided id has been handled.
+	Done(ctx context.Context, id string) error
+}
+
+// Handler is an http.Handler that receives batches posted by an event webhook,
+// decodes them and passes them to Handle.
+//
+// Requests must authenticate as configured by AuthType: with Username and Password
+// for "basic", and with a bearer token accepted by ValidToken (or equal to AccessToken,
+// if ValidToken is nil) for "oauth2". If AuthToken is set, it must also match the token header.
+//
+// SparkPost's validation ping, an empty batch, is acknowledged without calling Handle.
+// Each batch is claimed in Store before it's handled, so a retry that arrives while the first delivery
+// is still being handled gets a 409 response, and SparkPost tries again later. Batches already
+// recorded in Store are acknowledged without calling Handle again.
+// If Handle returns an error, the claim is released, the response status is 500 and SparkPost retries the batch later.
+// The claim is also released if Handle panics.
+//
+// If Validator is set, each batch is checked against the event documentation before it's handled.
+// Validation only warns: problems are passed to Warn (or written with the standard logger, if Warn is nil),
+// and the batch is handled as usual, even if the Validator is strict.
+type Handler struct {
+	AuthType    string
+	Username    string
+	Password    string
+	AccessToken string
+	ValidToken  func(ctx context.Context, token string) bool
+	AuthToken   string
+	Store       BatchStore
+	Handle      func(ctx context.Context, evs events.Events) error
+	Validator   *sp.EventValidator
+	Warn        func(ctx context.Context, problems []sp.EventProblem)
+}
+
+// NewHandler returns a Handler that authenticates requests as configured by w,
+// and passes batches to handle.
+func NewHandler(w *sp.WebhookItem, handle func(ctx context.Context, evs events.Events) error) *Handler {
+	return &Handler{
+		AuthType:    w.AuthType,
+		Username:    w.AuthCredentials.Username,
+		Password:    w.AuthCredentials.Password,
+		AccessToken: w.AuthCredentials.AccessToken,
+		AuthToken:   w.AuthToken,
+		Handle:      handle,
+	}
+}
+
+type batchIDKey struct{}
+
+// BatchID returns the id of the batch being handled, from the context passed to Handler.Handle.
+func BatchID(ctx context.Context) string {
+	id, _ := ctx.Value(batchIDKey{}).(string)
+	return id
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorized(r) {
+		if h.AuthType == AuthBasic {
+			w.Header().Set("WWW-Authenticate", `Basic realm="webhook"`)
+		}
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	var evs events.Events
+	if err := json.Unmarshal(body, &evs); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Validation requests contain a single empty event.
+	if len(evs) == 0 || h.Handle == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	ctx := r.Context()
+	if h.Validator != nil {
+		// Problems are returned along with the error from a strict Validator.
+		if problems, _ := h.Validator.ValidateBatch(body); len(problems) > 0 {
+			h.warn(ctx, problems)
+		}
+	}
+
+	id := r.Header.Get(BatchIDHeader)
+	store := h.Store
+	if id == "" {
+		store = nil
+	}
+	done := false
+	if store != nil {
+		claimed, err := store.Claim(ctx, id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		} else if !claimed {
+			// Either the batch is done, or another request is handling it and may yet fail.
+			seen, err := store.Seen(ctx, id)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			} else if seen {
+				w.WriteHeader(http.StatusOK)
+			} else {
+				http.Error(w, "batch is already being handled", http.StatusConflict)
+			}
+			return
+		}
+		// Unless the batch is done, release the claim so that a retry can handle it.
+		// This also runs if Handle panics, which the panic then carries on past.
+		defer func() {
+			if !done {
+				store.Release(ctx, id)
+			}
+		}()
+	}
+
+	if err := h.Handle(context.WithValue(ctx, batchIDKey{}, id), evs); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if store != nil {
+		if err := store.Done(ctx, id); err != nil {
+			// The batch was handled; a retry is better than losing track of that silently.
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	done = true
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) warn(ctx context.Context, problems []sp.EventProblem) {
+	if h.Warn != nil {
+		h.Warn(ctx, problems)
+		return
+	}
+	for _, p := range problems {
+		log.Printf("webhook: %s\n", p)
+	}
+}
+
+// authorized checks the request's credentials against the Handler's configuration.
+func (h *Handler) authorized(r *http.Request) bool {
+	if h.AuthToken != "" && !equal(r.Header.Get(TokenHeader), h.AuthToken) {
+		return false
+	}
+
+	switch h.AuthType {
+	case "", AuthNone:
+		return true
+	case AuthBasic:
+		user, pass, ok := r.BasicAuth()
+		// Evaluate both comparisons, so the time taken doesn't reveal which failed.
+		userOK, passOK := equal(user, h.Username), equal(pass, h.Password)
+		return ok && userOK && passOK
+	case AuthOAuth2:
+		auth := r.Header.Get("Authorization")
+		if len(auth) < 7 || !strings.EqualFold(auth[:7], "Bearer ") {
+			return false
+		}
+		token := strings.TrimSpace(auth[7:])
+		if h.ValidToken != nil {
+			return h.ValidToken(r.Context(), token)
+		}
+		return h.AccessToken != "" && equal(token, h.AccessToken)
+	}
+	return false
+}
+
+func equal(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// MemoryStore is a BatchStore that remembers batch ids in memory for TTL.
+// The zero value remembers batches for DefaultBatchTTL.
+type MemoryStore struct {
+	TTL time.Duration
+
+	mu      sync.Mutex
+	done    map[string]time.Time
+	claimed map[string]bool
+	sweepAt time.Time
+}
+
+// DefaultBatchTTL covers the period over which SparkPost retries a failed batch.
+const DefaultBatchTTL = 24 * time.Hour
+
+// Seen implements BatchStore.
+func (s *MemoryStore) Seen(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seen(id, time.Now()), nil
+}
+
+func (s *MemoryStore) seen(id string, now time.Time) bool {
+	expires, ok := s.done[id]
+	return ok && now.Before(expires)
+}
+
+// Claim implements BatchStore.
+func (s *MemoryStore) Claim(ctx context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.claimed[id] || s.seen(id, time.Now()) {
+		return false, nil
+	}
+	if s.claimed == nil {
+		s.claimed = map[string]bool{}
+	}
+	s.claimed[id] = true
+	return true, nil
+}
+
+// Release implements BatchStore.
+func (s *MemoryStore) Release(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.claimed, id)
+	return nil
+}
+
+// Done implements BatchStore.
+func (s *MemoryStore) Done(ctx context.Context, id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ttl := s.TTL
+	if ttl <= 0 {
+		ttl = DefaultBatchTTL
+	}
+	now := time.Now()
+	if s.done == nil {
+		s.done = map[string]time.Time{}
+	}
+	// Expired ids are swept a few times per TTL, rather than on every call.
+	if !now.Before(s.sweepAt) {
+		for k, expires := range s.done {
+			if now.After(expires) {
+				delete(s.done, k)
+			}
+		}
+		s.sweepAt = now.Add(ttl / 4)
+	}
+	delete(s.claimed, id)
+	s.done[id] = now.Add(ttl)
+	return nil
+}
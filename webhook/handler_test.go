@@ -0,0 +1,270 @@
+package webhook_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sp "github.com/SparkPost/gosparkpost"
+	"github.com/SparkPost/gosparkpost/events"
+	"github.com/SparkPost/gosparkpost/webhook"
+	"github.com/pkg/errors"
+)
+
+const batch = `[{"msys":{"message_event":{"type":"bounce","message_id":"000443ee14578172be22"}}},
+	{"msys":{"track_event":{"type":"click","message_id":"000443ee14578172be22"}}}]`
+
+func TestHandlerAuth(t *testing.T) {
+	basic := &sp.WebhookItem{AuthType: "basic"}
+	basic.AuthCredentials.Username = "user"
+	basic.AuthCredentials.Password = "pass"
+	oauth := &sp.WebhookItem{AuthType: "oauth2"}
+	oauth.AuthCredentials.AccessToken = "token"
+
+	for idx, test := range []struct {
+		wh     *sp.WebhookItem
+		header map[string]string
+		status int
+	}{
+		{&sp.WebhookItem{}, nil, http.StatusOK},
+		{&sp.WebhookItem{AuthType: "none", AuthToken: "secret"}, nil, http.StatusUnauthorized},
+		{&sp.WebhookItem{AuthType: "none", AuthToken: "secret"}, map[string]string{webhook.TokenHeader: "secret"}, http.StatusOK},
+		{basic, nil, http.StatusUnauthorized},
+		{basic, map[string]string{"Authorization": "Basic dXNlcjp3cm9uZw=="}, http.StatusUnauthorized},
+		{basic, map[string]string{"Authorization": "Basic dXNlcjpwYXNz"}, http.StatusOK},
+		{oauth, map[string]string{"Authorization": "Basic dXNlcjpwYXNz"}, http.StatusUnauthorized},
+		{oauth, map[string]string{"Authorization": "Bearer wrong"}, http.StatusUnauthorized},
+		{oauth, map[string]string{"Authorization": "bearer token"}, http.StatusOK},
+		{&sp.WebhookItem{AuthType: "digest"}, nil, http.StatusUnauthorized},
+	} {
+		calls := 0
+		h := webhook.NewHandler(test.wh, func(ctx context.Context, evs events.Events) error {
+			calls++
+			return nil
+		})
+
+		req := httptest.NewRequest("POST", "/events", strings.NewReader(batch))
+		for k, v := range test.header {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != test.status {
+			t.Errorf("Handler[%d] => status %d want %d", idx, rec.Code, test.status)
+		} else if test.status == http.StatusOK && calls != 1 {
+			t.Errorf("Handler[%d] => %d calls", idx, calls)
+		}
+	}
+
+	// ValidToken replaces the comparison with AccessToken
+	h := webhook.NewHandler(oauth, nil)
+	h.ValidToken = func(ctx context.Context, token string) bool { return token == "issued" }
+	for idx, token := range []string{"token", "issued"} {
+		req := httptest.NewRequest("POST", "/events", strings.NewReader(batch))
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if want := []int{http.StatusUnauthorized, http.StatusOK}[idx]; rec.Code != want {
+			t.Errorf("Handler.ValidToken[%d] => status %d want %d", idx, rec.Code, want)
+		}
+	}
+}
+
+func TestHandlerBatches(t *testing.T) {
+	store := &webhook.MemoryStore{}
+	fail := errors.New("database unavailable")
+
+	for idx, test := range []struct {
+		method string
+		batch  string
+		body   string
+		err    error
+		status int
+		calls  int
+	}{
+		{"GET", "", "", nil, http.StatusMethodNotAllowed, 0},
+		{"POST", "", "{", nil, http.StatusBadRequest, 0},
+		{"POST", "", `[{"msys":{}}]`, nil, http.StatusOK, 0},
+		{"POST", "batch-1", batch, fail, http.StatusInternalServerError, 1},
+		// the retry of a failed batch is handled
+		{"POST", "batch-1", batch, nil, http.StatusOK, 1},
+		// the retry of a handled batch isn't
+		{"POST", "batch-1", batch, nil, http.StatusOK, 0},
+		{"POST", "batch-2", batch, nil, http.StatusOK, 1},
+		{"POST", "", batch, nil, http.StatusOK, 1},
+		{"POST", "", batch, nil, http.StatusOK, 1},
+	} {
+		var got events.Events
+		var id string
+		calls := 0
+		h := &webhook.Handler{
+			Store: store,
+			Handle: func(ctx context.Context, evs events.Events) error {
+				calls++
+				got, id = evs, webhook.BatchID(ctx)
+				return test.err
+			},
+		}
+
+		req := httptest.NewRequest(test.method, "/events", strings.NewReader(test.body))
+		if test.batch != "" {
+			req.Header.Set(webhook.BatchIDHeader, test.batch)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != test.status {
+			t.Errorf("Handler[%d] => status %d want %d", idx, rec.Code, test.status)
+		} else if calls != test.calls {
+			t.Errorf("Handler[%d] => %d calls want %d", idx, calls, test.calls)
+		} else if calls > 0 {
+			if id != test.batch {
+				t.Errorf("Handler[%d] => batch id %q want %q", idx, id, test.batch)
+			}
+			if len(got) != 2 || got[0].EventType() != "bounce" || got[1].EventType() != "click" {
+				t.Errorf("Handler[%d] => %+v", idx, got)
+			}
+		}
+	}
+}
+
+func TestHandlerConcurrentRetry(t *testing.T) {
+	started, finish := make(chan struct{}), make(chan error)
+	calls := 0
+	h := &webhook.Handler{
+		Store: &webhook.MemoryStore{},
+		Handle: func(ctx context.Context, evs events.Events) error {
+			calls++
+			started <- struct{}{}
+			return <-finish
+		},
+	}
+	post := func() int {
+		req := httptest.NewRequest("POST", "/events", strings.NewReader(batch))
+		req.Header.Set(webhook.BatchIDHeader, "batch-1")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	for idx, test := range []struct {
+		err    error
+		status int
+	}{
+		{errors.New("database unavailable"), http.StatusInternalServerError},
+		{nil, http.StatusOK},
+	} {
+		status := make(chan int)
+		go func() { status <- post() }()
+		<-started
+
+		// A retry while the first delivery is being handled is turned away, so SparkPost tries again.
+		if code := post(); code != http.StatusConflict {
+			t.Errorf("Handler[%d] => retry in progress status %d want %d", idx, code, http.StatusConflict)
+		}
+		finish <- test.err
+		if code := <-status; code != test.status {
+			t.Errorf("Handler[%d] => status %d want %d", idx, code, test.status)
+		}
+	}
+
+	// Once handled, retries are acknowledged without handling them again.
+	if code := post(); code != http.StatusOK || calls != 2 {
+		t.Errorf("Handler => status %d with %d calls, want %d with 2", code, calls, http.StatusOK)
+	}
+}
+
+func TestHandlerPanic(t *testing.T) {
+	calls := 0
+	h := &webhook.Handler{
+		Store: &webhook.MemoryStore{},
+		Handle: func(ctx context.Context, evs events.Events) error {
+			if calls++; calls == 1 {
+				panic("handler bug")
+			}
+			return nil
+		},
+	}
+	post := func() (code int, recovered interface{}) {
+		defer func() { recovered = recover() }()
+		req := httptest.NewRequest("POST", "/events", strings.NewReader(batch))
+		req.Header.Set(webhook.BatchIDHeader, "batch-1")
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		return rec.Code, nil
+	}
+
+	if _, recovered := post(); recovered != "handler bug" {
+		t.Errorf("Handler => recovered %v, want the panic from Handle", recovered)
+	}
+	// The claim was released, so the retry is handled rather than turned away.
+	if code, _ := post(); code != http.StatusOK || calls != 2 {
+		t.Errorf("Handler => retry status %d with %d calls, want %d with 2", code, calls, http.StatusOK)
+	}
+}
+
+func TestHandlerTooLarge(t *testing.T) {
+	defer func(max int64) { webhook.MaxBodyBytes = max }(webhook.MaxBodyBytes)
+	webhook.MaxBodyBytes = int64(len(batch))
+
+	calls := 0
+	h := &webhook.Handler{Handle: func(ctx context.Context, evs events.Events) error {
+		calls++
+		return nil
+	}}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("POST", "/events", strings.NewReader(batch+"\n")))
+	if rec.Code != http.StatusRequestEntityTooLarge || calls != 0 {
+		t.Errorf("Handler => status %d with %d calls, want %d with 0", rec.Code, calls, http.StatusRequestEntityTooLarge)
+	}
+}
+
+func TestHandlerValidator(t *testing.T) {
+	groups := map[string]*sp.EventGroup{
+		"message_event": {Events: map[string]sp.EventMeta{
+			"bounce": {Fields: map[string]sp.EventField{
+				"type":       {SampleValue: "bounce"},
+				"message_id": {SampleValue: "000443ee14578172be22"},
+				"reason":     {SampleValue: "MAIL REFUSED"},
+			}},
+		}},
+	}
+
+	for idx, strict := range []bool{false, true} {
+		var warned []sp.EventProblem
+		calls := 0
+		h := &webhook.Handler{
+			Validator: &sp.EventValidator{Groups: groups, Strict: strict},
+			Warn: func(ctx context.Context, problems []sp.EventProblem) {
+				warned = append(warned, problems...)
+			},
+			Handle: func(ctx context.Context, evs events.Events) error {
+				calls++
+				return nil
+			},
+		}
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("POST", "/events", strings.NewReader(batch)))
+
+		// Problems are reported, but the batch is still handled.
+		if rec.Code != http.StatusOK || calls != 1 {
+			t.Errorf("HandlerValidator[%d] => status %d, %d calls", idx, rec.Code, calls)
+		}
+		want := []string{
+			`event 0 (bounce): missing field "reason"`,
+			`event 1: unknown type "click"`,
+		}
+		if len(warned) != len(want) {
+			t.Fatalf("HandlerValidator[%d] => %d problems want %d", idx, len(warned), len(want))
+		}
+		for i, p := range warned {
+			if p.String() != want[i] {
+				t.Errorf("HandlerValidator[%d] => %q want %q", idx, p, want[i])
+			}
+		}
+	}
+}
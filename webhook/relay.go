@@ -0,0 +1,110 @@
+// Package webhook provides http.Handlers that receive batches posted by SparkPost webhooks.
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/SparkPost/gosparkpost/events"
+)
+
+// TokenHeader is the header in which relay webhooks send their AuthToken.
+const TokenHeader = "X-MessageSystems-Webhook-Token"
+
+// MaxBodyBytes limits the size of the batches read by the handlers in this package.
+// Larger batches are rejected with status 413.
+var MaxBodyBytes int64 = 64 << 20
+
+// RelayHandler is an http.Handler that receives batches posted by a relay webhook.
+// Requests are rejected unless their token header matches AuthToken, when it's set.
+// Handle is called once per non-empty batch; if it returns an error, the response
+// status is 500 and SparkPost retries the batch later.
+type RelayHandler struct {
+	AuthToken string
+	Handle    func(ctx context.Context, msgs []*events.RelayMessage) error
+}
+
+// ServeHTTP implements http.Handler.
+func (h *RelayHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if h.AuthToken != "" &&
+		subtle.ConstantTimeCompare([]byte(r.Header.Get(TokenHeader)), []byte(h.AuthToken)) != 1 {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	body, ok := readBody(w, r)
+	if !ok {
+		return
+	}
+	msgs, err := DecodeRelayMessages(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Validation requests contain a single empty message.
+	if len(msgs) == 0 || h.Handle == nil {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if err = h.Handle(r.Context(), msgs); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// readBody reads a batch, responding with 413 if it's larger than MaxBodyBytes, or 400 if it can't be read.
+func readBody(w http.ResponseWriter, r *http.Request) ([]byte, bool) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		} else {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+		return nil, false
+	}
+	return body, true
+}
+
+// DecodeRelayMessages decodes a batch posted by a relay webhook.
+// Relayed messages don't include a type, so events.Events can't be used to decode them.
+func DecodeRelayMessages(body []byte) ([]*events.RelayMessage, error) {
+	var batch []struct {
+		Msys struct {
+			RelayMessage *events.RelayMessage `json:"relay_message"`
+		} `json:"msys"`
+	}
+	if err := json.Unmarshal(body, &batch); err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*events.RelayMessage, 0, len(batch))
+	for _, wrapper := range batch {
+		if m := wrapper.Msys.RelayMessage; m != nil {
+			m.Type = "relay_message"
+			msgs = append(msgs, m)
+		}
+	}
+	return msgs, nil
+}
+
+// RFC822 returns the raw message relayed in m, decoding it if it's base64-encoded.
+func RFC822(m *events.RelayMessage) ([]byte, error) {
+	if m.Content.Base64 {
+		return base64.StdEncoding.DecodeString(m.Content.Email)
+	}
+	return []byte(m.Content.Email), nil
+}
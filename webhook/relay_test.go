@@ -0,0 +1,119 @@
+package webhook_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/SparkPost/gosparkpost/events"
+	"github.com/SparkPost/gosparkpost/webhook"
+	"github.com/pkg/errors"
+)
+
+func TestRelayHandler(t *testing.T) {
+	batch, err := ioutil.ReadFile("../events/test/json/sample-relay-webhook.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for idx, test := range []struct {
+		method string
+		token  string
+		body   string
+		err    error
+		status int
+		calls  int
+	}{
+		{"GET", "secret", "", nil, http.StatusMethodNotAllowed, 0},
+		{"POST", "", string(batch), nil, http.StatusUnauthorized, 0},
+		{"POST", "wrong", string(batch), nil, http.StatusUnauthorized, 0},
+		{"POST", "secret", "{", nil, http.StatusBadRequest, 0},
+		{"POST", "secret", `[{"msys":{}}]`, nil, http.StatusOK, 0},
+		{"POST", "secret", string(batch), nil, http.StatusOK, 1},
+		{"POST", "secret", string(batch), errors.New("try again"), http.StatusInternalServerError, 1},
+	} {
+		var got []*events.RelayMessage
+		calls := 0
+		h := &webhook.RelayHandler{
+			AuthToken: "secret",
+			Handle: func(ctx context.Context, msgs []*events.RelayMessage) error {
+				calls++
+				got = msgs
+				return test.err
+			},
+		}
+
+		req := httptest.NewRequest(test.method, "/relay", strings.NewReader(test.body))
+		if test.token != "" {
+			req.Header.Set(webhook.TokenHeader, test.token)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		if rec.Code != test.status {
+			t.Errorf("RelayHandler[%d] => status %d want %d", idx, rec.Code, test.status)
+		} else if calls != test.calls {
+			t.Errorf("RelayHandler[%d] => %d calls want %d", idx, calls, test.calls)
+		} else if calls > 0 {
+			if len(got) != 1 || got[0].EventType() != "relay_message" ||
+				got[0].From != "me@sparkpostbox.com" || got[0].WebhookID != "1234567890" {
+				t.Errorf("RelayHandler[%d] => %+v", idx, got)
+			}
+		}
+	}
+}
+
+func TestRelayHandlerTooLarge(t *testing.T) {
+	batch, err := ioutil.ReadFile("../events/test/json/sample-relay-webhook.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(max int64) { webhook.MaxBodyBytes = max }(webhook.MaxBodyBytes)
+	webhook.MaxBodyBytes = int64(len(batch))
+
+	for idx, test := range []struct {
+		body   string
+		status int
+	}{
+		{string(batch), http.StatusOK},
+		// Still valid JSON if it were truncated at the limit.
+		{string(batch) + "\n", http.StatusRequestEntityTooLarge},
+	} {
+		calls := 0
+		h := &webhook.RelayHandler{Handle: func(ctx context.Context, msgs []*events.RelayMessage) error {
+			calls++
+			return nil
+		}}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("POST", "/relay", strings.NewReader(test.body)))
+		if rec.Code != test.status {
+			t.Errorf("RelayHandlerTooLarge[%d] => status %d want %d", idx, rec.Code, test.status)
+		} else if test.status != http.StatusOK && calls != 0 {
+			t.Errorf("RelayHandlerTooLarge[%d] => %d calls want 0", idx, calls)
+		}
+	}
+}
+
+func TestRFC822(t *testing.T) {
+	for idx, test := range []struct {
+		in  events.RelayContent
+		err error
+		out string
+	}{
+		{events.RelayContent{Email: "Subject: Hi\r\n\r\nHi\r\n"}, nil, "Subject: Hi\r\n\r\nHi\r\n"},
+		{events.RelayContent{Email: "U3ViamVjdDogSGkNCg0KSGkNCg==", Base64: true}, nil, "Subject: Hi\r\n\r\nHi\r\n"},
+		{events.RelayContent{Email: "!", Base64: true}, errors.New("illegal base64 data at input byte 0"), ""},
+	} {
+		raw, err := webhook.RFC822(&events.RelayMessage{Content: test.in})
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("RFC822[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("RFC822[%d] => err %q want %q", idx, err, test.err)
+		} else if string(raw) != test.out {
+			t.Errorf("RFC822[%d] => %q want %q", idx, raw, test.out)
+		}
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strconv"
 
+	"github.com/SparkPost/gosparkpost/events"
 	"github.com/pkg/errors"
 )
 
@@ -195,3 +197,241 @@ func doRequest(c *Client, finalUrl string, ctx context.Context) ([]byte, *Respon
 
 	return body, res, err
 }
+
+// WebhookValidation is the result of sending a test message to the target of a webhook or relay webhook.
+type WebhookValidation struct {
+	Message  string `json:"msg"`
+	Response struct {
+		Status  int               `json:"status"`
+		Headers map[string]string `json:"headers"`
+		Body    string            `json:"body"`
+	} `json:"response"`
+}
+
+// Failed returns true if SparkPost gave up on or is retrying the batch.
+func (s WebhookStatus) Failed() bool {
+	return s.FailureCode != ""
+}
+
+// Validate runs sanity checks on a WebhookItem struct.
+// This should catch most errors before attempting a doomed API call.
+func (w *WebhookItem) Validate() error {
+	if w == nil {
+		return errors.New("Can't Validate a nil WebhookItem")
+	}
+	if w.Name == "" {
+		return errors.New("Webhook requires a non-empty Name")
+	}
+	if w.Target == "" {
+		return errors.New("Webhook requires a non-empty Target")
+	}
+	if len(w.Events) == 0 {
+		return errors.New("Webhook requires at least one event type")
+	}
+	for _, e := range w.Events {
+		if !events.ValidEventType(e) {
+			return errors.Errorf("Webhook event type [%s] is not valid", e)
+		}
+	}
+	return nil
+}
+
+// payload returns the writable fields of a WebhookItem, leaving out empty auth details.
+func (w *WebhookItem) payload() map[string]interface{} {
+	p := map[string]interface{}{
+		"name":   w.Name,
+		"target": w.Target,
+		"events": w.Events,
+	}
+	if w.AuthType != "" {
+		p["auth_type"] = w.AuthType
+	}
+	if w.AuthToken != "" {
+		p["auth_token"] = w.AuthToken
+	}
+	if w.AuthRequestDetails.URL != "" {
+		p["auth_request_details"] = w.AuthRequestDetails
+	}
+	if w.AuthCredentials.Username != "" || w.AuthCredentials.Password != "" || w.AuthCredentials.AccessToken != "" {
+		p["auth_credentials"] = w.AuthCredentials
+	}
+	return p
+}
+
+// WebhookCreate creates the provided webhook, returning its id.
+// https://developers.sparkpost.com/api/webhooks/#webhooks-post-create-a-webhook
+func (c *Client) WebhookCreate(w *WebhookItem) (id string, res *Response, err error) {
+	return c.WebhookCreateContext(context.Background(), w)
+}
+
+// WebhookCreateContext is the same as WebhookCreate, and allows the caller to specify their own context.
+func (c *Client) WebhookCreateContext(ctx context.Context, w *WebhookItem) (id string, res *Response, err error) {
+	if w == nil {
+		err = errors.New("Create called with nil WebhookItem")
+		return
+	}
+
+	if err = w.Validate(); err != nil {
+		return
+	}
+
+	// A WebhookItem that makes it past Validate() will always Marshal
+	jsonBytes, _ := json.Marshal(w.payload())
+
+	path := fmt.Sprintf(WebhooksPathFormat, c.Config.ApiVersion)
+	res, err = c.HttpPost(ctx, c.Config.BaseUrl+path, jsonBytes)
+	if err != nil {
+		return
+	}
+
+	if err = res.ParseResponse(); err != nil {
+		return
+	}
+
+	if Is2XX(res.HTTP.StatusCode) {
+		var ok bool
+		var results map[string]interface{}
+		if results, ok = res.Results.(map[string]interface{}); !ok {
+			err = errors.New("Unexpected response to Webhook creation (results)")
+		} else if id, ok = results["id"].(string); !ok {
+			err = errors.New("Unexpected response to Webhook creation (id)")
+		}
+	} else {
+		err = res.HTTPError()
+	}
+	return
+}
+
+// WebhookUpdate replaces the configuration of the webhook identified by w.ID.
+// https://developers.sparkpost.com/api/webhooks/#webhooks-put-update-a-webhook
+func (c *Client) WebhookUpdate(w *WebhookItem) (*Response, error) {
+	return c.WebhookUpdateContext(context.Background(), w)
+}
+
+// WebhookUpdateContext is the same as WebhookUpdate, and allows the caller to specify their own context.
+func (c *Client) WebhookUpdateContext(ctx context.Context, w *WebhookItem) (*Response, error) {
+	if w == nil {
+		return nil, errors.New("Update called with nil WebhookItem")
+	} else if w.ID == "" {
+		return nil, errors.New("Update called with blank id")
+	}
+
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+
+	// A WebhookItem that makes it past Validate() will always Marshal
+	jsonBytes, _ := json.Marshal(w.payload())
+
+	path := fmt.Sprintf(WebhooksPathFormat, c.Config.ApiVersion)
+	return c.HttpPutJson(ctx, fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(w.ID)), jsonBytes)
+}
+
+// WebhookDelete deletes the webhook with the provided id.
+// https://developers.sparkpost.com/api/webhooks/#webhooks-delete-delete-a-webhook
+func (c *Client) WebhookDelete(id string) (*Response, error) {
+	return c.WebhookDeleteContext(context.Background(), id)
+}
+
+// WebhookDeleteContext is the same as WebhookDelete, and allows the caller to specify their own context.
+func (c *Client) WebhookDeleteContext(ctx context.Context, id string) (*Response, error) {
+	if id == "" {
+		return nil, errors.New("Delete called with blank id")
+	}
+
+	path := fmt.Sprintf(WebhooksPathFormat, c.Config.ApiVersion)
+	res, err := c.HttpDelete(ctx, fmt.Sprintf("%s%s/%s", c.Config.BaseUrl, path, url.PathEscape(id)))
+	if err != nil {
+		return res, err
+	}
+
+	// We get an empty response on success. If there are errors we get JSON.
+	if _, err = res.AssertJson(); err == nil {
+		if err = res.ParseResponse(); err != nil {
+			return res, err
+		}
+	}
+
+	return res, res.HTTPError()
+}
+
+// WebhookValidate sends a test batch to the target of the webhook with the provided id,
+// and returns how the target responded. A nil message sends an empty batch.
+// https://developers.sparkpost.com/api/webhooks/#webhooks-post-validate-a-webhook
+func (c *Client) WebhookValidate(id string, message interface{}) (*WebhookValidation, *Response, error) {
+	return c.WebhookValidateContext(context.Background(), id, message)
+}
+
+// WebhookValidateContext is the same as WebhookValidate, and allows the caller to specify their own context.
+func (c *Client) WebhookValidateContext(ctx context.Context, id string, message interface{}) (v *WebhookValidation, res *Response, err error) {
+	if id == "" {
+		err = errors.New("Validate called with blank id")
+		return
+	}
+	if message == nil {
+		message = map[string]interface{}{"msys": map[string]interface{}{}}
+	}
+
+	jsonBytes, err := json.Marshal(map[string]interface{}{"message": message})
+	if err != nil {
+		err = errors.Wrap(err, "encoding validation message")
+		return
+	}
+
+	path := fmt.Sprintf(WebhooksPathFormat, c.Config.ApiVersion)
+	res, err = c.HttpPost(ctx, fmt.Sprintf("%s%s/%s/validate", c.Config.BaseUrl, path, url.PathEscape(id)), jsonBytes)
+	if err != nil {
+		return
+	}
+
+	var body []byte
+	if body, err = res.AssertJson(); err != nil {
+		return
+	}
+
+	if err = res.ParseResponse(); err != nil {
+		return
+	}
+
+	if Is2XX(res.HTTP.StatusCode) {
+		wrapper := map[string]*WebhookValidation{}
+		if err = json.Unmarshal(body, &wrapper); err != nil {
+			err = errors.Wrap(err, "parsing api response")
+		} else if v = wrapper["results"]; v == nil {
+			err = errors.New("Unexpected response to Webhook validation (results)")
+		}
+	} else {
+		err = res.HTTPError()
+	}
+
+	return
+}
+
+// WebhookBatchStatus returns the status of up to limit recent batches sent to the webhook
+// with the provided id, most recent first. A limit of zero uses the API default.
+func (c *Client) WebhookBatchStatus(id string, limit int) ([]WebhookStatus, *Response, error) {
+	return c.WebhookBatchStatusContext(context.Background(), id, limit)
+}
+
+// WebhookBatchStatusContext is the same as WebhookBatchStatus, and allows the caller to specify their own context.
+func (c *Client) WebhookBatchStatusContext(ctx context.Context, id string, limit int) ([]WebhookStatus, *Response, error) {
+	if id == "" {
+		return nil, nil, errors.New("WebhookBatchStatus called with blank id")
+	}
+
+	s := &WebhookStatusWrapper{ID: url.PathEscape(id)}
+	if limit > 0 {
+		s.Params = map[string]string{"limit": strconv.Itoa(limit)}
+	}
+	res, err := c.WebhookStatusContext(ctx, s)
+	if err != nil {
+		return nil, res, err
+	}
+	if !Is2XX(res.HTTP.StatusCode) {
+		if err = res.ParseResponse(); err == nil {
+			err = res.HTTPError()
+		}
+		return nil, res, err
+	}
+	return s.Results, res, nil
+}
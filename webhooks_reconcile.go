@@ -0,0 +1,211 @@
+package gosparkpost
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// WebhookAction is the kind of change WebhookReconcile makes to a webhook.
+type WebhookAction string
+
+const (
+	WebhookActionCreate WebhookAction = "create"
+	WebhookActionUpdate WebhookAction = "update"
+	WebhookActionDelete WebhookAction = "delete"
+)
+
+// WebhookChange is a single step of a WebhookPlan.
+// Current is nil for creates, and Desired is nil for deletes.
+// Fields lists the fields that differ, for updates.
+type WebhookChange struct {
+	Action  WebhookAction
+	Current *WebhookItem
+	Desired *WebhookItem
+	Fields  []string
+}
+
+// WebhookPlan lists the changes needed to make an account's webhooks match a desired set.
+type WebhookPlan []WebhookChange
+
+// String returns the plan as a diff, one line per change, with a leading +, ~ or -.
+func (p WebhookPlan) String() string {
+	if len(p) == 0 {
+		return "no changes\n"
+	}
+	var b strings.Builder
+	for _, ch := range p {
+		switch ch.Action {
+		case WebhookActionCreate:
+			fmt.Fprintf(&b, "+ %q target=%s events=%s\n",
+				ch.Desired.Name, ch.Desired.Target, strings.Join(ch.Desired.Events, ","))
+		case WebhookActionUpdate:
+			fmt.Fprintf(&b, "~ %q (%s)\n", ch.Desired.Name, ch.Current.ID)
+			for _, f := range ch.Fields {
+				fmt.Fprintf(&b, "    %s: %s => %s\n", f, webhookField(ch.Current, f), webhookField(ch.Desired, f))
+			}
+		case WebhookActionDelete:
+			fmt.Fprintf(&b, "- %q (%s)\n", ch.Current.Name, ch.Current.ID)
+		}
+	}
+	return b.String()
+}
+
+// webhookField returns a printable value for one of the fields compared by WebhookDiff.
+// Secrets are never printed.
+func webhookField(w *WebhookItem, field string) string {
+	switch field {
+	case "target":
+		return w.Target
+	case "events":
+		return "[" + strings.Join(sortedEvents(w.Events), ",") + "]"
+	case "auth_type":
+		return authType(w)
+	}
+	return "(secret)"
+}
+
+// authType treats an empty AuthType as "none", which is the API default.
+func authType(w *WebhookItem) string {
+	if w.AuthType == "" {
+		return "none"
+	}
+	return w.AuthType
+}
+
+func sortedEvents(evs []string) []string {
+	sorted := append([]string(nil), evs...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// webhookDiff returns the names of the fields that differ between current and desired.
+// Auth details are only compared when they're set in both, since the API may omit them when listing webhooks.
+func webhookDiff(current, desired *WebhookItem) []string {
+	var fields []string
+	if current.Target != desired.Target {
+		fields = append(fields, "target")
+	}
+	if !reflect.DeepEqual(sortedEvents(current.Events), sortedEvents(desired.Events)) {
+		fields = append(fields, "events")
+	}
+	if authType(current) != authType(desired) {
+		fields = append(fields, "auth_type")
+	}
+	if secretChanged(current.AuthToken, desired.AuthToken) {
+		fields = append(fields, "auth_token")
+	}
+	cur, des := current.AuthRequestDetails, desired.AuthRequestDetails
+	if secretChanged(cur.URL, des.URL) || secretChanged(cur.Body.ClientID, des.Body.ClientID) ||
+		secretChanged(cur.Body.ClientSecret, des.Body.ClientSecret) {
+		fields = append(fields, "auth_request_details")
+	}
+	cc, dc := current.AuthCredentials, desired.AuthCredentials
+	if secretChanged(cc.Username, dc.Username) || secretChanged(cc.Password, dc.Password) ||
+		secretChanged(cc.AccessToken, dc.AccessToken) ||
+		cc.ExpiresIn != 0 && dc.ExpiresIn != 0 && cc.ExpiresIn != dc.ExpiresIn {
+		fields = append(fields, "auth_credentials")
+	}
+	return fields
+}
+
+// secretChanged returns true if both auth values are known and differ.
+func secretChanged(current, desired string) bool {
+	return current != "" && desired != "" && current != desired
+}
+
+// WebhookDiff compares the account's webhooks with the desired set, matching them by Name,
+// and returns the changes WebhookReconcile would make. Webhooks that aren't in the desired set are deleted.
+func (c *Client) WebhookDiff(desired []WebhookItem) (WebhookPlan, *Response, error) {
+	return c.WebhookDiffContext(context.Background(), desired)
+}
+
+// WebhookDiffContext is the same as WebhookDiff, and allows the caller to specify their own context.
+func (c *Client) WebhookDiffContext(ctx context.Context, desired []WebhookItem) (WebhookPlan, *Response, error) {
+	byName := map[string]*WebhookItem{}
+	for i := range desired {
+		w := &desired[i]
+		if err := w.Validate(); err != nil {
+			return nil, nil, errors.Wrapf(err, "desired webhook %d", i)
+		} else if byName[w.Name] != nil {
+			return nil, nil, errors.Errorf("desired webhook name %q is not unique", w.Name)
+		}
+		byName[w.Name] = w
+	}
+
+	list := &WebhookListWrapper{}
+	res, err := c.WebhooksContext(ctx, list)
+	if err != nil {
+		return nil, res, err
+	} else if !Is2XX(res.HTTP.StatusCode) {
+		if err = res.ParseResponse(); err == nil {
+			err = res.HTTPError()
+		}
+		return nil, res, err
+	}
+
+	var plan WebhookPlan
+	seen := map[string]bool{}
+	for i := range list.Results {
+		current := &list.Results[i]
+		want := byName[current.Name]
+		if want == nil || seen[current.Name] {
+			// Duplicate names can't be matched, so only the first one is kept.
+			plan = append(plan, WebhookChange{Action: WebhookActionDelete, Current: current})
+			continue
+		}
+		seen[current.Name] = true
+		if fields := webhookDiff(current, want); len(fields) > 0 {
+			plan = append(plan, WebhookChange{Action: WebhookActionUpdate, Current: current, Desired: want, Fields: fields})
+		}
+	}
+	for i := range desired {
+		if !seen[desired[i].Name] {
+			plan = append(plan, WebhookChange{Action: WebhookActionCreate, Desired: &desired[i]})
+		}
+	}
+
+	return plan, res, nil
+}
+
+// WebhookReconcile makes the account's webhooks match the desired set, as described by WebhookDiff.
+// The plan is passed to confirm before anything is changed, and nothing is changed unless confirm
+// returns true. A nil confirm applies the plan unconditionally. The plan is returned either way.
+func (c *Client) WebhookReconcile(desired []WebhookItem, confirm func(WebhookPlan) bool) (WebhookPlan, *Response, error) {
+	return c.WebhookReconcileContext(context.Background(), desired, confirm)
+}
+
+// WebhookReconcileContext is the same as WebhookReconcile, and allows the caller to specify their own context.
+func (c *Client) WebhookReconcileContext(ctx context.Context, desired []WebhookItem, confirm func(WebhookPlan) bool) (WebhookPlan, *Response, error) {
+	plan, res, err := c.WebhookDiffContext(ctx, desired)
+	if err != nil || len(plan) == 0 {
+		return plan, res, err
+	}
+	if confirm != nil && !confirm(plan) {
+		return plan, res, nil
+	}
+
+	for _, ch := range plan {
+		switch ch.Action {
+		case WebhookActionCreate:
+			_, res, err = c.WebhookCreateContext(ctx, ch.Desired)
+			err = errors.Wrapf(err, "creating webhook %q", ch.Desired.Name)
+		case WebhookActionUpdate:
+			update := *ch.Desired
+			update.ID = ch.Current.ID
+			res, err = c.WebhookUpdateContext(ctx, &update)
+			err = errors.Wrapf(err, "updating webhook %q", ch.Desired.Name)
+		case WebhookActionDelete:
+			res, err = c.WebhookDeleteContext(ctx, ch.Current.ID)
+			err = errors.Wrapf(err, "deleting webhook %q", ch.Current.Name)
+		}
+		if err != nil {
+			return plan, res, err
+		}
+	}
+	return plan, res, nil
+}
@@ -1,6 +1,7 @@
 package gosparkpost_test
 
 import (
+	"net/http"
 	"reflect"
 	"testing"
 
@@ -253,3 +254,280 @@ func TestWebhooks(t *testing.T) {
 		}
 	}
 }
+
+func TestWebhookCreate(t *testing.T) {
+	for idx, test := range []struct {
+		in     *sp.WebhookItem
+		err    error
+		status int
+		body   string
+		json   string
+		id     string
+	}{
+		{nil, errors.New("Create called with nil WebhookItem"), 0, "", "", ""},
+		{&sp.WebhookItem{Target: "https://example.com/hook", Events: []string{"bounce"}},
+			errors.New("Webhook requires a non-empty Name"), 0, "", "", ""},
+		{&sp.WebhookItem{Name: "hook", Events: []string{"bounce"}},
+			errors.New("Webhook requires a non-empty Target"), 0, "", "", ""},
+		{&sp.WebhookItem{Name: "hook", Target: "https://example.com/hook"},
+			errors.New("Webhook requires at least one event type"), 0, "", "", ""},
+		{&sp.WebhookItem{Name: "hook", Target: "https://example.com/hook", Events: []string{"bounce", "bounced"}},
+			errors.New("Webhook event type [bounced] is not valid"), 0, "", "", ""},
+		{&sp.WebhookItem{Name: "hook", Target: "https://example.com/hook", Events: []string{"bounce"}},
+			errors.New(`[{"message":"invalid params","code":"1200","description":""}]`), 400, "",
+			`{"errors":[{"message":"invalid params","code":"1200"}]}`, ""},
+
+		{&sp.WebhookItem{Name: "hook", Target: "https://example.com/hook", Events: []string{"bounce"}, AuthToken: "secret"}, nil, 200,
+			`{"name":"hook","target":"https://example.com/hook","events":["bounce"],"auth_token":"secret"}`,
+			`{"results":{"id":"12affc24-f183-11e3-9234-3c15c2c818c2"}}`, "12affc24-f183-11e3-9234-3c15c2c818c2"},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestRequestResponseBuilderFormat(t, "POST", test.status, sp.WebhooksPathFormat, test.body, test.json)
+
+		id, _, err := testClient.WebhookCreate(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("WebhookCreate[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("WebhookCreate[%d] => err %q want %q", idx, err, test.err)
+		} else if id != test.id {
+			t.Errorf("WebhookCreate[%d] => id %q want %q", idx, id, test.id)
+		}
+	}
+}
+
+func TestWebhookUpdate(t *testing.T) {
+	var basic = &sp.WebhookItem{ID: "id", Name: "hook", Target: "https://example.com/hook", Events: []string{"bounce"}, AuthType: "basic"}
+	basic.AuthCredentials.Username = "user"
+	basic.AuthCredentials.Password = "pass"
+
+	for idx, test := range []struct {
+		in     *sp.WebhookItem
+		err    error
+		status int
+		body   string
+		json   string
+	}{
+		{nil, errors.New("Update called with nil WebhookItem"), 0, "", ""},
+		{&sp.WebhookItem{Name: "hook", Target: "https://example.com/hook", Events: []string{"bounce"}},
+			errors.New("Update called with blank id"), 0, "", ""},
+		{&sp.WebhookItem{ID: "id", Name: "hook", Target: "https://example.com/hook"},
+			errors.New("Webhook requires at least one event type"), 0, "", ""},
+		{basic, errors.New(`[{"message":"resource not found","code":"1600","description":""}]`), 404, "",
+			`{"errors":[{"message":"resource not found","code":"1600"}]}`},
+		{basic, nil, 200,
+			`{"name":"hook","target":"https://example.com/hook","events":["bounce"],"auth_type":"basic","auth_credentials":{"username":"user","password":"pass"}}`,
+			`{"results":{"id":"id"}}`},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestRequestResponseBuilderFormat(t, "PUT", test.status, sp.WebhooksPathFormat+"/id", test.body, test.json)
+
+		_, err := testClient.WebhookUpdate(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("WebhookUpdate[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("WebhookUpdate[%d] => err %q want %q", idx, err, test.err)
+		}
+	}
+}
+
+func TestWebhookDelete(t *testing.T) {
+	for idx, test := range []struct {
+		in     string
+		err    error
+		status int
+		json   string
+	}{
+		{"", errors.New("Delete called with blank id"), 0, ""},
+		{"id", errors.New(`[{"message":"resource not found","code":"1600","description":""}]`), 404,
+			`{"errors":[{"message":"resource not found","code":"1600"}]}`},
+		{"id", nil, 204, ""},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestResponseBuilderFormat(t, "DELETE", test.status, sp.WebhooksPathFormat+"/id", test.json)
+
+		_, err := testClient.WebhookDelete(test.in)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("WebhookDelete[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("WebhookDelete[%d] => err %q want %q", idx, err, test.err)
+		}
+	}
+}
+
+func TestWebhookValidate(t *testing.T) {
+	for idx, test := range []struct {
+		id     string
+		msg    interface{}
+		err    error
+		status int
+		body   string
+		json   string
+		out    int
+	}{
+		{"", nil, errors.New("Validate called with blank id"), 0, "", "", 0},
+		{"id", nil, errors.New("Unexpected response to Webhook validation (results)"), 200,
+			`{"message":{"msys":{}}}`, `{"foo":{}}`, 0},
+		{"id", nil, errors.New(`[{"message":"resource not found","code":"1600","description":""}]`), 404,
+			`{"message":{"msys":{}}}`, `{"errors":[{"message":"resource not found","code":"1600"}]}`, 0},
+		{"id", []interface{}{}, nil, 200, `{"message":[]}`,
+			`{"results":{"msg":"Test POST to endpoint succeeded","response":{"status":200,"headers":{},"body":""}}}`, 200},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		mockRestRequestResponseBuilderFormat(t, "POST", test.status, sp.WebhooksPathFormat+"/id/validate", test.body, test.json)
+
+		v, _, err := testClient.WebhookValidate(test.id, test.msg)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("WebhookValidate[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("WebhookValidate[%d] => err %q want %q", idx, err, test.err)
+		} else if err == nil && v.Response.Status != test.out {
+			t.Errorf("WebhookValidate[%d] => %+v", idx, v)
+		}
+	}
+}
+
+func TestWebhookBatchStatus(t *testing.T) {
+	var res200 = loadTestFile(t, "test/json/webhook_status_200.json")
+
+	for idx, test := range []struct {
+		id     string
+		limit  int
+		err    error
+		status int
+		json   string
+		failed int
+	}{
+		{"", 0, errors.New("WebhookBatchStatus called with blank id"), 0, "", 0},
+		{"id", 0, errors.New(`[{"message":"resource not found","code":"1600","description":""}]`), 404,
+			`{"errors":[{"message":"resource not found","code":"1600"}]}`, 0},
+		{"id", 10, nil, 200, res200, 1},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		var query string
+		testMux.HandleFunc("/api/v1/webhooks/id/batch-status", func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			w.Header().Set("Content-Type", "application/json; charset=utf8")
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.json))
+		})
+
+		statuses, _, err := testClient.WebhookBatchStatus(test.id, test.limit)
+		if err == nil && test.err != nil || err != nil && test.err == nil {
+			t.Errorf("WebhookBatchStatus[%d] => err %q want %q", idx, err, test.err)
+		} else if err != nil && err.Error() != test.err.Error() {
+			t.Errorf("WebhookBatchStatus[%d] => err %q want %q", idx, err, test.err)
+		} else if err == nil {
+			failed := 0
+			for _, s := range statuses {
+				if s.Failed() {
+					failed++
+				}
+			}
+			if failed != test.failed || query != "limit=10" {
+				t.Errorf("WebhookBatchStatus[%d] => %d failed, query %q", idx, failed, query)
+			}
+		}
+	}
+}
+
+func TestWebhookReconcile(t *testing.T) {
+	var res200 = loadTestFile(t, "test/json/webhooks_200.json")
+	var desired = []sp.WebhookItem{
+		{Name: "Some webhook", Target: "http://client.example.com/some-webhook",
+			Events: []string{"click", "open", "injection", "delivery"}, AuthType: "basic"},
+		{Name: "Example webhook", Target: "https://new.example.com/hook",
+			Events: []string{"delivery", "injection", "open", "click"}, AuthType: "oauth2"},
+		{Name: "New webhook", Target: "https://example.com/new", Events: []string{"bounce"}},
+	}
+	var diff = `~ "Example webhook" (12affc24-f183-11e3-9234-3c15c2c818c2)
+    target: http://client.example.com/example-webhook => https://new.example.com/hook
+- "Another webhook" (123456-abcd-efgh-7890-123445566778)
++ "New webhook" target=https://example.com/new events=bounce
+`
+
+	for idx, test := range []struct {
+		confirm bool
+		calls   []string
+	}{
+		{false, nil},
+		{true, []string{
+			"PUT /api/v1/webhooks/12affc24-f183-11e3-9234-3c15c2c818c2",
+			"DELETE /api/v1/webhooks/123456-abcd-efgh-7890-123445566778",
+			"POST /api/v1/webhooks",
+		}},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		var calls []string
+		handler := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json; charset=utf8")
+			if r.Method == "GET" {
+				w.Write([]byte(res200))
+				return
+			}
+			calls = append(calls, r.Method+" "+r.URL.Path)
+			w.Write([]byte(`{"results":{"id":"new"}}`))
+		}
+		testMux.HandleFunc("/api/v1/webhooks", handler)
+		testMux.HandleFunc("/api/v1/webhooks/", handler)
+
+		var shown string
+		plan, _, err := testClient.WebhookReconcile(desired, func(p sp.WebhookPlan) bool {
+			shown = p.String()
+			return test.confirm
+		})
+		if err != nil {
+			t.Errorf("WebhookReconcile[%d] => err %q", idx, err)
+		} else if shown != diff || plan.String() != diff {
+			t.Errorf("WebhookReconcile[%d] => diff:\n%s\nwant:\n%s", idx, shown, diff)
+		} else if !reflect.DeepEqual(calls, test.calls) {
+			t.Errorf("WebhookReconcile[%d] => calls %q want %q", idx, calls, test.calls)
+		}
+	}
+
+	_, _, err := testClient.WebhookDiff([]sp.WebhookItem{desired[2], desired[2]})
+	if err == nil || err.Error() != `desired webhook name "New webhook" is not unique` {
+		t.Errorf("WebhookDiff => err %q", err)
+	}
+}
+
+func TestWebhookDiffAuth(t *testing.T) {
+	desired := sp.WebhookItem{Name: "Basic webhook", Target: "https://example.com/hook",
+		Events: []string{"delivery"}, AuthType: "basic", AuthToken: "5ebe2294ecd0e0f08eab7690d2a6ee69"}
+	desired.AuthCredentials.Username = "user"
+	desired.AuthCredentials.Password = "secret"
+
+	for idx, test := range []struct {
+		auth   string
+		fields []string
+	}{
+		// Auth details the list omits aren't reported as changed.
+		{``, nil},
+		{`"auth_token":"","auth_credentials":{}`, nil},
+		{`"auth_token":"5ebe2294ecd0e0f08eab7690d2a6ee69","auth_credentials":{"username":"user","password":"secret"}`, nil},
+		{`"auth_token":"0000","auth_credentials":{"username":"user"}`, []string{"auth_token"}},
+		{`"auth_credentials":{"username":"admin"}`, []string{"auth_credentials"}},
+	} {
+		testSetup(t)
+		defer testTeardown()
+		item := `{"id":"1","name":"Basic webhook","target":"https://example.com/hook","events":["delivery"],"auth_type":"basic"`
+		if test.auth != "" {
+			item += "," + test.auth
+		}
+		mockRestBuilderFormat(t, "GET", sp.WebhooksPathFormat, `{"results":[`+item+`}]}`)
+
+		plan, res, err := testClient.WebhookDiff([]sp.WebhookItem{desired})
+		if err != nil {
+			testFailVerbose(t, res, "WebhookDiff[%d] => err %q", idx, err)
+		} else if test.fields == nil && len(plan) != 0 {
+			t.Errorf("WebhookDiff[%d] => plan:\n%s\nwant no changes", idx, plan)
+		} else if test.fields != nil && (len(plan) != 1 || !reflect.DeepEqual(plan[0].Fields, test.fields)) {
+			t.Errorf("WebhookDiff[%d] => plan:\n%s\nwant fields %q", idx, plan, test.fields)
+		}
+	}
+}